@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/agent"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// newAgentClient создает клиента агента, слушающего на сокете из настроек
+func newAgentClient() pb.AgentServiceClient {
+	connection, err := agent.Dial(viper.GetString("agent.socket"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create agent connection")
+	}
+	return pb.NewAgentServiceClient(connection)
+}
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Manage agent holding the unlocked master key",
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var lockAgentCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Wipe master key from agent memory",
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := newAgentClient().Lock(context.Background(), &pb.LockRequest{}); err != nil {
+			log.Fatal().Msgf("Failed to lock agent: %v", err)
+		}
+
+		fmt.Println("Agent locked")
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(lockAgentCmd)
+}
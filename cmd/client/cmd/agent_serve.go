@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/agent"
+)
+
+var serveAgentCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run locked agent in foreground",
+	Run: func(cmd *cobra.Command, args []string) {
+		idleTimeout, err := cmd.Flags().GetDuration("idle-timeout")
+		if err != nil {
+			log.Fatal().Msgf("Error reading idle timeout: %v", err)
+		}
+		if !cmd.Flags().Changed("idle-timeout") {
+			idleTimeout = viper.GetDuration("agent.idle_timeout")
+		}
+
+		signal.Ignore(syscall.SIGHUP)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		listener, err := agent.Listen(viper.GetString("agent.socket"))
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to listen agent socket")
+		}
+
+		if err = agent.Serve(ctx, listener, agent.New(idleTimeout, stop)); err != nil {
+			log.Fatal().Err(err).Msg("Error on agent Serve")
+		}
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(serveAgentCmd)
+
+	serveAgentCmd.Flags().Duration("idle-timeout", 0, "Lock agent after period without requests, 0 disables auto-lock")
+}
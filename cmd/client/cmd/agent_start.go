@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+const agentStartTimeout = 5 * time.Second
+
+func autoLockInfo(idleTimeout time.Duration) string {
+	if idleTimeout <= 0 {
+		return "auto-lock disabled"
+	}
+	return fmt.Sprintf("auto-lock after %v of inactivity", idleTimeout)
+}
+
+var startAgentCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start agent in background and unlock it",
+	Run: func(cmd *cobra.Command, args []string) {
+		idleTimeout, err := cmd.Flags().GetDuration("idle-timeout")
+		if err != nil {
+			log.Fatal().Msgf("Error reading idle timeout: %v", err)
+		}
+		if !cmd.Flags().Changed("idle-timeout") {
+			idleTimeout = viper.GetDuration("agent.idle_timeout")
+		}
+
+		agentClient := newAgentClient()
+		if _, err = agentClient.Status(context.Background(), &pb.StatusRequest{}); err == nil {
+			log.Fatal().Msg("Agent is already running")
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to find client executable")
+		}
+		daemon := exec.Command(executable, "agent", "serve",
+			"--agent-socket", viper.GetString("agent.socket"),
+			"--idle-timeout", idleTimeout.String(),
+		)
+		if err = daemon.Start(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to start agent")
+		}
+		if err = daemon.Process.Release(); err != nil {
+			log.Warn().Err(err).Msg("Failed to release agent process")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), agentStartTimeout)
+		defer cancel()
+		for {
+			if _, err = agentClient.Status(ctx, &pb.StatusRequest{}); err == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				log.Fatal().Err(err).Msg("Agent has not started")
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+
+		unlockAgent(agentClient)
+		fmt.Printf("Agent started, %s\n", autoLockInfo(idleTimeout))
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(startAgentCmd)
+
+	startAgentCmd.Flags().Duration("idle-timeout", 0, "Lock agent after period without requests, 0 disables auto-lock")
+}
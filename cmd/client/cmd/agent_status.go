@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var statusAgentCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show agent status",
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := newAgentClient().Status(context.Background(), &pb.StatusRequest{})
+		if err != nil {
+			log.Fatal().Msgf("Agent is not running: %v", err)
+		}
+
+		state := "unlocked"
+		if resp.GetLocked() {
+			state = "locked"
+		}
+		fmt.Printf("Agent is %s, %s\n", state, autoLockInfo(time.Duration(resp.GetIdleTimeoutSeconds())*time.Second))
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(statusAgentCmd)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var stopAgentCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Lock and stop agent",
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := newAgentClient().Stop(context.Background(), &pb.StopRequest{}); err != nil {
+			log.Fatal().Msgf("Failed to stop agent: %v", err)
+		}
+
+		fmt.Println("Agent stopped")
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(stopAgentCmd)
+}
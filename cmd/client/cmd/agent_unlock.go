@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/prompt"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+)
+
+// unlockAgent запрашивает мастер-пароль и разблокирует агент. Агент проверяет пароль по ключу данных
+// сохраненного токена доступа профиля; если токена еще нет, пароль запрашивается повторно
+func unlockAgent(agentClient pb.AgentServiceClient) {
+	check, err := token.NewEncryptedFileStorage(tokenPath(), nil).EncryptedDataKey()
+	if err != nil && !errors.Is(err, token.ErrNotFound) {
+		log.Fatal().Err(err).Msg("Failed to read access token")
+	}
+
+	password, err := prompt.Default.Secret("Master password", len(check) == 0)
+	if err != nil {
+		log.Fatal().Msgf("Error reading master password: %v", err)
+	}
+
+	request := &pb.UnlockRequest{Password: password, Check: check}
+	if _, err = agentClient.Unlock(context.Background(), request); err != nil {
+		log.Fatal().Msgf("Failed to unlock agent: %v", err)
+	}
+}
+
+var unlockAgentCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Unlock running agent with master password",
+	Run: func(cmd *cobra.Command, args []string) {
+		unlockAgent(newAgentClient())
+		fmt.Println("Agent unlocked")
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(unlockAgentCmd)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// auditRecord запись журнала аудита в формате JSON Lines
+type auditRecord struct {
+	Time          time.Time `json:"time"`
+	Action        string    `json:"action"`
+	SecretName    string    `json:"secret_name,omitempty"`
+	SecretVersion string    `json:"secret_version,omitempty"`
+	Target        string    `json:"target,omitempty"`
+	Result        string    `json:"result"`
+	PeerAddress   string    `json:"peer_address,omitempty"`
+	SessionID     string    `json:"session_id,omitempty"`
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show audit log of access to user private data",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, err := readTimeFlag(cmd, "from")
+		if err != nil {
+			log.Fatal().Msgf("Error reading start of time range: %v", err)
+		}
+
+		to, err := readTimeFlag(cmd, "to")
+		if err != nil {
+			log.Fatal().Msgf("Error reading end of time range: %v", err)
+		}
+
+		jsonLines, err := cmd.Flags().GetBool("json-lines")
+		if err != nil {
+			log.Fatal().Msgf("Error reading output format: %v", err)
+		}
+
+		auditClient := pb.NewAuditServiceClient(newAuthorizedConnection())
+		resp, err := auditClient.ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+			From: from,
+			To:   to,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list audit events")
+		}
+
+		records := make([]auditRecord, 0, len(resp.GetEvents()))
+		for _, event := range resp.GetEvents() {
+			records = append(records, auditRecord{
+				Time:          event.GetCreatedAt().AsTime().Local(),
+				Action:        event.GetAction(),
+				SecretName:    event.GetSecretName(),
+				SecretVersion: event.GetSecretVersion(),
+				Target:        event.GetTarget(),
+				Result:        codes.Code(event.GetCode()).String(),
+				PeerAddress:   event.GetPeerAddress(),
+				SessionID:     event.GetSessionId(),
+			})
+		}
+
+		if jsonLines {
+			encoder := json.NewEncoder(os.Stdout)
+			for _, record := range records {
+				if err = encoder.Encode(record); err != nil {
+					log.Fatal().Err(err).Msg("Failed to encode audit event")
+				}
+			}
+			return
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "TIME\tACTION\tSECRET\tVERSION\tTARGET\tRESULT\tPEER\tSESSION")
+		for _, r := range records {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				r.Time.Format(time.RFC3339), r.Action, r.SecretName, r.SecretVersion, r.Target,
+				r.Result, r.PeerAddress, r.SessionID)
+		}
+		if err = writer.Flush(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print audit events")
+		}
+	},
+}
+
+// readTimeFlag читает момент времени в формате RFC3339 или YYYY-MM-DD
+func readTimeFlag(cmd *cobra.Command, name string) (*timestamppb.Timestamp, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+			return nil, err
+		}
+	}
+	return timestamppb.New(t), nil
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().String("from", "", "Start of time range, RFC3339 or YYYY-MM-DD")
+	auditCmd.Flags().String("to", "", "End of time range, RFC3339 or YYYY-MM-DD")
+	auditCmd.Flags().Bool("json-lines", false, "Export events as JSON Lines")
+}
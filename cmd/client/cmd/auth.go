@@ -5,7 +5,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
@@ -21,7 +20,7 @@ var authCmd = &cobra.Command{
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		connection, err := grpc.Dial(
 			viper.GetString("grpc.address"),
-			grpc.WithTransportCredentials(insecure.NewCredentials()))
+			grpc.WithTransportCredentials(transportCredentials()))
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to create client connection")
 		}
@@ -20,7 +20,7 @@ var loginCmd = &cobra.Command{
 			log.Fatal().Err(err).Msg("Failed to read email")
 		}
 
-		password, err := cmd.Flags().GetString("password")
+		password, err := readSensitiveFlag(cmd, "password", "Password", false)
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to read password")
 		}
@@ -34,10 +34,10 @@ var loginCmd = &cobra.Command{
 			return
 		}
 
-		if err := tokenStorage.Save(resp.AccessToken); err != nil {
+		if err := newTokenStorage().Save(resp.AccessToken); err != nil {
 			log.Fatal().Err(err).Msg("Failed to store access token")
 		}
-		fmt.Printf("Access Token: %s\n", resp.AccessToken)
+		fmt.Printf("Access token saved for profile %s\n", currentProfile())
 	},
 }
 
@@ -48,8 +48,5 @@ func init() {
 	if err := loginCmd.MarkFlagRequired("email"); err != nil {
 		log.Error().Err(err)
 	}
-	loginCmd.Flags().StringP("password", "p", "", "User password")
-	if err := loginCmd.MarkFlagRequired("password"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(loginCmd, "password", "p", "User password")
 }
@@ -20,7 +20,7 @@ var registerCmd = &cobra.Command{
 			log.Fatal().Err(err).Msg("Failed to read email")
 		}
 
-		password, err := cmd.Flags().GetString("password")
+		password, err := readSensitiveFlag(cmd, "password", "Password", true)
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to read password")
 		}
@@ -34,7 +34,10 @@ var registerCmd = &cobra.Command{
 			return
 		}
 
-		fmt.Printf("Access Token: %s\n", resp.AccessToken)
+		if err := newTokenStorage().Save(resp.AccessToken); err != nil {
+			log.Fatal().Err(err).Msg("Failed to store access token")
+		}
+		fmt.Printf("Access token saved for profile %s\n", currentProfile())
 	},
 }
 
@@ -46,8 +49,5 @@ func init() {
 		log.Error().Err(err)
 	}
 
-	registerCmd.Flags().StringP("password", "p", "", "User password")
-	if err := registerCmd.MarkFlagRequired("password"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(registerCmd, "password", "p", "User password")
 }
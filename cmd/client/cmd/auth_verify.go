@@ -15,7 +15,7 @@ var verifyCmd = &cobra.Command{
 	Use:   "verify",
 	Short: "Verifies access token",
 	Run: func(cmd *cobra.Command, args []string) {
-		token, err := cmd.Flags().GetString("token")
+		token, err := readSensitiveFlag(cmd, "token", "Access token", false)
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to read token")
 		}
@@ -36,8 +36,5 @@ var verifyCmd = &cobra.Command{
 func init() {
 	authCmd.AddCommand(verifyCmd)
 
-	verifyCmd.Flags().StringP("token", "t", "", "Access token")
-	if err := verifyCmd.MarkFlagRequired("token"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(verifyCmd, "token", "t", "Access token")
 }
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/dockercredential"
+)
+
+// dockerCredentialFail выводит ошибку в stdout, где ее ожидает Docker, и завершает клиент
+func dockerCredentialFail(err error) {
+	dockercredential.WriteError(os.Stdout, err)
+	os.Exit(1)
+}
+
+var dockerCredentialCmd = &cobra.Command{
+	Use:   "docker-credential",
+	Short: "Docker credential helper backed by the vault",
+	Long: "Docker credential helper backed by the vault. Registry credentials are stored as " +
+		"credentials secrets named docker:<server URL>. When the client is run as " +
+		dockercredential.HelperName + " (e.g. via symlink), the arguments are passed to this command, " +
+		"so the helper is enabled with \"credsStore\": \"gophkeeper\" in ~/.docker/config.json",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dockerCredentialCmd)
+
+	// Docker запускает помощник как docker-credential-<name> <action>
+	if filepath.Base(os.Args[0]) == dockercredential.HelperName {
+		rootCmd.SetArgs(append([]string{dockerCredentialCmd.Name()}, os.Args[1:]...))
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/dockercredential"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var dockerCredentialEraseCmd = &cobra.Command{
+	Use:   "erase",
+	Short: "Move credentials of the registry URL read from stdin to trash",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serverURL, err := dockercredential.ReadServerURL(os.Stdin)
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+		name := dockercredential.SecretName(serverURL)
+
+		_, stored, err := credentialsSecret(name)
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+		if stored == nil {
+			dockerCredentialFail(dockercredential.ErrCredentialsNotFound)
+		}
+
+		if _, err = secretClient.DeleteSecret(context.Background(), &pb.DeleteSecretRequest{Name: name}); err != nil {
+			dockerCredentialFail(err)
+		}
+	},
+}
+
+func init() {
+	dockerCredentialCmd.AddCommand(dockerCredentialEraseCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/dockercredential"
+)
+
+var dockerCredentialGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Return stored credentials for the registry URL read from stdin",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serverURL, err := dockercredential.ReadServerURL(os.Stdin)
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+
+		_, stored, err := credentialsSecret(dockercredential.SecretName(serverURL))
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+		if stored == nil {
+			dockerCredentialFail(dockercredential.ErrCredentialsNotFound)
+		}
+
+		if err = json.NewEncoder(os.Stdout).Encode(dockercredential.FromSecret(serverURL, *stored)); err != nil {
+			dockerCredentialFail(err)
+		}
+	},
+}
+
+func init() {
+	dockerCredentialCmd.AddCommand(dockerCredentialGetCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/dockercredential"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// listDockerCredentials возвращает имена пользователей всех сохраненных реестров по их адресам
+func listDockerCredentials() (map[string]string, error) {
+	resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[string]string)
+	for _, info := range resp.GetSecrets() {
+		serverURL, ok := dockercredential.ServerURL(info.GetName())
+		if !ok {
+			continue
+		}
+		secret, err := decryptSecret(info.GetContent(), info.GetDataKey())
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", info.GetName(), err)
+		}
+		credentials, ok := secret.(models.Credentials)
+		if !ok {
+			log.Debug().Msgf("Secret %s is %s, not credentials", info.GetName(), secret.Type())
+			continue
+		}
+		usernames[serverURL] = credentials.Login
+	}
+	return usernames, nil
+}
+
+var dockerCredentialListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored registry URLs with usernames as JSON",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		usernames, err := listDockerCredentials()
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+		if err = json.NewEncoder(os.Stdout).Encode(usernames); err != nil {
+			dockerCredentialFail(err)
+		}
+	},
+}
+
+func init() {
+	dockerCredentialCmd.AddCommand(dockerCredentialListCmd)
+}
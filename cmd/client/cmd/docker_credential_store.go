@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/dockercredential"
+)
+
+var dockerCredentialStoreCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Store registry credentials read from stdin as JSON",
+	Long: "Store registry credentials read from stdin as JSON. " +
+		"Existing secret is updated only if the credentials changed",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := dockercredential.ReadCredentials(os.Stdin)
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+		name := dockercredential.SecretName(credentials.ServerURL)
+
+		resp, stored, err := credentialsSecret(name)
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+		secret := credentials.Credentials()
+		switch {
+		case stored == nil:
+			_, err = createSecret(name, nil, secret)
+		case *stored != secret:
+			_, err = updateSecretVersion(name, resp.GetVersion(), resp.GetDataKey(), secret)
+		}
+		if err != nil {
+			dockerCredentialFail(err)
+		}
+	},
+}
+
+func init() {
+	dockerCredentialCmd.AddCommand(dockerCredentialStoreCmd)
+}
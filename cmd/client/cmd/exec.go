@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/secretref"
+)
+
+// forwardedSignals сигналы, передаваемые дочернему процессу
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT}
+
+// readEnvMappings читает сопоставления переменных окружения из флагов --env-file и --env
+func readEnvMappings(cmd *cobra.Command) ([]secretref.Mapping, error) {
+	var mappings []secretref.Mapping
+
+	path, err := cmd.Flags().GetString("env-file")
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		if mappings, err = secretref.ReadMappings(file); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := cmd.Flags().GetStringArray("env")
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		mapping, err := secretref.ParseMapping(value)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+// runCommand запускает команду, передавая ей полученные сигналы, и возвращает код завершения
+func runCommand(args, env []string) (int, error) {
+	child := exec.Command(args[0], args[1:]...)
+	child.Env = env
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		return 0, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if err := child.Process.Signal(sig); err != nil {
+					log.Debug().Err(err).Msgf("Failed to forward signal %v", sig)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := child.Wait()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return 0, err
+	}
+
+	state := child.ProcessState
+	if status, ok := state.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal()), nil
+	}
+	return state.ExitCode(), nil
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec [flags] -- command [args...]",
+	Short: "Run command with secrets injected as environment variables",
+	Args:  cobra.MinimumNArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		mappings, err := readEnvMappings(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading env mappings: %v", err)
+		}
+
+		env := os.Environ()
+		resolver := secretref.NewResolver(fetchSecret)
+		for _, mapping := range mappings {
+			value, err := resolver.Resolve(mapping.Reference)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to resolve %s", mapping.Variable)
+			}
+			env = append(env, mapping.Variable+"="+value)
+		}
+
+		code, err := runCommand(args, env)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to run command")
+		}
+		os.Exit(code)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.Flags().SetInterspersed(false)
+	execCmd.Flags().StringArray("env", nil, "Environment variable mapping VARIABLE=name#field, can be repeated")
+	execCmd.Flags().String("env-file", "", "File with environment variable mappings, one VARIABLE=name#field per line")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/archive"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/prompt"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/utils"
+)
+
+// readExportPassphrase запрашивает парольную фразу архива, confirm требует повторного ввода
+func readExportPassphrase(confirm bool) ([]byte, error) {
+	passphrase, err := prompt.Default.Secret("Export passphrase", confirm)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return nil, archive.ErrEmptyPassphrase
+	}
+	return []byte(passphrase), nil
+}
+
+// exportAttachments загружает и расшифровывает вложения заметки name для сохранения в архиве
+func exportAttachments(name string, note models.Note) ([]archive.Attachment, error) {
+	attachments := make([]archive.Attachment, 0, len(note.Attachments))
+	for _, attachment := range note.Attachments {
+		data, err := fetchAttachment(name, attachment)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %s: %w", attachment.Name, err)
+		}
+		attachments = append(attachments, archive.Attachment{Name: attachment.Name, Data: data})
+	}
+	return attachments, nil
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all secrets into archive encrypted with export passphrase",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		outputPath, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Fatal().Msgf("Error reading output path: %v", err)
+		}
+
+		skipAttachments, err := cmd.Flags().GetBool("skip-attachments")
+		if err != nil {
+			log.Fatal().Msgf("Error reading skip attachments flag: %v", err)
+		}
+
+		resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list secrets")
+		}
+
+		items := make([]archive.Item, 0, len(resp.GetSecrets()))
+		attachmentCount := 0
+		for _, info := range resp.GetSecrets() {
+			secret, err := decryptSecret(info.GetContent(), info.GetDataKey())
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to decrypt secret %s", info.GetName())
+			}
+
+			// Ссылки на вложения действительны только для секрета на сервере, поэтому в архив
+			// сохраняется их содержимое, а при восстановлении вложения загружаются заново
+			var attachments []archive.Attachment
+			if note, ok := secret.(models.Note); ok && len(note.Attachments) > 0 {
+				if skipAttachments {
+					log.Warn().Msgf("Attachments of note %s are skipped", info.GetName())
+				} else if attachments, err = exportAttachments(info.GetName(), note); err != nil {
+					log.Fatal().Err(err).Msgf("Failed to export attachments of note %s", info.GetName())
+				}
+				attachmentCount += len(attachments)
+				note.Attachments = nil
+				secret = note
+			}
+
+			data, err := models.EncodeSecret(secret)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to encode secret %s", info.GetName())
+			}
+			items = append(items, archive.Item{
+				Name:        info.GetName(),
+				Version:     info.GetVersion(),
+				Data:        data,
+				Attachments: attachments,
+			})
+		}
+
+		passphrase, err := readExportPassphrase(true)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read export passphrase")
+		}
+
+		var buf bytes.Buffer
+		if err = archive.Write(&buf, passphrase, items); err != nil {
+			log.Fatal().Err(err).Msg("Failed to create archive")
+		}
+		if err = utils.WritePrivateFile(outputPath, buf.Bytes()); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write archive")
+		}
+		fmt.Printf("Exported %d secrets with %d attachments to %s\n", len(items), attachmentCount, outputPath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP("output", "o", "", "Archive file")
+	if err := exportCmd.MarkFlagRequired("output"); err != nil {
+		log.Error().Err(err)
+	}
+	exportCmd.Flags().Bool("skip-attachments", false, "Do not download note attachments into archive")
+}
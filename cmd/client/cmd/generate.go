@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/passgen"
+)
+
+// addGeneratorFlags добавляет флаги параметров генерации пароля
+func addGeneratorFlags(flags *pflag.FlagSet) {
+	flags.Int("length", passgen.DefaultOptions.Length, "Generated password length")
+	flags.Bool("no-lower", false, "Exclude lowercase letters from generated password")
+	flags.Bool("no-upper", false, "Exclude uppercase letters from generated password")
+	flags.Bool("no-digits", false, "Exclude digits from generated password")
+	flags.Bool("no-symbols", false, "Exclude symbols from generated password")
+	flags.Bool("exclude-ambiguous", false, "Exclude ambiguous characters like l, 1, O and 0")
+	flags.Bool("passphrase", false, "Generate passphrase of dictionary words instead of password")
+	flags.Int("words", passgen.DefaultPassphraseOptions.Words, "Passphrase word count")
+	flags.String("separator", passgen.DefaultPassphraseOptions.Separator, "Passphrase word separator")
+	flags.Bool("capitalize", false, "Capitalize passphrase words")
+}
+
+// generatePassword генерирует пароль или парольную фразу по флагам addGeneratorFlags
+// и возвращает его вместе с оценкой энтропии в битах
+func generatePassword(flags *pflag.FlagSet) (string, float64, error) {
+	passphrase, err := flags.GetBool("passphrase")
+	if err != nil {
+		return "", 0, err
+	}
+	if passphrase {
+		options := passgen.PassphraseOptions{}
+		if options.Words, err = flags.GetInt("words"); err != nil {
+			return "", 0, err
+		}
+		if options.Separator, err = flags.GetString("separator"); err != nil {
+			return "", 0, err
+		}
+		if options.Capitalize, err = flags.GetBool("capitalize"); err != nil {
+			return "", 0, err
+		}
+		password, err := passgen.Passphrase(options)
+		return password, options.Entropy(), err
+	}
+
+	options := passgen.Options{}
+	if options.Length, err = flags.GetInt("length"); err != nil {
+		return "", 0, err
+	}
+	for _, class := range []struct {
+		flag    string
+		enabled *bool
+	}{
+		{"no-lower", &options.Lower},
+		{"no-upper", &options.Upper},
+		{"no-digits", &options.Digits},
+		{"no-symbols", &options.Symbols},
+	} {
+		excluded, err := flags.GetBool(class.flag)
+		if err != nil {
+			return "", 0, err
+		}
+		*class.enabled = !excluded
+	}
+	if options.ExcludeAmbiguous, err = flags.GetBool("exclude-ambiguous"); err != nil {
+		return "", 0, err
+	}
+	password, err := passgen.Generate(options)
+	return password, options.Entropy(), err
+}
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate password or passphrase",
+	Run: func(cmd *cobra.Command, args []string) {
+		password, entropy, err := generatePassword(cmd.Flags())
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to generate password")
+		}
+
+		fmt.Println(password)
+		fmt.Fprintf(os.Stderr, "Estimated entropy: %.0f bits\n", entropy)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+
+	addGeneratorFlags(generateCmd.Flags())
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/gitcredential"
+)
+
+// readGitCredential читает запрос Git со стандартного ввода и возвращает его вместе с названием секрета
+func readGitCredential() (gitcredential.Credential, string, error) {
+	request, err := gitcredential.Read(os.Stdin)
+	if err != nil {
+		return gitcredential.Credential{}, "", err
+	}
+	name, err := request.SecretName()
+	return request, name, err
+}
+
+var gitCredentialCmd = &cobra.Command{
+	Use:   "git-credential",
+	Short: "Git credential helper backed by the vault",
+	Long: "Git credential helper backed by the vault. Credentials are stored as credentials secrets " +
+		"named git:protocol://host or git:protocol://host/path when credential.useHttpPath is enabled. " +
+		"Configure with: git config --global credential.helper '!gophkeeper-cli git-credential'",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gitCredentialCmd)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var gitCredentialEraseCmd = &cobra.Command{
+	Use:   "erase",
+	Short: "Move credentials rejected by Git to trash",
+	Long: "Move credentials rejected by Git, read from stdin, to trash. " +
+		"Secret is kept if it stores other username or password than the rejected ones",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		request, name, err := readGitCredential()
+		if err != nil {
+			log.Fatal().Msgf("Error reading git credential request: %v", err)
+		}
+
+		_, stored, err := credentialsSecret(name)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get secret: %v", err)
+		}
+		if stored == nil || !request.Matches(*stored) {
+			return
+		}
+
+		if _, err = secretClient.DeleteSecret(context.Background(), &pb.DeleteSecretRequest{Name: name}); err != nil {
+			log.Fatal().Msgf("Failed to delete secret: %v", err)
+		}
+	},
+}
+
+func init() {
+	gitCredentialCmd.AddCommand(gitCredentialEraseCmd)
+}
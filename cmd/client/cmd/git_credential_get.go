@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/gitcredential"
+)
+
+var gitCredentialGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Return stored credentials for the Git request read from stdin",
+	Long: "Return stored credentials for the Git request read from stdin. Secret with repository path " +
+		"is looked up first, then secret of the host. Nothing is printed if credentials are not found",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		request, name, err := readGitCredential()
+		if err != nil {
+			log.Fatal().Msgf("Error reading git credential request: %v", err)
+		}
+
+		names := []string{name}
+		if request.Path != "" {
+			hostRequest := request
+			hostRequest.Path = ""
+			hostName, err := hostRequest.SecretName()
+			if err != nil {
+				log.Fatal().Msgf("Error reading git credential request: %v", err)
+			}
+			names = append(names, hostName)
+		}
+
+		for _, name := range names {
+			_, stored, err := credentialsSecret(name)
+			if err != nil {
+				log.Fatal().Msgf("Failed to get secret: %v", err)
+			}
+			if stored == nil || request.Username != "" && request.Username != stored.Login {
+				continue
+			}
+			if err = gitcredential.Write(os.Stdout, request.WithSecret(*stored)); err != nil {
+				log.Fatal().Msgf("Failed to write git credentials: %v", err)
+			}
+			return
+		}
+	},
+}
+
+func init() {
+	gitCredentialCmd.AddCommand(gitCredentialGetCmd)
+}
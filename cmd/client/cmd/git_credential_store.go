@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var gitCredentialStoreCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Store credentials accepted by Git",
+	Long: "Store credentials accepted by Git, read from stdin. " +
+		"Existing secret is updated only if the credentials changed",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		request, name, err := readGitCredential()
+		if err != nil {
+			log.Fatal().Msgf("Error reading git credential request: %v", err)
+		}
+		if request.Username == "" || request.Password == "" {
+			return
+		}
+
+		resp, stored, err := credentialsSecret(name)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get secret: %v", err)
+		}
+		secret := request.Secret()
+		switch {
+		case stored == nil:
+			_, err = createSecret(name, nil, secret)
+		case *stored != secret:
+			_, err = updateSecretVersion(name, resp.GetVersion(), resp.GetDataKey(), secret)
+		}
+		if err != nil {
+			log.Fatal().Msgf("Failed to store secret: %v", err)
+		}
+	},
+}
+
+func init() {
+	gitCredentialCmd.AddCommand(gitCredentialStoreCmd)
+}
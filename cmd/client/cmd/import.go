@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/importer"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// listSecretNames возвращает названия собственных секретов пользователя или секретов коллекции
+func listSecretNames(collection *pb.CollectionRef) ([]string, error) {
+	resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{
+		Collection: collection,
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(resp.GetSecrets()))
+	for _, info := range resp.GetSecrets() {
+		names = append(names, info.GetName())
+	}
+	return names, nil
+}
+
+// printImportPlan выводит запланированные действия с импортируемыми секретами
+func printImportPlan(actions []importer.Action) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ACTION\tNAME\tTYPE")
+	for _, action := range actions {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", action.Kind, action.Name, action.Entry.Secret.Type())
+	}
+	return writer.Flush()
+}
+
+// applyImportPlan создает и перезаписывает секреты согласно плану импорта
+func applyImportPlan(actions []importer.Action, collection *pb.CollectionRef) {
+	counts := make(map[importer.ActionKind]int)
+	for _, action := range actions {
+		var err error
+		switch action.Kind {
+		case importer.ActionCreate:
+			_, err = createSecret(action.Name, collection, action.Entry.Secret)
+		case importer.ActionUpdate:
+			_, err = updateSecret(action.Name, "", collection, action.Entry.Secret)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Failed to import secret %s", action.Name)
+		}
+		counts[action.Kind]++
+	}
+	fmt.Printf("Created %d, overwritten %d, skipped %d secrets\n",
+		counts[importer.ActionCreate], counts[importer.ActionUpdate], counts[importer.ActionSkip])
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [flags] file",
+	Short: "Import secrets from other password managers",
+	Args:  cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Fatal().Msgf("Error reading import format: %v", err)
+		}
+
+		policyName, err := cmd.Flags().GetString("on-duplicate")
+		if err != nil {
+			log.Fatal().Msgf("Error reading duplicate policy: %v", err)
+		}
+		policy, err := importer.ParsePolicy(policyName)
+		if err != nil {
+			log.Fatal().Msgf("Error reading duplicate policy: %v", err)
+		}
+
+		apply, err := cmd.Flags().GetBool("apply")
+		if err != nil {
+			log.Fatal().Msgf("Error reading apply flag: %v", err)
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open import file")
+		}
+		defer file.Close()
+
+		result, err := importer.Parse(importer.Format(format), file)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to parse import file")
+		}
+		for _, warning := range result.Warnings {
+			log.Warn().Msg(warning)
+		}
+
+		existing, err := listSecretNames(collection)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list secrets")
+		}
+
+		actions := importer.Plan(result.Entries, existing, policy)
+		if err = printImportPlan(actions); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print import plan")
+		}
+
+		if !apply {
+			fmt.Println("Dry run, no secrets were changed. Use --apply to import")
+			return
+		}
+		applyImportPlan(actions, collection)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().String("format", "", "Import file format: bitwarden|keepass|csv")
+	if err := importCmd.MarkFlagRequired("format"); err != nil {
+		log.Error().Err(err)
+	}
+	importCmd.Flags().String("on-duplicate", string(importer.PolicySkip), "Action for existing secret names: skip|overwrite|rename")
+	importCmd.Flags().Bool("apply", false, "Import secrets, by default only the import plan is printed")
+	importCmd.Flags().String("org", "", "Organization of the secret collection")
+	importCmd.Flags().String("collection", "", "Organization collection to import secrets into")
+}
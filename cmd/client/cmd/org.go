@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
+)
+
+var roles = map[string]pb.Role{
+	"owner":     pb.Role_ROLE_OWNER,
+	"admin":     pb.Role_ROLE_ADMIN,
+	"member":    pb.Role_ROLE_MEMBER,
+	"read-only": pb.Role_ROLE_READ_ONLY,
+}
+
+func roleName(role pb.Role) string {
+	for name, r := range roles {
+		if r == role {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// readCollectionRef читает флаги --org и --collection; возвращает nil, если коллекция не указана
+func readCollectionRef(cmd *cobra.Command) (*pb.CollectionRef, error) {
+	organization, err := cmd.Flags().GetString("org")
+	if err != nil {
+		return nil, err
+	}
+	collection, err := cmd.Flags().GetString("collection")
+	if err != nil {
+		return nil, err
+	}
+	if organization == "" && collection == "" {
+		return nil, nil
+	}
+	if organization == "" || collection == "" {
+		return nil, errors.New("both --org and --collection must be specified")
+	}
+	return &pb.CollectionRef{
+		Organization: organization,
+		Collection:   collection,
+	}, nil
+}
+
+// collectionKey загружает с сервера ключ коллекции и расшифровывает его закрытым ключом пользователя
+func collectionKey(ref *pb.CollectionRef) ([]byte, error) {
+	resp, err := organizationClient.GetCollectionKey(context.Background(), &pb.GetCollectionKeyRequest{
+		Organization: ref.GetOrganization(),
+		Collection:   ref.GetCollection(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return x25519.Open(privateKey, resp.GetWrappedKey())
+}
+
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage organizations, teams and shared collections",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orgCmd)
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var collectionOrgCmd = &cobra.Command{
+	Use:   "collection",
+	Short: "Manage organization collections of secrets",
+}
+
+func init() {
+	orgCmd.AddCommand(collectionOrgCmd)
+
+	collectionOrgCmd.PersistentFlags().String("org", "", "Organization name")
+	if err := collectionOrgCmd.MarkPersistentFlagRequired("org"); err != nil {
+		log.Error().Err(err)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
+)
+
+var createCollectionOrgCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create collection of secrets in organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		collection, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading collection name: %v", err)
+		}
+
+		keyPair, err := secretClient.GetKeyPair(context.Background(), &pb.GetKeyPairRequest{})
+		if err != nil {
+			log.Fatal().Msgf("Failed to get key pair, run `secret keys init` first: %v", err)
+		}
+
+		key, err := gcm.NewKey()
+		if err != nil {
+			log.Fatal().Msgf("Failed to generate collection key: %v", err)
+		}
+		wrappedKey, err := x25519.Seal(keyPair.GetPublicKey(), key)
+		if err != nil {
+			log.Fatal().Msgf("Failed to encrypt collection key: %v", err)
+		}
+
+		_, err = organizationClient.CreateCollection(context.Background(), &pb.CreateCollectionRequest{
+			Organization: organization,
+			Collection:   collection,
+			WrappedKey:   wrappedKey,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to create collection: %v", err)
+		}
+
+		fmt.Printf("Collection %s created successfully\n", collection)
+	},
+}
+
+func init() {
+	collectionOrgCmd.AddCommand(createCollectionOrgCmd)
+
+	createCollectionOrgCmd.Flags().String("name", "", "Collection name")
+	if err := createCollectionOrgCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
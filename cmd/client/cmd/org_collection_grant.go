@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var grantCollectionOrgCmd = &cobra.Command{
+	Use:   "grant",
+	Short: "Grant team access to collection",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		collection, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading collection name: %v", err)
+		}
+
+		team, err := cmd.Flags().GetString("team")
+		if err != nil {
+			log.Fatal().Msgf("Error reading team name: %v", err)
+		}
+
+		_, err = organizationClient.GrantCollection(context.Background(), &pb.GrantCollectionRequest{
+			Organization: organization,
+			Collection:   collection,
+			Team:         team,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to grant collection: %v", err)
+		}
+
+		fmt.Printf("Team %s granted access to collection %s\n", team, collection)
+		fmt.Println("Run `org collection sync-keys` to share collection key with team members")
+	},
+}
+
+func init() {
+	collectionOrgCmd.AddCommand(grantCollectionOrgCmd)
+
+	grantCollectionOrgCmd.Flags().String("name", "", "Collection name")
+	if err := grantCollectionOrgCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	grantCollectionOrgCmd.Flags().String("team", "", "Team name")
+	if err := grantCollectionOrgCmd.MarkFlagRequired("team"); err != nil {
+		log.Error().Err(err)
+	}
+}
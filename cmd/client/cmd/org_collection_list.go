@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var listCollectionOrgCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List organization collections available to the user",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		resp, err := organizationClient.ListCollections(context.Background(), &pb.ListCollectionsRequest{
+			Organization: organization,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to list collections: %v", err)
+		}
+
+		for _, name := range resp.GetCollections() {
+			fmt.Println(name)
+		}
+	},
+}
+
+func init() {
+	collectionOrgCmd.AddCommand(listCollectionOrgCmd)
+}
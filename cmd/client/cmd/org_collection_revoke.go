@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var revokeCollectionOrgCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke team access to collection",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		collection, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading collection name: %v", err)
+		}
+
+		team, err := cmd.Flags().GetString("team")
+		if err != nil {
+			log.Fatal().Msgf("Error reading team name: %v", err)
+		}
+
+		_, err = organizationClient.RevokeCollection(context.Background(), &pb.RevokeCollectionRequest{
+			Organization: organization,
+			Collection:   collection,
+			Team:         team,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to revoke collection: %v", err)
+		}
+
+		fmt.Printf("Team %s access to collection %s revoked\n", team, collection)
+	},
+}
+
+func init() {
+	collectionOrgCmd.AddCommand(revokeCollectionOrgCmd)
+
+	revokeCollectionOrgCmd.Flags().String("name", "", "Collection name")
+	if err := revokeCollectionOrgCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	revokeCollectionOrgCmd.Flags().String("team", "", "Team name")
+	if err := revokeCollectionOrgCmd.MarkFlagRequired("team"); err != nil {
+		log.Error().Err(err)
+	}
+}
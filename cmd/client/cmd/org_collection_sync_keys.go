@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
+)
+
+var syncKeysCollectionOrgCmd = &cobra.Command{
+	Use:   "sync-keys",
+	Short: "Share collection key with members who have access and key pair but no key yet",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		collection, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading collection name: %v", err)
+		}
+
+		ref := &pb.CollectionRef{
+			Organization: organization,
+			Collection:   collection,
+		}
+		key, err := collectionKey(ref)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get collection key: %v", err)
+		}
+
+		resp, err := organizationClient.ListPendingCollectionKeys(
+			context.Background(), &pb.ListPendingCollectionKeysRequest{
+				Organization: organization,
+				Collection:   collection,
+			})
+		if err != nil {
+			log.Fatal().Msgf("Failed to list members without collection key: %v", err)
+		}
+
+		keys := make([]*pb.CollectionKey, 0, len(resp.GetMembers()))
+		for _, member := range resp.GetMembers() {
+			wrappedKey, err := x25519.Seal(member.GetPublicKey(), key)
+			if err != nil {
+				log.Fatal().Msgf("Failed to encrypt collection key: %v", err)
+			}
+			keys = append(keys, &pb.CollectionKey{
+				Email:      member.GetEmail(),
+				WrappedKey: wrappedKey,
+			})
+		}
+		if len(keys) == 0 {
+			fmt.Println("All members with access already have collection key")
+			return
+		}
+
+		_, err = organizationClient.PutCollectionKeys(context.Background(), &pb.PutCollectionKeysRequest{
+			Organization: organization,
+			Collection:   collection,
+			Keys:         keys,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to put collection keys: %v", err)
+		}
+
+		for _, k := range keys {
+			fmt.Printf("Collection key shared with %s\n", k.GetEmail())
+		}
+	},
+}
+
+func init() {
+	collectionOrgCmd.AddCommand(syncKeysCollectionOrgCmd)
+
+	syncKeysCollectionOrgCmd.Flags().String("name", "", "Collection name")
+	if err := syncKeysCollectionOrgCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
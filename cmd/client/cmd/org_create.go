@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var createOrgCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		resp, err := organizationClient.CreateOrganization(
+			context.Background(), &pb.CreateOrganizationRequest{Name: name})
+		if err != nil {
+			log.Fatal().Msgf("Failed to create organization: %v", err)
+		}
+
+		fmt.Printf("Organization %s created successfully\n", resp.GetName())
+	},
+}
+
+func init() {
+	orgCmd.AddCommand(createOrgCmd)
+
+	createOrgCmd.Flags().String("name", "", "Organization name")
+	if err := createOrgCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var listOrgCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List organizations of the user",
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := organizationClient.ListOrganizations(context.Background(), &pb.ListOrganizationsRequest{})
+		if err != nil {
+			log.Fatal().Msgf("Failed to list organizations: %v", err)
+		}
+
+		for _, info := range resp.GetOrganizations() {
+			fmt.Printf("%s\t%s\n", info.GetName(), roleName(info.GetRole()))
+		}
+	},
+}
+
+func init() {
+	orgCmd.AddCommand(listOrgCmd)
+}
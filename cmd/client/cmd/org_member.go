@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var memberOrgCmd = &cobra.Command{
+	Use:   "member",
+	Short: "Manage organization members",
+}
+
+func init() {
+	orgCmd.AddCommand(memberOrgCmd)
+
+	memberOrgCmd.PersistentFlags().String("org", "", "Organization name")
+	if err := memberOrgCmd.MarkPersistentFlagRequired("org"); err != nil {
+		log.Error().Err(err)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var addMemberOrgCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add user to organization or change member role",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		email, err := cmd.Flags().GetString("email")
+		if err != nil {
+			log.Fatal().Msgf("Error reading member email: %v", err)
+		}
+
+		roleName, err := cmd.Flags().GetString("role")
+		if err != nil {
+			log.Fatal().Msgf("Error reading member role: %v", err)
+		}
+		role, ok := roles[roleName]
+		if !ok {
+			log.Fatal().Msgf("Unknown role %q, expected owner, admin, member or read-only", roleName)
+		}
+
+		_, err = organizationClient.PutMember(context.Background(), &pb.PutMemberRequest{
+			Organization: organization,
+			Email:        email,
+			Role:         role,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to add member: %v", err)
+		}
+
+		fmt.Printf("User %s is %s of organization %s\n", email, roleName, organization)
+		fmt.Println("Run `org collection sync-keys` to share collection keys with new members")
+	},
+}
+
+func init() {
+	memberOrgCmd.AddCommand(addMemberOrgCmd)
+
+	addMemberOrgCmd.Flags().String("email", "", "Member email")
+	if err := addMemberOrgCmd.MarkFlagRequired("email"); err != nil {
+		log.Error().Err(err)
+	}
+	addMemberOrgCmd.Flags().String("role", "member", "Member role: owner, admin, member or read-only")
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var listMemberOrgCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List organization members",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		resp, err := organizationClient.ListMembers(context.Background(), &pb.ListMembersRequest{
+			Organization: organization,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to list members: %v", err)
+		}
+
+		for _, info := range resp.GetMembers() {
+			fmt.Printf("%s\t%s\n", info.GetEmail(), roleName(info.GetRole()))
+		}
+	},
+}
+
+func init() {
+	memberOrgCmd.AddCommand(listMemberOrgCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var removeMemberOrgCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove user from organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		email, err := cmd.Flags().GetString("email")
+		if err != nil {
+			log.Fatal().Msgf("Error reading member email: %v", err)
+		}
+
+		_, err = organizationClient.RemoveMember(context.Background(), &pb.RemoveMemberRequest{
+			Organization: organization,
+			Email:        email,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to remove member: %v", err)
+		}
+
+		fmt.Printf("User %s removed from organization %s\n", email, organization)
+	},
+}
+
+func init() {
+	memberOrgCmd.AddCommand(removeMemberOrgCmd)
+
+	removeMemberOrgCmd.Flags().String("email", "", "Member email")
+	if err := removeMemberOrgCmd.MarkFlagRequired("email"); err != nil {
+		log.Error().Err(err)
+	}
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var teamOrgCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Manage organization teams",
+}
+
+func init() {
+	orgCmd.AddCommand(teamOrgCmd)
+
+	teamOrgCmd.PersistentFlags().String("org", "", "Organization name")
+	if err := teamOrgCmd.MarkPersistentFlagRequired("org"); err != nil {
+		log.Error().Err(err)
+	}
+}
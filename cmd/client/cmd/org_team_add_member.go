@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var addMemberTeamOrgCmd = &cobra.Command{
+	Use:   "add-member",
+	Short: "Add organization member to team",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		team, err := cmd.Flags().GetString("team")
+		if err != nil {
+			log.Fatal().Msgf("Error reading team name: %v", err)
+		}
+
+		email, err := cmd.Flags().GetString("email")
+		if err != nil {
+			log.Fatal().Msgf("Error reading member email: %v", err)
+		}
+
+		_, err = organizationClient.AddTeamMember(context.Background(), &pb.AddTeamMemberRequest{
+			Organization: organization,
+			Team:         team,
+			Email:        email,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to add team member: %v", err)
+		}
+
+		fmt.Printf("User %s added to team %s\n", email, team)
+		fmt.Println("Run `org collection sync-keys` to share collection keys with new members")
+	},
+}
+
+func init() {
+	teamOrgCmd.AddCommand(addMemberTeamOrgCmd)
+
+	addMemberTeamOrgCmd.Flags().String("team", "", "Team name")
+	if err := addMemberTeamOrgCmd.MarkFlagRequired("team"); err != nil {
+		log.Error().Err(err)
+	}
+	addMemberTeamOrgCmd.Flags().String("email", "", "Member email")
+	if err := addMemberTeamOrgCmd.MarkFlagRequired("email"); err != nil {
+		log.Error().Err(err)
+	}
+}
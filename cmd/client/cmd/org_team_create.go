@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var createTeamOrgCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create team in organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		team, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading team name: %v", err)
+		}
+
+		_, err = organizationClient.CreateTeam(context.Background(), &pb.CreateTeamRequest{
+			Organization: organization,
+			Team:         team,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to create team: %v", err)
+		}
+
+		fmt.Printf("Team %s created successfully\n", team)
+	},
+}
+
+func init() {
+	teamOrgCmd.AddCommand(createTeamOrgCmd)
+
+	createTeamOrgCmd.Flags().String("name", "", "Team name")
+	if err := createTeamOrgCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
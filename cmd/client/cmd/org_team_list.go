@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var listTeamOrgCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List organization teams with their members",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		resp, err := organizationClient.ListTeams(context.Background(), &pb.ListTeamsRequest{
+			Organization: organization,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to list teams: %v", err)
+		}
+
+		for _, info := range resp.GetTeams() {
+			fmt.Printf("%s\t%s\n", info.GetName(), strings.Join(info.GetMembers(), ", "))
+		}
+	},
+}
+
+func init() {
+	teamOrgCmd.AddCommand(listTeamOrgCmd)
+}
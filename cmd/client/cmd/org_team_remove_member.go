@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var removeMemberTeamOrgCmd = &cobra.Command{
+	Use:   "remove-member",
+	Short: "Remove member from team",
+	Run: func(cmd *cobra.Command, args []string) {
+		organization, err := cmd.Flags().GetString("org")
+		if err != nil {
+			log.Fatal().Msgf("Error reading organization name: %v", err)
+		}
+
+		team, err := cmd.Flags().GetString("team")
+		if err != nil {
+			log.Fatal().Msgf("Error reading team name: %v", err)
+		}
+
+		email, err := cmd.Flags().GetString("email")
+		if err != nil {
+			log.Fatal().Msgf("Error reading member email: %v", err)
+		}
+
+		_, err = organizationClient.RemoveTeamMember(context.Background(), &pb.RemoveTeamMemberRequest{
+			Organization: organization,
+			Team:         team,
+			Email:        email,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to remove team member: %v", err)
+		}
+
+		fmt.Printf("User %s removed from team %s\n", email, team)
+	},
+}
+
+func init() {
+	teamOrgCmd.AddCommand(removeMemberTeamOrgCmd)
+
+	removeMemberTeamOrgCmd.Flags().String("team", "", "Team name")
+	if err := removeMemberTeamOrgCmd.MarkFlagRequired("team"); err != nil {
+		log.Error().Err(err)
+	}
+	removeMemberTeamOrgCmd.Flags().String("email", "", "Member email")
+	if err := removeMemberTeamOrgCmd.MarkFlagRequired("email"); err != nil {
+		log.Error().Err(err)
+	}
+}
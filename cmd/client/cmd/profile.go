@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/config"
+)
+
+// openConfigFile открывает конфигурационный файл клиента для изменения профилей
+func openConfigFile() *config.File {
+	file, err := config.OpenFile(configFilePath())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read client config")
+	}
+	return file
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage server profiles",
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/config"
+)
+
+var addProfileCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add or replace server profile",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading profile name: %v", err)
+		}
+
+		var profile config.Profile
+		if profile.GRPC.Address, err = cmd.Flags().GetString("address"); err != nil {
+			log.Fatal().Msgf("Error reading server address: %v", err)
+		}
+		if profile.GRPC.TLS.Enabled, err = cmd.Flags().GetBool("tls"); err != nil {
+			log.Fatal().Msgf("Error reading TLS flag: %v", err)
+		}
+		if profile.GRPC.TLS.CAFile, err = cmd.Flags().GetString("ca-file"); err != nil {
+			log.Fatal().Msgf("Error reading CA file: %v", err)
+		}
+		if profile.GRPC.TLS.ServerName, err = cmd.Flags().GetString("server-name"); err != nil {
+			log.Fatal().Msgf("Error reading TLS server name: %v", err)
+		}
+		if profile.GRPC.TLS.CAFile != "" || profile.GRPC.TLS.ServerName != "" {
+			profile.GRPC.TLS.Enabled = true
+		}
+		if profile.Encryption.Source, err = cmd.Flags().GetString("key-source"); err != nil {
+			log.Fatal().Msgf("Error reading key source: %v", err)
+		}
+		switch profile.Encryption.Source {
+		case "", config.KeySourceKey, config.KeySourceAgent:
+		default:
+			log.Fatal().Msgf("Unknown key source %q, expected key or agent", profile.Encryption.Source)
+		}
+		if profile.Token.Path, err = cmd.Flags().GetString("token-path"); err != nil {
+			log.Fatal().Msgf("Error reading token path: %v", err)
+		}
+
+		file := openConfigFile()
+		if err = file.PutProfile(name, profile); err != nil {
+			log.Fatal().Err(err).Msg("Failed to add profile")
+		}
+		if err = file.Save(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to save client config")
+		}
+
+		fmt.Printf("Profile %s saved to %s\n", name, file.Path)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(addProfileCmd)
+
+	addProfileCmd.Flags().String("name", "", "Profile name")
+	if err := addProfileCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	addProfileCmd.Flags().String("address", "", "Server grpc address")
+	if err := addProfileCmd.MarkFlagRequired("address"); err != nil {
+		log.Error().Err(err)
+	}
+	addProfileCmd.Flags().Bool("tls", false, "Connect to server over TLS")
+	addProfileCmd.Flags().String("ca-file", "", "Server CA certificate file, system roots by default")
+	addProfileCmd.Flags().String("server-name", "", "Server name to verify TLS certificate against")
+	addProfileCmd.Flags().String("key-source", "", "Master key source: key or agent")
+	addProfileCmd.Flags().String("token-path", "", "Access token file, user config dir by default")
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var listProfileCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List server profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		file := openConfigFile()
+		current := file.CurrentProfile()
+
+		for _, name := range file.Profiles() {
+			marker := " "
+			if name == current {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\n", marker, name)
+		}
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(listProfileCmd)
+}
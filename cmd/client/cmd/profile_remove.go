@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+)
+
+var removeProfileCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove server profile and its access token",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading profile name: %v", err)
+		}
+
+		file := openConfigFile()
+		profile, err := file.Profile(name)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to remove profile")
+		}
+		// Путь к токену читается до удаления профиля, так как он может быть задан в его настройках
+		tokenPath := profile.Token.Path
+		if tokenPath == "" {
+			if tokenPath, err = token.ProfilePath(name); err != nil {
+				log.Warn().Err(err).Msg("Failed to find profile access token")
+			}
+		}
+
+		if err = file.RemoveProfile(name); err != nil {
+			log.Fatal().Err(err).Msg("Failed to remove profile")
+		}
+		if err = file.Save(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to save client config")
+		}
+
+		if tokenPath != "" {
+			if err = os.Remove(tokenPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Warn().Err(err).Msg("Failed to remove profile access token")
+			}
+		}
+
+		fmt.Printf("Profile %s removed\n", name)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(removeProfileCmd)
+
+	removeProfileCmd.Flags().String("name", "", "Profile name")
+	if err := removeProfileCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
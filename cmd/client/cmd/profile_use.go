@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var useProfileCmd = &cobra.Command{
+	Use:   "use",
+	Short: "Select current server profile",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading profile name: %v", err)
+		}
+
+		file := openConfigFile()
+		if err = file.UseProfile(name); err != nil {
+			log.Fatal().Err(err).Msg("Failed to select profile")
+		}
+		if err = file.Save(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to save client config")
+		}
+
+		fmt.Printf("Using profile %s\n", name)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(useProfileCmd)
+
+	useProfileCmd.Flags().String("name", "", "Profile name")
+	if err := useProfileCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/render"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/secretref"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/utils"
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render text/template with secret references",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		input, err := cmd.Flags().GetString("input")
+		if err != nil {
+			log.Fatal().Msgf("Error reading template path: %v", err)
+		}
+
+		outputPath, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Fatal().Msgf("Error reading output path: %v", err)
+		}
+
+		text, err := os.ReadFile(input)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read template")
+		}
+
+		var buf bytes.Buffer
+		resolver := secretref.NewResolver(fetchSecret)
+		if err = render.Render(&buf, input, string(text), resolver); err != nil {
+			log.Fatal().Err(err).Msg("Failed to render template")
+		}
+
+		if outputPath == "" {
+			_, err = os.Stdout.Write(buf.Bytes())
+		} else {
+			err = utils.WritePrivateFile(outputPath, buf.Bytes())
+		}
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to write rendered template")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().StringP("input", "i", "", "Template file")
+	if err := renderCmd.MarkFlagRequired("input"); err != nil {
+		log.Error().Err(err)
+	}
+	renderCmd.Flags().StringP("output", "o", "", "Output file, written with 0600 permissions; stdout if empty")
+}
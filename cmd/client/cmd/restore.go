@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/archive"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/importer"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// restoreAttachments загружает на сервер вложения восстановленных заметок и сохраняет ссылки на них
+func restoreAttachments(actions []importer.Action, attachments map[string][]archive.Attachment) {
+	count := 0
+	for _, action := range actions {
+		noteAttachments := attachments[action.Entry.Name]
+		if action.Kind == importer.ActionSkip || len(noteAttachments) == 0 {
+			continue
+		}
+		note, ok := action.Entry.Secret.(models.Note)
+		if !ok {
+			log.Fatal().Msgf("Secret %s is %s, not note", action.Name, action.Entry.Secret.Type())
+		}
+		for _, attachment := range noteAttachments {
+			if err := attachData(action.Name, &note, attachment.Name, attachment.Data); err != nil {
+				log.Fatal().Err(err).Msgf("Failed to restore attachment %s of note %s", attachment.Name, action.Name)
+			}
+		}
+		if _, err := updateSecret(action.Name, "", nil, note); err != nil {
+			log.Fatal().Err(err).Msgf("Failed to save attachments of note %s", action.Name)
+		}
+		count += len(noteAttachments)
+	}
+	fmt.Printf("Restored %d attachments\n", count)
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [flags] file",
+	Short: "Restore secrets from export archive",
+	Args:  cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		policyName, err := cmd.Flags().GetString("on-duplicate")
+		if err != nil {
+			log.Fatal().Msgf("Error reading duplicate policy: %v", err)
+		}
+		policy, err := importer.ParsePolicy(policyName)
+		if err != nil {
+			log.Fatal().Msgf("Error reading duplicate policy: %v", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal().Msgf("Error reading dry run flag: %v", err)
+		}
+
+		skipAttachments, err := cmd.Flags().GetBool("skip-attachments")
+		if err != nil {
+			log.Fatal().Msgf("Error reading skip attachments flag: %v", err)
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open archive")
+		}
+		defer file.Close()
+
+		passphrase, err := readExportPassphrase(false)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read export passphrase")
+		}
+
+		header, items, err := archive.Read(file, passphrase)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read archive")
+		}
+		log.Info().Msgf("Archive created at %s contains %d secrets", header.CreatedAt.Local(), len(items))
+
+		entries := make([]importer.Entry, 0, len(items))
+		attachments := make(map[string][]archive.Attachment)
+		for _, item := range items {
+			secret, err := models.DecodeSecret(item.Data)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to decode secret %s", item.Name)
+			}
+			// Ссылки на вложения в архивах предыдущих версий указывают на вложения исходного секрета
+			if note, ok := secret.(models.Note); ok && len(note.Attachments) > 0 {
+				log.Warn().Msgf("Archive does not contain attachments of note %s", item.Name)
+				note.Attachments = nil
+				secret = note
+			}
+			if len(item.Attachments) > 0 && !skipAttachments {
+				attachments[item.Name] = item.Attachments
+			}
+			entries = append(entries, importer.Entry{Name: item.Name, Secret: secret})
+		}
+		if collection != nil && len(attachments) > 0 {
+			log.Fatal().Msgf("Failed to restore secrets: %v, use --skip-attachments", errCollectionAttachments)
+		}
+
+		existing, err := listSecretNames(collection)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list secrets")
+		}
+
+		actions := importer.Plan(entries, existing, policy)
+		if err = printImportPlan(actions); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print restore plan")
+		}
+
+		if dryRun {
+			fmt.Println("Dry run, no secrets were changed")
+			return
+		}
+		applyImportPlan(actions, collection)
+		restoreAttachments(actions, attachments)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().String("on-duplicate", string(importer.PolicySkip), "Action for existing secret names: skip|overwrite|rename")
+	restoreCmd.Flags().Bool("dry-run", false, "Only print the restore plan")
+	restoreCmd.Flags().String("org", "", "Organization of the secret collection")
+	restoreCmd.Flags().String("collection", "", "Organization collection to restore secrets into")
+	restoreCmd.Flags().Bool("skip-attachments", false, "Do not restore note attachments")
+}
@@ -1,14 +1,29 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/agent"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/config"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/prompt"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
 	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
 	"github.com/go-developer-ya-practicum/gophkeeper/pkg/version"
 )
@@ -17,10 +32,13 @@ var (
 	cfgFile string
 
 	defaults = map[string]interface{}{
-		"grpc.address": "127.0.0.1:9090",
+		"profile":            config.DefaultProfile,
+		"grpc.address":       "127.0.0.1:9090",
+		"agent.idle_timeout": 15 * time.Minute,
 	}
 
-	tokenStorage token.Storage
+	// blockCipher шифр мастер-ключа, создается функцией masterCipher
+	blockCipher cipher.BlockCipher
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -39,8 +57,6 @@ func Execute() {
 }
 
 func init() {
-	tokenStorage = token.NewFileStorage("token.txt")
-
 	if err := godotenv.Load(); err != nil {
 		log.Debug().Msg("No .env file found")
 	}
@@ -48,12 +64,18 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(
 		&cfgFile, "config", "c", "", "Client config filepath")
 
+	rootCmd.PersistentFlags().StringP(
+		"profile", "P", "", "Server profile from client config")
+
 	rootCmd.PersistentFlags().StringP(
 		"grpc-address", "g", "", "Server grpc address")
 
 	rootCmd.PersistentFlags().StringP(
 		"encryption-key", "k", "", "Secret encryption key")
 
+	rootCmd.PersistentFlags().String(
+		"agent-socket", "", "Agent unix socket path")
+
 	cobra.OnInitialize(initConfig)
 }
 
@@ -65,6 +87,9 @@ func initConfig() {
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
+		if path, err := config.DefaultFilePath(); err == nil {
+			viper.AddConfigPath(filepath.Dir(path))
+		}
 		viper.AddConfigPath("/etc/gophkeeper")
 		viper.AddConfigPath("$HOME")
 		viper.AddConfigPath(".")
@@ -89,4 +114,159 @@ func initConfig() {
 			log.Fatal().Err(err).Msg("Failed to bind flag")
 		}
 	})
+
+	profile := currentProfile()
+	if err := config.ValidateProfileName(profile); err != nil {
+		log.Fatal().Err(err).Msgf("Invalid profile %q", profile)
+	}
+	if settings := viper.GetStringMap("profiles." + profile); len(settings) > 0 {
+		if err := viper.MergeConfigMap(settings); err != nil {
+			log.Fatal().Err(err).Msg("Failed to apply profile settings")
+		}
+	} else if profile != config.DefaultProfile {
+		log.Fatal().Msgf("Profile %s not found in client config", profile)
+	}
+	viper.SetDefault("agent.socket", agent.DefaultSocketPath(profile))
+}
+
+// currentProfile возвращает имя выбранного профиля сервера
+func currentProfile() string {
+	return viper.GetString("profile")
+}
+
+// configFilePath возвращает путь к изменяемому командами profile конфигурационному файлу
+func configFilePath() string {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path
+	}
+	path, err := config.DefaultFilePath()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to find client config path")
+	}
+	return path
+}
+
+// transportCredentials возвращает параметры защиты подключения к серверу выбранного профиля
+func transportCredentials() credentials.TransportCredentials {
+	if !viper.GetBool("grpc.tls.enabled") {
+		return insecure.NewCredentials()
+	}
+
+	serverName := viper.GetString("grpc.tls.server_name")
+	if caFile := viper.GetString("grpc.tls.ca_file"); caFile != "" {
+		creds, err := credentials.NewClientTLSFromFile(caFile, serverName)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load server CA certificate")
+		}
+		return creds
+	}
+	return credentials.NewTLS(&tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	})
+}
+
+// masterCipher возвращает шифр мастер-ключа. Если ключ шифрования не задан,
+// шифрование выполняется запущенным агентом, а если агент не запущен - ключ запрашивается с терминала
+func masterCipher() cipher.BlockCipher {
+	if blockCipher != nil {
+		return blockCipher
+	}
+
+	source := viper.GetString("encryption.source")
+	key := viper.GetString("encryption.key")
+	if source == config.KeySourceKey || source == "" && key != "" {
+		blockCipher = newKeyCipher(key)
+		return blockCipher
+	}
+
+	agentClient := newAgentClient()
+	resp, err := agentClient.Status(context.Background(), &pb.StatusRequest{})
+	if err != nil && source == "" && prompt.Default.IsTerminal() {
+		if key, err = prompt.Default.Secret("Encryption key", false); err != nil {
+			log.Fatal().Msgf("Error reading encryption key: %v", err)
+		}
+		blockCipher = newKeyCipher(key)
+		return blockCipher
+	}
+	if err != nil {
+		log.Fatal().Msgf("Agent for profile %s is not running, run `agent start` or set encryption key", currentProfile())
+	}
+	if resp.GetLocked() {
+		log.Fatal().Msg("Agent is locked, run `agent unlock`")
+	}
+	blockCipher = agent.NewCipher(agentClient)
+	return blockCipher
+}
+
+// newKeyCipher создает шифр мастер-ключа по ключу шифрования
+func newKeyCipher(key string) cipher.BlockCipher {
+	keyCipher, err := gcm.NewMaster(key)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create cipher")
+	}
+	return keyCipher
+}
+
+// addSensitiveFlag добавляет необязательный флаг чувствительного значения и флаг <name>-file
+// для чтения значения из файла, стандартного ввода (-) или файлового дескриптора (fd:N)
+func addSensitiveFlag(cmd *cobra.Command, name, shorthand, usage string) {
+	cmd.Flags().StringP(name, shorthand, "", usage+", prompted if omitted")
+	cmd.Flags().String(name+"-file", "", usage+" source: file path, - for stdin or fd:N for file descriptor")
+	cmd.MarkFlagsMutuallyExclusive(name, name+"-file")
+}
+
+// readSensitiveFlag возвращает значение флага name, значение из источника флага <name>-file
+// или запрашивает его без отображения на экране. confirm требует повторного ввода нового значения
+func readSensitiveFlag(cmd *cobra.Command, name, label string, confirm bool) (string, error) {
+	if cmd.Flags().Changed(name) {
+		return cmd.Flags().GetString(name)
+	}
+	source, err := cmd.Flags().GetString(name + "-file")
+	if err != nil {
+		return "", err
+	}
+	if source != "" {
+		return prompt.Default.ReadSource(source)
+	}
+	return prompt.Default.Secret(label, confirm)
+}
+
+// tokenPath возвращает путь к файлу токена доступа текущего профиля
+func tokenPath() string {
+	path := viper.GetString("token.path")
+	if path == "" {
+		var err error
+		if path, err = token.ProfilePath(currentProfile()); err != nil {
+			log.Fatal().Err(err).Msg("Failed to find token storage path")
+		}
+	}
+	return path
+}
+
+// newTokenStorage создает хранилище токена доступа профиля, зашифрованного мастер-ключом
+func newTokenStorage() token.Storage {
+	return token.NewEncryptedFileStorage(tokenPath(), masterCipher())
+}
+
+// newAuthorizedConnection создает подключение к серверу, добавляющее токен доступа в каждый запрос
+func newAuthorizedConnection() *grpc.ClientConn {
+	accessToken, err := newTokenStorage().Load()
+	if errors.Is(err, token.ErrNotFound) {
+		log.Fatal().Msgf("Access token for profile %s not found, run `auth login`", currentProfile())
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load access token")
+	}
+	interceptor := interceptors.NewAuthInterceptor(accessToken)
+
+	connection, err := grpc.Dial(
+		viper.GetString("grpc.address"),
+		grpc.WithTransportCredentials(transportCredentials()),
+		grpc.WithUnaryInterceptor(interceptor.Unary()),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create client connection")
+	}
+	return connection
 }
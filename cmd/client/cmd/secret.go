@@ -1,68 +1,261 @@
 package cmd
 
 import (
-	"github.com/rs/zerolog/log"
+	"context"
+	"fmt"
+
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
-	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/interceptors"
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/output"
 	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
-	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher"
 	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
 )
 
 var (
-	secretClient pb.SecretServiceClient
-	blockCipher  cipher.BlockCipher
+	secretClient       pb.SecretServiceClient
+	organizationClient pb.OrganizationServiceClient
 )
 
-func encryptSecret(s models.Secret) ([]byte, error) {
+// initSecretClients создает клиентов сервисов секретов и организаций и шифр мастер-ключа
+func initSecretClients() {
+	masterCipher()
+	connection := newAuthorizedConnection()
+	secretClient = pb.NewSecretServiceClient(connection)
+	organizationClient = pb.NewOrganizationServiceClient(connection)
+}
+
+// encryptSecret шифрует секрет ключом данных dataKey
+func encryptSecret(s models.Secret, dataKey []byte) ([]byte, error) {
 	encoded, err := models.EncodeSecret(s)
 	if err != nil {
 		return nil, err
 	}
-	return blockCipher.Encrypt(encoded)
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return dataCipher.Encrypt(encoded)
 }
 
-func decryptSecret(b []byte) (models.Secret, error) {
-	encoded, err := blockCipher.Decrypt(b)
+// decryptSecret расшифровывает собственный секрет пользователя.
+// Ключ данных wrappedKey зашифрован мастер-ключом; секреты без ключа данных
+// зашифрованы непосредственно мастер-ключом
+func decryptSecret(content, wrappedKey []byte) (models.Secret, error) {
+	if len(wrappedKey) == 0 {
+		encoded, err := blockCipher.Decrypt(content)
+		if err != nil {
+			return nil, err
+		}
+		return models.DecodeSecret(encoded)
+	}
+
+	dataKey, err := blockCipher.Decrypt(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return openSecret(content, dataKey)
+}
+
+// decryptSharedSecret расшифровывает секрет другого пользователя,
+// ключ данных которого зашифрован открытым ключом пользователя
+func decryptSharedSecret(content, wrappedKey []byte) (models.Secret, error) {
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := x25519.Open(privateKey, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return openSecret(content, dataKey)
+}
+
+func openSecret(content, dataKey []byte) (models.Secret, error) {
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := dataCipher.Decrypt(content)
 	if err != nil {
 		return nil, err
 	}
 	return models.DecodeSecret(encoded)
 }
 
-var secretCmd = &cobra.Command{
-	Use:   "secret",
-	Short: "Manage user private data",
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		accessToken, err := tokenStorage.Load()
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to load access token")
-		}
-		if accessToken == "" {
-			log.Fatal().Msg("Empty access token")
-		}
-		interceptor := interceptors.NewAuthInterceptor(viper.GetString("token"))
+// newDataKey генерирует ключ данных нового секрета и возвращает его вместе с копией,
+// зашифрованной мастер-ключом
+func newDataKey() (dataKey, wrappedKey []byte, err error) {
+	dataKey, err = gcm.NewKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	wrappedKey, err = blockCipher.Encrypt(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataKey, wrappedKey, nil
+}
+
+// createSecret шифрует секрет новым ключом данных и сохраняет его на сервере.
+// Если указана коллекция collection, секрет шифруется ключом коллекции
+func createSecret(name string, collection *pb.CollectionRef, s models.Secret) (*pb.CreateSecretResponse, error) {
+	request := &pb.CreateSecretRequest{
+		Name:       name,
+		Collection: collection,
+	}
+
+	var dataKey []byte
+	var err error
+	if collection != nil {
+		dataKey, err = collectionKey(collection)
+	} else {
+		dataKey, request.DataKey, err = newDataKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Content, err = encryptSecret(s, dataKey); err != nil {
+		return nil, err
+	}
+	return secretClient.CreateSecret(context.Background(), request)
+}
 
-		connection, err := grpc.Dial(
-			viper.GetString("grpc.address"),
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			grpc.WithUnaryInterceptor(interceptor.Unary()),
-		)
+// updateSecret обновляет содержимое секрета, сохраняя его ключ данных.
+// Если указан владелец owner, обновляется секрет, к которому владелец предоставил доступ,
+// если указана коллекция collection - секрет коллекции организации
+func updateSecret(name, owner string, collection *pb.CollectionRef, s models.Secret) (*pb.UpdateSecretResponse, error) {
+	request := &pb.UpdateSecretRequest{
+		Name:       name,
+		Owner:      owner,
+		Collection: collection,
+	}
+
+	var dataKey []byte
+	if collection != nil {
+		var err error
+		if dataKey, err = collectionKey(collection); err != nil {
+			return nil, err
+		}
+	} else if owner != "" {
+		resp, err := secretClient.GetSecret(context.Background(), &pb.GetSecretRequest{
+			Name:  name,
+			Owner: owner,
+		})
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to create client connection")
+			return nil, err
 		}
-
-		secretClient = pb.NewSecretServiceClient(connection)
-		cipher, err := gcm.New(viper.GetString("encryption.key"))
+		privateKey, err := loadPrivateKey()
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to create cipher")
+			return nil, err
 		}
-		blockCipher = cipher
+		if dataKey, err = x25519.Open(privateKey, resp.GetDataKey()); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if dataKey, request.DataKey, err = secretDataKey(name); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := encryptSecret(s, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	request.Content = content
+	return secretClient.UpdateSecret(context.Background(), request)
+}
+
+// fetchSecret получает и расшифровывает собственный секрет пользователя
+func fetchSecret(name string) (models.Secret, error) {
+	resp, err := secretClient.GetSecret(context.Background(), &pb.GetSecretRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return decryptSecret(resp.GetContent(), resp.GetDataKey())
+}
+
+// getSecret получает и расшифровывает собственный секрет пользователя. Если указан владелец owner,
+// получает секрет, к которому владелец предоставил доступ, если указана коллекция collection - секрет коллекции
+func getSecret(name, owner string, collection *pb.CollectionRef) (*pb.GetSecretResponse, models.Secret, error) {
+	resp, err := secretClient.GetSecret(context.Background(), &pb.GetSecretRequest{
+		Name:       name,
+		Owner:      owner,
+		Collection: collection,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var secret models.Secret
+	if owner != "" || collection != nil {
+		secret, err = decryptSharedSecret(resp.GetContent(), resp.GetDataKey())
+	} else {
+		secret, err = decryptSecret(resp.GetContent(), resp.GetDataKey())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, secret, nil
+}
+
+// credentialsSecret получает собственный секрет name с учетными данными. Если секрета нет, возвращает nil
+func credentialsSecret(name string) (*pb.GetSecretResponse, *models.Credentials, error) {
+	resp, secret, err := getSecret(name, "", nil)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	credentials, ok := secret.(models.Credentials)
+	if !ok {
+		return nil, nil, fmt.Errorf("secret %s is %s, not credentials", name, secret.Type())
+	}
+	return resp, &credentials, nil
+}
+
+// secretDataKey возвращает ключ данных собственного секрета пользователя.
+// Для секретов, зашифрованных непосредственно мастер-ключом, генерирует новый ключ данных
+func secretDataKey(name string) (dataKey, wrappedKey []byte, err error) {
+	resp, err := secretClient.GetSecret(context.Background(), &pb.GetSecretRequest{Name: name})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.GetDataKey()) == 0 {
+		return newDataKey()
+	}
+	dataKey, err = blockCipher.Decrypt(resp.GetDataKey())
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataKey, resp.GetDataKey(), nil
+}
+
+// addOutputFlag добавляет флаг формата вывода секретов
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", string(output.FormatTable), "Output format: table|json|yaml|env|raw")
+}
+
+// readOutputFormat читает формат вывода секретов
+func readOutputFormat(cmd *cobra.Command) (output.Format, error) {
+	name, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+	return output.ParseFormat(name)
+}
+
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage user private data",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
 	},
 }
 
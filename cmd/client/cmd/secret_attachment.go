@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/editor"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var errCollectionAttachments = errors.New("attachments are supported only for personal notes")
+
+// addNoteFlags добавляет флаги заголовка, текста и вложений заметки
+func addNoteFlags(cmd *cobra.Command) {
+	cmd.Flags().String("title", "", "Note title, overrides the title line of the text")
+	cmd.Flags().String("body-file", "", "Read Markdown text from file instead of opening $EDITOR, - for stdin")
+	cmd.Flags().StringArray("attach", nil, "Attach file to the note, may be repeated")
+}
+
+// readNoteText возвращает заголовок и текст заметки из файла --body-file или из редактора,
+// в котором открывается текущий текст заметки note. Флаг --title заменяет заголовок
+func readNoteText(cmd *cobra.Command, note models.Note, edit bool) (models.Note, error) {
+	bodyFile, err := cmd.Flags().GetString("body-file")
+	if err != nil {
+		return note, err
+	}
+
+	var text []byte
+	switch {
+	case bodyFile == "-":
+		text, err = io.ReadAll(os.Stdin)
+	case bodyFile != "":
+		text, err = os.ReadFile(bodyFile)
+	case edit:
+		text, err = editor.Edit([]byte(note.Markdown()), ".md")
+	default:
+		text = []byte(note.Markdown())
+	}
+	if err != nil {
+		return note, err
+	}
+	note.Title, note.Body = models.ParseNote(string(text))
+
+	if cmd.Flags().Changed("title") {
+		if note.Title, err = cmd.Flags().GetString("title"); err != nil {
+			return note, err
+		}
+	}
+	return note, nil
+}
+
+// attachFiles шифрует файлы paths, сохраняет их на сервере как вложения секрета name и добавляет в заметку
+func attachFiles(name string, note *models.Note, paths []string) error {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err = attachData(name, note, filepath.Base(path), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachData шифрует data, сохраняет на сервере как вложение attachmentName секрета name и добавляет в заметку
+func attachData(name string, note *models.Note, attachmentName string, data []byte) error {
+	attachment, content, err := models.NewAttachment(attachmentName, data)
+	if err != nil {
+		return err
+	}
+	if _, err = note.Attachment(attachment.Name); err == nil {
+		return fmt.Errorf("%w: %s", models.ErrAttachmentExists, attachment.Name)
+	}
+
+	resp, err := secretClient.PutBlob(context.Background(), &pb.PutBlobRequest{
+		SecretName: name,
+		Content:    content,
+	})
+	if err != nil {
+		return err
+	}
+	attachment.ID = resp.GetId()
+	return note.Attach(attachment)
+}
+
+// deleteAttachments удаляет с сервера вложения, исключенные из заметки name
+func deleteAttachments(name string, attachments []models.Attachment) {
+	for _, attachment := range attachments {
+		_, err := secretClient.DeleteBlob(context.Background(), &pb.DeleteBlobRequest{
+			SecretName: name,
+			Id:         attachment.ID,
+		})
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to delete attachment %s", attachment.Name)
+		}
+	}
+}
+
+// fetchNote получает и расшифровывает собственную заметку пользователя
+func fetchNote(name string) (models.Note, error) {
+	secret, err := fetchSecret(name)
+	if err != nil {
+		return models.Note{}, err
+	}
+	note, ok := secret.(models.Note)
+	if !ok {
+		return models.Note{}, fmt.Errorf("secret %s is %s, not note", name, secret.Type())
+	}
+	return note, nil
+}
+
+// fetchAttachment получает и расшифровывает вложение заметки name
+func fetchAttachment(name string, attachment models.Attachment) ([]byte, error) {
+	resp, err := secretClient.GetBlob(context.Background(), &pb.GetBlobRequest{
+		SecretName: name,
+		Id:         attachment.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attachment.Open(resp.GetContent())
+}
+
+var attachmentSecretCmd = &cobra.Command{
+	Use:   "attachment",
+	Short: "Manage note attachments",
+}
+
+func init() {
+	secretCmd.AddCommand(attachmentSecretCmd)
+
+	attachmentSecretCmd.PersistentFlags().String("name", "", "Note name")
+	if err := attachmentSecretCmd.MarkPersistentFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var getAttachmentSecretCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Download and decrypt note attachment",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		attachmentName, err := cmd.Flags().GetString("attachment")
+		if err != nil {
+			log.Fatal().Msgf("Error reading attachment name: %v", err)
+		}
+
+		out, err := cmd.Flags().GetString("out")
+		if err != nil {
+			log.Fatal().Msgf("Error reading output file: %v", err)
+		}
+
+		note, err := fetchNote(name)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get note: %v", err)
+		}
+		attachment, err := note.Attachment(attachmentName)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get attachment: %v", err)
+		}
+		data, err := fetchAttachment(name, attachment)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get attachment: %v", err)
+		}
+
+		if out == "" {
+			_, err = os.Stdout.Write(data)
+		} else {
+			err = os.WriteFile(out, data, 0600)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to write attachment")
+		}
+	},
+}
+
+func init() {
+	attachmentSecretCmd.AddCommand(getAttachmentSecretCmd)
+
+	getAttachmentSecretCmd.Flags().String("attachment", "", "Attachment name")
+	if err := getAttachmentSecretCmd.MarkFlagRequired("attachment"); err != nil {
+		log.Error().Err(err)
+	}
+	getAttachmentSecretCmd.Flags().StringP("out", "o", "", "Write attachment to file instead of stdout")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var listAttachmentSecretCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List note attachments",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		note, err := fetchNote(name)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get note: %v", err)
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "NAME\tSIZE")
+		for _, attachment := range note.Attachments {
+			fmt.Fprintf(writer, "%s\t%d\n", attachment.Name, attachment.Size)
+		}
+		if err = writer.Flush(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print attachments")
+		}
+	},
+}
+
+func init() {
+	attachmentSecretCmd.AddCommand(listAttachmentSecretCmd)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var copySecretCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		newName, err := cmd.Flags().GetString("new-name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading new secret name: %v", err)
+		}
+
+		resp, err := secretClient.CopySecret(context.Background(), &pb.CopySecretRequest{
+			Name:    name,
+			NewName: newName,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to copy secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s copied to %s version %v successfully\n", name, resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(copySecretCmd)
+
+	copySecretCmd.Flags().String("name", "", "Secret name")
+	if err := copySecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	copySecretCmd.Flags().String("new-name", "", "New secret name")
+	if err := copySecretCmd.MarkFlagRequired("new-name"); err != nil {
+		log.Error().Err(err)
+	}
+}
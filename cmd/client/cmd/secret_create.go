@@ -11,4 +11,7 @@ var createSecretCmd = &cobra.Command{
 
 func init() {
 	secretCmd.AddCommand(createSecretCmd)
+
+	createSecretCmd.PersistentFlags().String("org", "", "Organization of the secret collection")
+	createSecretCmd.PersistentFlags().String("collection", "", "Organization collection of the secret")
 }
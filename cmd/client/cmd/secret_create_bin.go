@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"io/ioutil"
 
@@ -9,7 +8,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var createBinSecretCmd = &cobra.Command{
@@ -22,6 +20,12 @@ var createBinSecretCmd = &cobra.Command{
 			return
 		}
 
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
 		file, err := cmd.Flags().GetString("file")
 		if err != nil {
 			log.Fatal().Msgf("Error reading file name: %v", err)
@@ -38,16 +42,7 @@ var createBinSecretCmd = &cobra.Command{
 			Data: data,
 		}
 
-		content, err := encryptSecret(bin)
-		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
-			return
-		}
-
-		resp, err := secretClient.CreateSecret(context.Background(), &pb.CreateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := createSecret(name, collection, bin)
 		if err != nil {
 			log.Fatal().Msgf("Failed to create secret: %v", err)
 			return
@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var createCardSecretCmd = &cobra.Command{
@@ -21,7 +19,13 @@ var createCardSecretCmd = &cobra.Command{
 			return
 		}
 
-		number, err := cmd.Flags().GetString("number")
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		number, err := readSensitiveFlag(cmd, "number", "Card number", true)
 		if err != nil {
 			log.Fatal().Msgf("Error reading card number: %v", err)
 			return
@@ -33,7 +37,7 @@ var createCardSecretCmd = &cobra.Command{
 			return
 		}
 
-		code, err := cmd.Flags().GetString("code")
+		code, err := readSensitiveFlag(cmd, "code", "Card security code", true)
 		if err != nil {
 			log.Fatal().Msgf("Error reading card security code: %v", err)
 			return
@@ -45,23 +49,13 @@ var createCardSecretCmd = &cobra.Command{
 			return
 		}
 
-		card := models.Card{
-			Number:       number,
-			ExpiryDate:   date,
-			SecurityCode: code,
-			Holder:       holder,
-		}
-
-		content, err := encryptSecret(card)
+		card, err := models.ParseCard(number, date, code, holder)
 		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
+			log.Fatal().Msgf("Invalid card: %v", err)
 			return
 		}
 
-		resp, err := secretClient.CreateSecret(context.Background(), &pb.CreateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := createSecret(name, collection, card)
 		if err != nil {
 			log.Fatal().Msgf("Failed to create secret: %v", err)
 			return
@@ -78,18 +72,12 @@ func init() {
 	if err := createCardSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
-	createCardSecretCmd.Flags().String("number", "", "Card number")
-	if err := createCardSecretCmd.MarkFlagRequired("number"); err != nil {
-		log.Error().Err(err)
-	}
-	createCardSecretCmd.Flags().String("date", "", "Card expiry date")
+	addSensitiveFlag(createCardSecretCmd, "number", "", "Card number")
+	createCardSecretCmd.Flags().String("date", "", "Card expiry date in MM/YY format")
 	if err := createCardSecretCmd.MarkFlagRequired("date"); err != nil {
 		log.Error().Err(err)
 	}
-	createCardSecretCmd.Flags().String("code", "", "Card security code")
-	if err := createCardSecretCmd.MarkFlagRequired("code"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(createCardSecretCmd, "code", "", "Card security code")
 	createCardSecretCmd.Flags().String("holder", "", "Card holder")
 	if err := createCardSecretCmd.MarkFlagRequired("holder"); err != nil {
 		log.Error().Err(err)
@@ -1,14 +1,13 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"os"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var createCredentialsSecretCmd = &cobra.Command{
@@ -21,16 +20,32 @@ var createCredentialsSecretCmd = &cobra.Command{
 			return
 		}
 
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
 		login, err := cmd.Flags().GetString("login")
 		if err != nil {
 			log.Fatal().Msgf("Error reading login: %v", err)
 			return
 		}
 
-		password, err := cmd.Flags().GetString("password")
+		generate, err := cmd.Flags().GetBool("generate")
 		if err != nil {
+			log.Fatal().Msgf("Error reading generate flag: %v", err)
+		}
+
+		var password string
+		if generate {
+			var entropy float64
+			if password, entropy, err = generatePassword(cmd.Flags()); err != nil {
+				log.Fatal().Err(err).Msg("Failed to generate password")
+			}
+			fmt.Fprintf(os.Stderr, "Generated password with estimated entropy %.0f bits\n", entropy)
+		} else if password, err = readSensitiveFlag(cmd, "password", "Password", true); err != nil {
 			log.Fatal().Msgf("Error reading password: %v", err)
-			return
 		}
 
 		credentials := models.Credentials{
@@ -38,16 +53,7 @@ var createCredentialsSecretCmd = &cobra.Command{
 			Password: password,
 		}
 
-		content, err := encryptSecret(credentials)
-		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
-			return
-		}
-
-		resp, err := secretClient.CreateSecret(context.Background(), &pb.CreateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := createSecret(name, collection, credentials)
 		if err != nil {
 			log.Fatal().Msgf("Failed to create secret: %v", err)
 			return
@@ -68,8 +74,9 @@ func init() {
 	if err := createCredentialsSecretCmd.MarkFlagRequired("login"); err != nil {
 		log.Error().Err(err)
 	}
-	createCredentialsSecretCmd.Flags().String("password", "", "Password")
-	if err := createCredentialsSecretCmd.MarkFlagRequired("password"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(createCredentialsSecretCmd, "password", "", "Password")
+	createCredentialsSecretCmd.Flags().Bool("generate", false, "Generate random password instead of --password")
+	createCredentialsSecretCmd.MarkFlagsMutuallyExclusive("password", "generate")
+	createCredentialsSecretCmd.MarkFlagsMutuallyExclusive("password-file", "generate")
+	addGeneratorFlags(createCredentialsSecretCmd.Flags())
 }
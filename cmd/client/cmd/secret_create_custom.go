@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/prompt"
+)
+
+// addCustomFieldFlags добавляет флаги значений пользовательских полей
+func addCustomFieldFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("field", nil, "Field name[:type]=value, type is text|hidden|url|date, may be repeated")
+	cmd.Flags().StringArray("hidden-field", nil, "Hidden field name=value or name to prompt for the value, may be repeated")
+}
+
+// applyCustomFields изменяет поля секрета согласно флагам --field и --hidden-field.
+// Значения скрытых полей без значения запрашиваются без отображения на экране
+func applyCustomFields(cmd *cobra.Command, custom *models.Custom) error {
+	values, err := cmd.Flags().GetStringArray("field")
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		field, err := models.ParseCustomField(value, "")
+		if err != nil {
+			return err
+		}
+		custom.Set(field)
+	}
+
+	hidden, err := cmd.Flags().GetStringArray("hidden-field")
+	if err != nil {
+		return err
+	}
+	for _, value := range hidden {
+		if !strings.Contains(value, "=") {
+			secret, err := prompt.Default.Secret("Value of "+value, false)
+			if err != nil {
+				return err
+			}
+			value += "=" + secret
+		}
+		field, err := models.ParseCustomField(value, models.FieldKindHidden)
+		if err != nil {
+			return err
+		}
+		custom.Set(field)
+	}
+	return nil
+}
+
+// promptEmptyHiddenFields запрашивает значения скрытых полей шаблона, не заданные флагами
+func promptEmptyHiddenFields(custom *models.Custom) error {
+	for i, entry := range custom.Entries {
+		if entry.Kind != models.FieldKindHidden || entry.Value != "" {
+			continue
+		}
+		value, err := prompt.Default.Secret("Value of "+entry.Name, false)
+		if err != nil {
+			return err
+		}
+		custom.Entries[i].Value = value
+	}
+	return nil
+}
+
+var createCustomSecretCmd = &cobra.Command{
+	Use:   "custom",
+	Short: "Create secret with custom fields",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		templateName, err := cmd.Flags().GetString("template")
+		if err != nil {
+			log.Fatal().Msgf("Error reading template name: %v", err)
+			return
+		}
+
+		var secret models.Custom
+		if templateName != "" {
+			template, err := fetchTemplate(templateName)
+			if err != nil {
+				log.Fatal().Msgf("Failed to get template: %v", err)
+				return
+			}
+			secret = template.NewCustom(templateName)
+		}
+
+		if err = applyCustomFields(cmd, &secret); err != nil {
+			log.Fatal().Msgf("Error reading custom fields: %v", err)
+			return
+		}
+		if err = promptEmptyHiddenFields(&secret); err != nil {
+			log.Fatal().Msgf("Error reading custom fields: %v", err)
+			return
+		}
+		if len(secret.Entries) == 0 {
+			log.Fatal().Msg("Error reading custom fields: either --template or --field is required")
+			return
+		}
+		if err = secret.Validate(); err != nil {
+			log.Fatal().Msgf("Error reading custom fields: %v", err)
+			return
+		}
+
+		resp, err := createSecret(name, collection, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to create secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s version %v created successfully\n", resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	createSecretCmd.AddCommand(createCustomSecretCmd)
+
+	createCustomSecretCmd.Flags().String("name", "", "Secret name")
+	if err := createCustomSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	createCustomSecretCmd.Flags().String("template", "", "Template defining secret fields")
+	addCustomFieldFlags(createCustomSecretCmd)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+var createNoteSecretCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Create note with Markdown text and attachments",
+	Long: "Create note with Markdown text and attachments. Without --body-file the text is edited in $EDITOR; " +
+		"a first line \"# Title\" becomes the note title",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		attach, err := cmd.Flags().GetStringArray("attach")
+		if err != nil {
+			log.Fatal().Msgf("Error reading attachments: %v", err)
+			return
+		}
+		if collection != nil && len(attach) > 0 {
+			log.Fatal().Msgf("Error reading attachments: %v", errCollectionAttachments)
+			return
+		}
+
+		note, err := readNoteText(cmd, models.Note{Title: name}, true)
+		if err != nil {
+			log.Fatal().Msgf("Error reading note text: %v", err)
+			return
+		}
+
+		if note.Title == "" && note.Body == "" {
+			log.Fatal().Msg("Empty note, nothing to save")
+			return
+		}
+
+		resp, err := createSecret(name, collection, note)
+		if err != nil {
+			log.Fatal().Msgf("Failed to create secret: %v", err)
+			return
+		}
+
+		// Вложения связываются с существующим секретом, поэтому сохраняются после создания заметки
+		if len(attach) > 0 {
+			if err = attachFiles(name, &note, attach); err != nil {
+				log.Fatal().Msgf("Note %s created without attachments, add them with secret update note --attach: %v", name, err)
+				return
+			}
+			updated, err := updateSecret(name, "", nil, note)
+			if err != nil {
+				log.Fatal().Msgf("Failed to save note attachments: %v", err)
+				return
+			}
+			fmt.Printf("Secret %s version %v created successfully\n", updated.GetName(), updated.GetVersion())
+			return
+		}
+
+		fmt.Printf("Secret %s version %v created successfully\n", resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	createSecretCmd.AddCommand(createNoteSecretCmd)
+
+	createNoteSecretCmd.Flags().String("name", "", "Secret name")
+	if err := createNoteSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	addNoteFlags(createNoteSecretCmd)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// addSSHKeyFlags добавляет флаги генерации или импорта SSH-ключа
+func addSSHKeyFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("generate", false, "Generate new key")
+	cmd.Flags().String("key-type", models.SSHKeyTypeEd25519, "Generated key type: ed25519|rsa")
+	cmd.Flags().Int("bits", models.DefaultSSHKeyRSABits, "Generated RSA key size")
+	cmd.Flags().StringP("file", "f", "", "OpenSSH or PEM private key file to import")
+	cmd.Flags().String("passphrase", "", "Passphrase of encrypted private key, prompted if required")
+	cmd.Flags().String("passphrase-file", "", "Passphrase source: file path, - for stdin or fd:N for file descriptor")
+	cmd.Flags().String("comment", "", "Key comment, by default taken from imported key")
+	cmd.MarkFlagsMutuallyExclusive("generate", "file")
+	cmd.MarkFlagsMutuallyExclusive("passphrase", "passphrase-file")
+}
+
+// readSSHKey генерирует или импортирует SSH-ключ. Парольная фраза запрашивается,
+// только если импортируемый ключ зашифрован и она не передана флагами
+func readSSHKey(cmd *cobra.Command) (models.SSHKey, error) {
+	comment, err := cmd.Flags().GetString("comment")
+	if err != nil {
+		return models.SSHKey{}, err
+	}
+
+	generate, err := cmd.Flags().GetBool("generate")
+	if err != nil {
+		return models.SSHKey{}, err
+	}
+	if generate {
+		keyType, err := cmd.Flags().GetString("key-type")
+		if err != nil {
+			return models.SSHKey{}, err
+		}
+		bits, err := cmd.Flags().GetInt("bits")
+		if err != nil {
+			return models.SSHKey{}, err
+		}
+		return models.GenerateSSHKey(keyType, bits, comment)
+	}
+
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return models.SSHKey{}, err
+	}
+	if file == "" {
+		return models.SSHKey{}, errors.New("either --generate or --file is required")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return models.SSHKey{}, err
+	}
+
+	var passphrase string
+	if cmd.Flags().Changed("passphrase") || cmd.Flags().Changed("passphrase-file") {
+		if passphrase, err = readSensitiveFlag(cmd, "passphrase", "Key passphrase", false); err != nil {
+			return models.SSHKey{}, err
+		}
+	}
+	key, err := models.ParseSSHKey(data, passphrase, comment)
+	if errors.Is(err, models.ErrSSHKeyPassphrase) && passphrase == "" {
+		if passphrase, err = readSensitiveFlag(cmd, "passphrase", "Key passphrase", false); err != nil {
+			return models.SSHKey{}, err
+		}
+		key, err = models.ParseSSHKey(data, passphrase, comment)
+	}
+	return key, err
+}
+
+var createSSHKeySecretCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "Create SSH key secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		secret, err := readSSHKey(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading SSH key: %v", err)
+			return
+		}
+
+		resp, err := createSecret(name, collection, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to create secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s version %v created successfully\n", resp.GetName(), resp.GetVersion())
+		fmt.Println(secret.PublicKey)
+	},
+}
+
+func init() {
+	createSecretCmd.AddCommand(createSSHKeySecretCmd)
+
+	createSSHKeySecretCmd.Flags().String("name", "", "Secret name")
+	if err := createSSHKeySecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	addSSHKeyFlags(createSSHKeySecretCmd)
+}
@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var createTextSecretCmd = &cobra.Command{
@@ -21,7 +19,13 @@ var createTextSecretCmd = &cobra.Command{
 			return
 		}
 
-		data, err := cmd.Flags().GetString("data")
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		data, err := readSensitiveFlag(cmd, "data", "Text data", true)
 		if err != nil {
 			log.Fatal().Msgf("Error reading text data: %v", err)
 			return
@@ -31,16 +35,7 @@ var createTextSecretCmd = &cobra.Command{
 			Data: data,
 		}
 
-		content, err := encryptSecret(text)
-		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
-			return
-		}
-
-		resp, err := secretClient.CreateSecret(context.Background(), &pb.CreateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := createSecret(name, collection, text)
 		if err != nil {
 			log.Fatal().Msgf("Failed to create secret: %v", err)
 			return
@@ -57,8 +52,5 @@ func init() {
 	if err := createTextSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
-	createTextSecretCmd.Flags().String("data", "", "Text data")
-	if err := createTextSecretCmd.MarkFlagRequired("data"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(createTextSecretCmd, "data", "", "Text data")
 }
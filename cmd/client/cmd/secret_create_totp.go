@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/qrcode"
+)
+
+// addTOTPFlags добавляет флаги параметров одноразовых паролей: URI otpauth://, изображение QR-кода
+// или отдельные параметры
+func addTOTPFlags(cmd *cobra.Command) {
+	cmd.Flags().String("uri", "", "otpauth://totp/ URI")
+	cmd.Flags().String("uri-file", "", "otpauth://totp/ URI source: file path, - for stdin or fd:N for file descriptor")
+	cmd.Flags().String("qr", "", "Path to QR code image (PNG, JPEG or GIF) with otpauth://totp/ URI")
+	addSensitiveFlag(cmd, "secret", "", "Base32 TOTP secret")
+	cmd.Flags().String("issuer", "", "TOTP issuer")
+	cmd.Flags().String("account", "", "TOTP account name")
+	cmd.Flags().String("algorithm", models.TOTPAlgorithmSHA1, "HMAC algorithm: SHA1|SHA256|SHA512")
+	cmd.Flags().Int("digits", models.DefaultTOTPDigits, "Number of code digits")
+	cmd.Flags().Int("period", models.DefaultTOTPPeriod, "Code period in seconds")
+	cmd.MarkFlagsMutuallyExclusive("uri", "uri-file", "qr", "secret", "secret-file")
+}
+
+// readTOTP читает параметры одноразовых паролей из URI, QR-кода или отдельных флагов.
+// Если не задан ни один источник, ключ запрашивается с терминала
+func readTOTP(cmd *cobra.Command) (models.TOTP, error) {
+	qr, err := cmd.Flags().GetString("qr")
+	if err != nil {
+		return models.TOTP{}, err
+	}
+	if qr != "" {
+		uri, err := qrcode.DecodeFile(qr)
+		if err != nil {
+			return models.TOTP{}, err
+		}
+		return models.ParseTOTPURI(uri)
+	}
+
+	if cmd.Flags().Changed("uri") || cmd.Flags().Changed("uri-file") {
+		uri, err := readSensitiveFlag(cmd, "uri", "TOTP URI", false)
+		if err != nil {
+			return models.TOTP{}, err
+		}
+		return models.ParseTOTPURI(uri)
+	}
+
+	var t models.TOTP
+	if t.Issuer, err = cmd.Flags().GetString("issuer"); err != nil {
+		return models.TOTP{}, err
+	}
+	if t.Account, err = cmd.Flags().GetString("account"); err != nil {
+		return models.TOTP{}, err
+	}
+	if t.Algorithm, err = cmd.Flags().GetString("algorithm"); err != nil {
+		return models.TOTP{}, err
+	}
+	if t.Digits, err = cmd.Flags().GetInt("digits"); err != nil {
+		return models.TOTP{}, err
+	}
+	if t.Period, err = cmd.Flags().GetInt("period"); err != nil {
+		return models.TOTP{}, err
+	}
+	if t.Secret, err = readSensitiveFlag(cmd, "secret", "TOTP secret", false); err != nil {
+		return models.TOTP{}, err
+	}
+	return t, t.Validate()
+}
+
+var createTOTPSecretCmd = &cobra.Command{
+	Use:   "totp",
+	Short: "Create TOTP secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		secret, err := readTOTP(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading TOTP parameters: %v", err)
+			return
+		}
+
+		resp, err := createSecret(name, collection, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to create secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s version %v created successfully\n", resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	createSecretCmd.AddCommand(createTOTPSecretCmd)
+
+	createTOTPSecretCmd.Flags().String("name", "", "Secret name")
+	if err := createTOTPSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	addTOTPFlags(createTOTPSecretCmd)
+}
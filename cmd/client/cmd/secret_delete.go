@@ -19,14 +19,21 @@ var deleteSecretCmd = &cobra.Command{
 			log.Fatal().Msgf("Error reading secret name: %v", err)
 		}
 
-		resp, err := secretClient.DeleteSecret(
-			context.Background(), &pb.DeleteSecretRequest{Name: name})
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		resp, err := secretClient.DeleteSecret(context.Background(), &pb.DeleteSecretRequest{
+			Name:       name,
+			Collection: collection,
+		})
 		if err != nil {
 			log.Fatal().Msgf("Failed to delete secret: %v", err)
 			return
 		}
 
-		fmt.Printf("Secret %s deleted successfully\n", resp.GetName())
+		fmt.Printf("Secret %s moved to trash successfully\n", resp.GetName())
 	},
 }
 
@@ -37,4 +44,6 @@ func init() {
 	if err := deleteSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
+	deleteSecretCmd.Flags().String("org", "", "Organization of the secret collection")
+	deleteSecretCmd.Flags().String("collection", "", "Organization collection of the secret")
 }
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/editor"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/prompt"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/secretedit"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// updateSecretVersion сохраняет новое содержимое собственного секрета, если на сервере
+// по-прежнему хранится версия expectedVersion. wrappedKey - ключ данных полученной версии секрета
+func updateSecretVersion(name, expectedVersion string, wrappedKey []byte, s models.Secret) (*pb.UpdateSecretResponse, error) {
+	var dataKey []byte
+	var err error
+	if len(wrappedKey) == 0 {
+		dataKey, wrappedKey, err = newDataKey()
+	} else {
+		dataKey, err = blockCipher.Decrypt(wrappedKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := encryptSecret(s, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return secretClient.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
+		Name:            name,
+		Content:         content,
+		DataKey:         wrappedKey,
+		ExpectedVersion: expectedVersion,
+	})
+}
+
+// editSecretDocument открывает документ секрета в редакторе, пока результат не пройдет проверку
+// или пользователь не откажется от повторного редактирования. Возвращает nil, если документ не изменен
+func editSecretDocument(document []byte, previous models.Secret) (models.Secret, error) {
+	text := document
+	for {
+		edited, err := editor.Edit(text, ".yaml")
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(edited)) == 0 || bytes.Equal(edited, document) {
+			return nil, nil
+		}
+
+		secret, err := secretedit.Unmarshal(edited, previous)
+		if err == nil {
+			return secret, nil
+		}
+		fmt.Printf("Invalid secret: %v\n", err)
+		again, confirmErr := prompt.Default.Confirm("Edit again?", true)
+		if confirmErr != nil || !again {
+			return nil, err
+		}
+		text = edited
+	}
+}
+
+var editSecretCmd = &cobra.Command{
+	Use:   "edit [flags] name",
+	Short: "Edit secret fields as YAML in $EDITOR",
+	Long: "Edit secret fields as YAML in $EDITOR. The result is validated against the secret type, " +
+		"the changed fields are shown with hidden values masked, and the secret is saved only " +
+		"if it was not changed on the server since it was opened",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		yes, err := cmd.Flags().GetBool("yes")
+		if err != nil {
+			log.Fatal().Msgf("Error reading confirmation flag: %v", err)
+		}
+
+		resp, secret, err := getSecret(name, "", nil)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get secret: %v", err)
+		}
+		document, err := secretedit.Marshal(name, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to edit secret: %v", err)
+		}
+
+		updated, err := editSecretDocument(document, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to edit secret: %v", err)
+		}
+		if updated == nil {
+			fmt.Println("Secret not changed")
+			return
+		}
+
+		changes := secretedit.Diff(secret, updated)
+		if len(changes) == 0 {
+			fmt.Println("Secret not changed")
+			return
+		}
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if !yes {
+			confirmed, err := prompt.Default.Confirm("Save changes?", false)
+			if err != nil {
+				log.Fatal().Msgf("Error reading confirmation: %v", err)
+			}
+			if !confirmed {
+				fmt.Println("Changes discarded")
+				return
+			}
+		}
+
+		result, err := updateSecretVersion(name, resp.GetVersion(), resp.GetDataKey(), updated)
+		if status.Code(err) == codes.Aborted {
+			log.Fatal().Msgf("Secret %s was changed since version %s, changes are not saved, edit it again", name, resp.GetVersion())
+		}
+		if err != nil {
+			log.Fatal().Msgf("Failed to update secret: %v", err)
+		}
+
+		fmt.Printf("Secret %s version %v updated successfully\n", result.GetName(), result.GetVersion())
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(editSecretCmd)
+
+	editSecretCmd.Flags().BoolP("yes", "y", false, "Save changes without confirmation")
+}
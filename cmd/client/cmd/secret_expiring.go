@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// expiringCard карта, срок действия которой истекает в пределах отчетного периода
+type expiringCard struct {
+	name   string
+	card   models.Card
+	expiry time.Time
+}
+
+// parsePeriod разбирает период в днях (60d) или в формате time.ParseDuration (72h)
+func parsePeriod(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+var expiringSecretCmd = &cobra.Command{
+	Use:   "expiring",
+	Short: "Report payment cards that expire soon or have already expired",
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := cmd.Flags().GetString("within")
+		if err != nil {
+			log.Fatal().Msgf("Error reading report period: %v", err)
+		}
+		within, err := parsePeriod(value)
+		if err != nil {
+			log.Fatal().Msgf("Error reading report period: %v", err)
+		}
+
+		resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list secrets")
+		}
+
+		now := time.Now()
+		var cards []expiringCard
+		for _, info := range resp.GetSecrets() {
+			secret, err := decryptSecret(info.GetContent(), info.GetDataKey())
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to decrypt secret %s", info.GetName())
+			}
+			card, ok := secret.(models.Card)
+			if !ok {
+				continue
+			}
+			expiry, err := card.Expiry()
+			if err != nil {
+				log.Warn().Err(err).Msgf("Skipping card %s", info.GetName())
+				continue
+			}
+			if expiry.Before(now.Add(within)) {
+				cards = append(cards, expiringCard{name: info.GetName(), card: card, expiry: expiry})
+			}
+		}
+		if len(cards) == 0 {
+			fmt.Printf("No cards expire within %s\n", value)
+			return
+		}
+		sort.Slice(cards, func(i, j int) bool {
+			return cards[i].expiry.Before(cards[j].expiry)
+		})
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "NAME\tNETWORK\tNUMBER\tEXPIRES\tDAYS LEFT")
+		for _, c := range cards {
+			left := "expired"
+			if c.expiry.After(now) {
+				left = strconv.Itoa(int(c.expiry.Sub(now).Hours() / 24))
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", c.name, c.card.Network(), c.card.MaskedNumber(), c.card.ExpiryDate, left)
+		}
+		if err = writer.Flush(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print expiring cards")
+		}
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(expiringSecretCmd)
+
+	expiringSecretCmd.Flags().String("within", "30d", "Report period in days (60d) or as a duration (72h)")
+}
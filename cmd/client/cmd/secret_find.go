@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/search"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// searchDocuments получает секреты пользователя и возвращает их поисковые документы.
+// Если задан индекс index, расшифровываются только секреты, версия которых изменилась с момента индексации
+func searchDocuments(index *search.Index) ([]search.Document, error) {
+	resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]search.Document, 0, len(resp.GetSecrets()))
+	names := make([]string, 0, len(resp.GetSecrets()))
+	for _, info := range resp.GetSecrets() {
+		names = append(names, info.GetName())
+		if index != nil {
+			if doc, ok := index.Lookup(info.GetName(), info.GetVersion()); ok {
+				docs = append(docs, doc)
+				continue
+			}
+		}
+
+		secret, err := decryptSecret(info.GetContent(), info.GetDataKey())
+		if err != nil {
+			return nil, err
+		}
+		doc := search.NewDocument(info.GetName(), info.GetVersion(), secret)
+		docs = append(docs, doc)
+		if index != nil {
+			index.Put(doc)
+		}
+	}
+	if index != nil {
+		index.Retain(names)
+	}
+	return docs, nil
+}
+
+var findSecretCmd = &cobra.Command{
+	Use:   "find [flags] query",
+	Short: "Fuzzy search secrets by name, login, card holder and text",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			log.Fatal().Msgf("Error reading result limit: %v", err)
+		}
+
+		useIndex, err := cmd.Flags().GetBool("index")
+		if err != nil {
+			log.Fatal().Msgf("Error reading index flag: %v", err)
+		}
+
+		var index *search.Index
+		var indexPath string
+		if useIndex {
+			if indexPath, err = search.ProfilePath(currentProfile()); err != nil {
+				log.Fatal().Err(err).Msg("Failed to find search index path")
+			}
+			if index, err = search.LoadIndex(indexPath, masterCipher()); err != nil {
+				log.Warn().Err(err).Msg("Failed to load search index, rebuilding")
+				index = search.NewIndex()
+			}
+		}
+
+		docs, err := searchDocuments(index)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load secrets")
+		}
+		if index != nil {
+			if err = index.Save(indexPath, masterCipher()); err != nil {
+				log.Warn().Err(err).Msg("Failed to save search index")
+			}
+		}
+
+		results := search.Find(strings.Join(args, " "), docs, limit)
+		if len(results) == 0 {
+			fmt.Fprintln(os.Stderr, "No secrets found")
+			os.Exit(1)
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "NAME\tTYPE\tMATCH")
+		for _, result := range results {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", result.Name, result.Type, result.Field)
+		}
+		if err = writer.Flush(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print search results")
+		}
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(findSecretCmd)
+
+	findSecretCmd.Flags().Int("limit", 20, "Maximum number of results, 0 for all")
+	findSecretCmd.Flags().Bool("index", false, "Use local search index encrypted with the master key to skip decrypting unchanged secrets")
+}
@@ -1,13 +1,12 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
+	"os"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/output"
 )
 
 var getSecretCmd = &cobra.Command{
@@ -19,19 +18,50 @@ var getSecretCmd = &cobra.Command{
 			log.Fatal().Msgf("Error reading secret name: %v", err)
 		}
 
-		resp, err := secretClient.GetSecret(context.Background(), &pb.GetSecretRequest{
-			Name: name,
-		})
+		owner, err := cmd.Flags().GetString("owner")
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get secret")
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		format, err := readOutputFormat(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading output format: %v", err)
+		}
+
+		field, err := cmd.Flags().GetString("field")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret field: %v", err)
 		}
 
-		secret, err := decryptSecret(resp.GetContent())
+		reveal, err := cmd.Flags().GetBool("reveal")
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to decrypt secret")
+			log.Fatal().Msgf("Error reading reveal flag: %v", err)
+		}
+
+		resp, secret, err := getSecret(name, owner, collection)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to get secret")
 		}
 
-		fmt.Printf("%s\n", secret)
+		record := output.NewRecord(resp.GetName(), resp.GetVersion(), secret)
+		if owner != "" || collection != nil {
+			record.Owner = owner
+			record.Permission = permissionName(resp.GetPermission())
+		}
+		record.Reveal = reveal
+		if field != "" {
+			err = output.WriteField(os.Stdout, record, field)
+		} else {
+			err = output.WriteRecord(os.Stdout, format, record)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to print secret")
+		}
 	},
 }
 
@@ -42,4 +72,10 @@ func init() {
 	if err := getSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
+	getSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
+	getSecretCmd.Flags().String("org", "", "Organization of the secret collection")
+	getSecretCmd.Flags().String("collection", "", "Organization collection of the secret")
+	getSecretCmd.Flags().String("field", "", "Print only the value of a single field, e.g. password")
+	getSecretCmd.Flags().Bool("reveal", false, "Show hidden fields such as passwords and card numbers in table output")
+	addOutputFlag(getSecretCmd)
 }
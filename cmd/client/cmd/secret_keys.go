@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var cachedPrivateKey []byte
+
+// loadPrivateKey загружает с сервера закрытый ключ пользователя и расшифровывает его мастер-ключом
+func loadPrivateKey() ([]byte, error) {
+	if cachedPrivateKey != nil {
+		return cachedPrivateKey, nil
+	}
+
+	resp, err := secretClient.GetKeyPair(context.Background(), &pb.GetKeyPairRequest{})
+	if err != nil {
+		return nil, err
+	}
+	key, err := blockCipher.Decrypt(resp.GetEncryptedPrivateKey())
+	if err != nil {
+		return nil, err
+	}
+	cachedPrivateKey = key
+	return cachedPrivateKey, nil
+}
+
+var keysSecretCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage key pair used to share secrets",
+}
+
+func init() {
+	secretCmd.AddCommand(keysSecretCmd)
+}
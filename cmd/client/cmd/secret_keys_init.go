@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
+)
+
+var initKeysSecretCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate key pair used to share secrets",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, err := secretClient.GetKeyPair(context.Background(), &pb.GetKeyPairRequest{})
+		if err == nil {
+			fmt.Println("Key pair already initialized")
+			return
+		}
+		if status.Code(err) != codes.NotFound {
+			log.Fatal().Msgf("Failed to get key pair: %v", err)
+		}
+
+		publicKey, privateKey, err := x25519.GenerateKeyPair()
+		if err != nil {
+			log.Fatal().Msgf("Failed to generate key pair: %v", err)
+		}
+		encryptedPrivateKey, err := blockCipher.Encrypt(privateKey)
+		if err != nil {
+			log.Fatal().Msgf("Failed to encrypt private key: %v", err)
+		}
+
+		_, err = secretClient.PutKeyPair(context.Background(), &pb.PutKeyPairRequest{
+			PublicKey:           publicKey,
+			EncryptedPrivateKey: encryptedPrivateKey,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to put key pair: %v", err)
+		}
+
+		fmt.Println("Key pair initialized successfully")
+	},
+}
+
+func init() {
+	keysSecretCmd.AddCommand(initKeysSecretCmd)
+}
@@ -2,11 +2,12 @@ package cmd
 
 import (
 	"context"
-	"fmt"
+	"os"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/output"
 	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
@@ -14,22 +15,46 @@ var listSecretCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List secrets",
 	Run: func(cmd *cobra.Command, args []string) {
-		resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{})
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		format, err := readOutputFormat(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading output format: %v", err)
+		}
+
+		resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{
+			Collection: collection,
+		})
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to list secret")
 		}
 
+		decrypt := decryptSecret
+		if collection != nil {
+			decrypt = decryptSharedSecret
+		}
+		records := make([]output.Record, 0, len(resp.GetSecrets()))
 		for _, info := range resp.GetSecrets() {
-			secret, err := decryptSecret(info.GetContent())
+			secret, err := decrypt(info.GetContent(), info.GetDataKey())
 			if err != nil {
 				log.Fatal().Err(err).Msg("Failed to decrypt secret")
 			}
+			records = append(records, output.NewRecord(info.GetName(), info.GetVersion(), secret))
+		}
 
-			fmt.Printf("%s\n", secret)
+		if err = output.WriteList(os.Stdout, format, records); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print secrets")
 		}
 	},
 }
 
 func init() {
 	secretCmd.AddCommand(listSecretCmd)
+
+	listSecretCmd.Flags().String("org", "", "Organization of the secret collection")
+	listSecretCmd.Flags().String("collection", "", "Organization collection of the secret")
+	addOutputFlag(listSecretCmd)
 }
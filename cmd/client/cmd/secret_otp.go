@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+var otpSecretCmd = &cobra.Command{
+	Use:   "otp [flags] name",
+	Short: "Print current one-time password of TOTP secret",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		_, secret, err := getSecret(args[0], owner, collection)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to get secret")
+		}
+
+		otp, ok := secret.(models.TOTP)
+		if !ok {
+			log.Fatal().Msgf("Secret %s is %s, not totp", args[0], secret.Type())
+		}
+		code, remaining, err := otp.Code(time.Now())
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to generate one-time password")
+		}
+
+		fmt.Println(code)
+		fmt.Fprintf(os.Stderr, "Valid for %ds\n", int(math.Ceil(remaining.Seconds())))
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(otpSecretCmd)
+
+	otpSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
+	otpSecretCmd.Flags().String("org", "", "Organization of the secret collection")
+	otpSecretCmd.Flags().String("collection", "", "Organization collection of the secret")
+}
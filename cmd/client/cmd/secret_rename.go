@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var renameSecretCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		newName, err := cmd.Flags().GetString("new-name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading new secret name: %v", err)
+		}
+
+		resp, err := secretClient.RenameSecret(context.Background(), &pb.RenameSecretRequest{
+			Name:    name,
+			NewName: newName,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to rename secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s renamed to %s successfully\n", name, resp.GetName())
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(renameSecretCmd)
+
+	renameSecretCmd.Flags().String("name", "", "Secret name")
+	if err := renameSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	renameSecretCmd.Flags().String("new-name", "", "New secret name")
+	if err := renameSecretCmd.MarkFlagRequired("new-name"); err != nil {
+		log.Error().Err(err)
+	}
+}
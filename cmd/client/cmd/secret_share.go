@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
+)
+
+var permissions = map[string]pb.Permission{
+	"read":       pb.Permission_PERMISSION_READ,
+	"read-write": pb.Permission_PERMISSION_READ_WRITE,
+}
+
+func permissionName(permission pb.Permission) string {
+	for name, p := range permissions {
+		if p == permission {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+var shareSecretCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Share secret with another user",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		recipient, err := cmd.Flags().GetString("recipient")
+		if err != nil {
+			log.Fatal().Msgf("Error reading recipient: %v", err)
+		}
+
+		permissionName, err := cmd.Flags().GetString("permission")
+		if err != nil {
+			log.Fatal().Msgf("Error reading permission: %v", err)
+		}
+		permission, ok := permissions[permissionName]
+		if !ok {
+			log.Fatal().Msgf("Unknown permission %q, expected read or read-write", permissionName)
+		}
+
+		publicKeyResp, err := secretClient.GetPublicKey(
+			context.Background(), &pb.GetPublicKeyRequest{Email: recipient})
+		if status.Code(err) == codes.NotFound {
+			log.Fatal().Msgf("User %s has no key pair, ask them to run `secret keys init`", recipient)
+		}
+		if err != nil {
+			log.Fatal().Msgf("Failed to get recipient public key: %v", err)
+		}
+
+		dataKey, err := shareableDataKey(name)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get secret data key: %v", err)
+		}
+
+		recipientKey, err := x25519.Seal(publicKeyResp.GetPublicKey(), dataKey)
+		if err != nil {
+			log.Fatal().Msgf("Failed to encrypt data key: %v", err)
+		}
+
+		resp, err := secretClient.ShareSecret(context.Background(), &pb.ShareSecretRequest{
+			Name:       name,
+			Recipient:  recipient,
+			WrappedKey: recipientKey,
+			Permission: permission,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to share secret: %v", err)
+		}
+
+		fmt.Printf("Secret %s shared with %s successfully\n", resp.GetName(), resp.GetRecipient())
+	},
+}
+
+// shareableDataKey возвращает ключ данных секрета. Секрет, зашифрованный непосредственно
+// мастер-ключом, предварительно перешифровывается новым ключом данных
+func shareableDataKey(name string) ([]byte, error) {
+	resp, err := secretClient.GetSecret(context.Background(), &pb.GetSecretRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GetDataKey()) != 0 {
+		return blockCipher.Decrypt(resp.GetDataKey())
+	}
+
+	secret, err := decryptSecret(resp.GetContent(), nil)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, wrappedKey, err := newDataKey()
+	if err != nil {
+		return nil, err
+	}
+	content, err := encryptSecret(secret, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	_, err = secretClient.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
+		Name:    name,
+		Content: content,
+		DataKey: wrappedKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dataKey, nil
+}
+
+func init() {
+	secretCmd.AddCommand(shareSecretCmd)
+
+	shareSecretCmd.Flags().String("name", "", "Secret name")
+	if err := shareSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	shareSecretCmd.Flags().String("recipient", "", "Recipient email")
+	if err := shareSecretCmd.MarkFlagRequired("recipient"); err != nil {
+		log.Error().Err(err)
+	}
+	shareSecretCmd.Flags().String("permission", "read", "Recipient permission: read or read-write")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/output"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var sharedSecretCmd = &cobra.Command{
+	Use:   "shared",
+	Short: "List secrets shared with you",
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := readOutputFormat(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading output format: %v", err)
+		}
+
+		resp, err := secretClient.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list shared secrets")
+		}
+
+		records := make([]output.Record, 0, len(resp.GetSecrets()))
+		for _, info := range resp.GetSecrets() {
+			secret, err := decryptSharedSecret(info.GetContent(), info.GetWrappedKey())
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to decrypt secret")
+			}
+			record := output.NewRecord(info.GetName(), info.GetVersion(), secret)
+			record.Owner = info.GetOwner()
+			record.Permission = permissionName(info.GetPermission())
+			records = append(records, record)
+		}
+
+		if err = output.WriteList(os.Stdout, format, records); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print secrets")
+		}
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(sharedSecretCmd)
+
+	addOutputFlag(sharedSecretCmd)
+}
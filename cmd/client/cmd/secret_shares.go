@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var sharesSecretCmd = &cobra.Command{
+	Use:   "shares",
+	Short: "List users the secret is shared with",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		resp, err := secretClient.ListSecretShares(context.Background(), &pb.ListSecretSharesRequest{Name: name})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list secret shares")
+		}
+
+		for _, info := range resp.GetShares() {
+			fmt.Printf("%s %s\n", info.GetRecipient(), permissionName(info.GetPermission()))
+		}
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(sharesSecretCmd)
+
+	sharesSecretCmd.Flags().String("name", "", "Secret name")
+	if err := sharesSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
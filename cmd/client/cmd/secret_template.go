@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+)
+
+// putTemplate шифрует шаблон новым ключом данных и сохраняет его на сервере
+func putTemplate(name string, t models.Template) error {
+	encoded, err := models.EncodeTemplate(t)
+	if err != nil {
+		return err
+	}
+	dataKey, wrappedKey, err := newDataKey()
+	if err != nil {
+		return err
+	}
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return err
+	}
+	content, err := dataCipher.Encrypt(encoded)
+	if err != nil {
+		return err
+	}
+
+	_, err = secretClient.PutTemplate(context.Background(), &pb.PutTemplateRequest{
+		Name:    name,
+		Content: content,
+		DataKey: wrappedKey,
+	})
+	return err
+}
+
+// decryptTemplate расшифровывает шаблон, полученный с сервера
+func decryptTemplate(info *pb.TemplateInfo) (models.Template, error) {
+	dataKey, err := blockCipher.Decrypt(info.GetDataKey())
+	if err != nil {
+		return models.Template{}, err
+	}
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return models.Template{}, err
+	}
+	encoded, err := dataCipher.Decrypt(info.GetContent())
+	if err != nil {
+		return models.Template{}, err
+	}
+	return models.DecodeTemplate(encoded)
+}
+
+// fetchTemplate получает и расшифровывает шаблон name
+func fetchTemplate(name string) (models.Template, error) {
+	resp, err := secretClient.GetTemplate(context.Background(), &pb.GetTemplateRequest{Name: name})
+	if err != nil {
+		return models.Template{}, err
+	}
+	return decryptTemplate(resp.GetTemplate())
+}
+
+var templateSecretCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage templates of custom secrets",
+}
+
+func init() {
+	secretCmd.AddCommand(templateSecretCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var deleteTemplateSecretCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete template of custom secrets, existing secrets are kept",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading template name: %v", err)
+		}
+
+		resp, err := secretClient.DeleteTemplate(context.Background(), &pb.DeleteTemplateRequest{Name: name})
+		if err != nil {
+			log.Fatal().Msgf("Failed to delete template: %v", err)
+		}
+
+		fmt.Printf("Template %s deleted successfully\n", resp.GetName())
+	},
+}
+
+func init() {
+	templateSecretCmd.AddCommand(deleteTemplateSecretCmd)
+
+	deleteTemplateSecretCmd.Flags().String("name", "", "Template name")
+	if err := deleteTemplateSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
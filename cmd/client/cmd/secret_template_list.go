@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var listTemplateSecretCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates of custom secrets",
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := secretClient.ListTemplates(context.Background(), &pb.ListTemplatesRequest{})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list templates")
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "NAME\tFIELDS")
+		for _, info := range resp.GetTemplates() {
+			template, err := decryptTemplate(info)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to decrypt template %s", info.GetName())
+			}
+			fields := make([]string, 0, len(template.Fields))
+			for _, field := range template.Fields {
+				fields = append(fields, fmt.Sprintf("%s:%s", field.Name, field.Kind))
+			}
+			fmt.Fprintf(writer, "%s\t%s\n", info.GetName(), strings.Join(fields, ", "))
+		}
+		if err = writer.Flush(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to print templates")
+		}
+	},
+}
+
+func init() {
+	templateSecretCmd.AddCommand(listTemplateSecretCmd)
+}
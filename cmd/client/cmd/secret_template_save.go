@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+var saveTemplateSecretCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Create or replace template of custom secrets",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading template name: %v", err)
+		}
+
+		specs, err := cmd.Flags().GetStringArray("field")
+		if err != nil {
+			log.Fatal().Msgf("Error reading template fields: %v", err)
+		}
+
+		var template models.Template
+		for _, spec := range specs {
+			field, err := models.ParseTemplateField(spec)
+			if err != nil {
+				log.Fatal().Msgf("Error reading template fields: %v", err)
+			}
+			template.Fields = append(template.Fields, field)
+		}
+		if err = template.Validate(); err != nil {
+			log.Fatal().Msgf("Error reading template fields: %v", err)
+		}
+
+		if err = putTemplate(name, template); err != nil {
+			log.Fatal().Msgf("Failed to save template: %v", err)
+		}
+
+		fmt.Printf("Template %s saved successfully\n", name)
+	},
+}
+
+func init() {
+	templateSecretCmd.AddCommand(saveTemplateSecretCmd)
+
+	saveTemplateSecretCmd.Flags().String("name", "", "Template name")
+	if err := saveTemplateSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	saveTemplateSecretCmd.Flags().StringArray("field", nil, "Template field name[:type], type is text|hidden|url|date, may be repeated")
+	if err := saveTemplateSecretCmd.MarkFlagRequired("field"); err != nil {
+		log.Error().Err(err)
+	}
+}
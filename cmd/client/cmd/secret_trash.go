@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var trashSecretCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage deleted secrets",
+}
+
+func init() {
+	secretCmd.AddCommand(trashSecretCmd)
+
+	trashSecretCmd.PersistentFlags().String("org", "", "Organization of the secret collection")
+	trashSecretCmd.PersistentFlags().String("collection", "", "Organization collection trash, requires owner or admin role")
+}
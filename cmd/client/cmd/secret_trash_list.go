@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var listTrashSecretCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deleted secrets",
+	Run: func(cmd *cobra.Command, args []string) {
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		resp, err := secretClient.ListDeletedSecrets(context.Background(), &pb.ListDeletedSecretsRequest{
+			Collection: collection,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to list deleted secrets")
+		}
+
+		for _, info := range resp.GetSecrets() {
+			fmt.Printf("%s version %s deleted at %s",
+				info.GetName(), info.GetVersion(), info.GetDeletedAt().AsTime().Local().Format(time.RFC3339))
+			if info.GetPurgeAt() != nil {
+				fmt.Printf(", will be purged at %s", info.GetPurgeAt().AsTime().Local().Format(time.RFC3339))
+			}
+			fmt.Println()
+		}
+	},
+}
+
+func init() {
+	trashSecretCmd.AddCommand(listTrashSecretCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var purgeTrashSecretCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently delete secret from trash",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		resp, err := secretClient.PurgeSecret(context.Background(), &pb.PurgeSecretRequest{
+			Name:       name,
+			Collection: collection,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to purge secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s purged successfully\n", resp.GetName())
+	},
+}
+
+func init() {
+	trashSecretCmd.AddCommand(purgeTrashSecretCmd)
+
+	purgeTrashSecretCmd.Flags().String("name", "", "Secret name")
+	if err := purgeTrashSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
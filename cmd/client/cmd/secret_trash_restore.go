@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var restoreTrashSecretCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore deleted secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		resp, err := secretClient.RestoreSecret(context.Background(), &pb.RestoreSecretRequest{
+			Name:       name,
+			Collection: collection,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to restore secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s version %v restored successfully\n", resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	trashSecretCmd.AddCommand(restoreTrashSecretCmd)
+
+	restoreTrashSecretCmd.Flags().String("name", "", "Secret name")
+	if err := restoreTrashSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+}
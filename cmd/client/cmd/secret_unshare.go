@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+var unshareSecretCmd = &cobra.Command{
+	Use:   "unshare",
+	Short: "Revoke another user's access to secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+		}
+
+		recipient, err := cmd.Flags().GetString("recipient")
+		if err != nil {
+			log.Fatal().Msgf("Error reading recipient: %v", err)
+		}
+
+		resp, err := secretClient.UnshareSecret(context.Background(), &pb.UnshareSecretRequest{
+			Name:      name,
+			Recipient: recipient,
+		})
+		if err != nil {
+			log.Fatal().Msgf("Failed to unshare secret: %v", err)
+		}
+
+		fmt.Printf("Secret %s unshared from %s successfully\n", resp.GetName(), resp.GetRecipient())
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(unshareSecretCmd)
+
+	unshareSecretCmd.Flags().String("name", "", "Secret name")
+	if err := unshareSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	unshareSecretCmd.Flags().String("recipient", "", "Recipient email")
+	if err := unshareSecretCmd.MarkFlagRequired("recipient"); err != nil {
+		log.Error().Err(err)
+	}
+}
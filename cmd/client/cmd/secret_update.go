@@ -11,4 +11,7 @@ var updateSecretCmd = &cobra.Command{
 
 func init() {
 	secretCmd.AddCommand(updateSecretCmd)
+
+	updateSecretCmd.PersistentFlags().String("org", "", "Organization of the secret collection")
+	updateSecretCmd.PersistentFlags().String("collection", "", "Organization collection of the secret")
 }
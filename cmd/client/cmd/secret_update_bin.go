@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"io/ioutil"
 
@@ -9,7 +8,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var updateBinSecretCmd = &cobra.Command{
@@ -22,6 +20,18 @@ var updateBinSecretCmd = &cobra.Command{
 			return
 		}
 
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+			return
+		}
+
 		file, err := cmd.Flags().GetString("file")
 		if err != nil {
 			log.Fatal().Msgf("Error reading file name: %v", err)
@@ -38,16 +48,7 @@ var updateBinSecretCmd = &cobra.Command{
 			Data: data,
 		}
 
-		content, err := encryptSecret(bin)
-		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
-			return
-		}
-
-		resp, err := secretClient.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := updateSecret(name, owner, collection, bin)
 		if err != nil {
 			log.Fatal().Msgf("Failed to update secret: %v", err)
 			return
@@ -64,6 +65,7 @@ func init() {
 	if err := updateBinSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
+	updateBinSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
 	updateBinSecretCmd.Flags().StringP("file", "f", "", "Binary file")
 	if err := updateBinSecretCmd.MarkFlagRequired("file"); err != nil {
 		log.Error().Err(err)
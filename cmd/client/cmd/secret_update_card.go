@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var updateCardSecretCmd = &cobra.Command{
@@ -21,7 +19,19 @@ var updateCardSecretCmd = &cobra.Command{
 			return
 		}
 
-		number, err := cmd.Flags().GetString("number")
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+			return
+		}
+
+		number, err := readSensitiveFlag(cmd, "number", "Card number", true)
 		if err != nil {
 			log.Fatal().Msgf("Error reading card number: %v", err)
 			return
@@ -33,7 +43,7 @@ var updateCardSecretCmd = &cobra.Command{
 			return
 		}
 
-		code, err := cmd.Flags().GetString("code")
+		code, err := readSensitiveFlag(cmd, "code", "Card security code", true)
 		if err != nil {
 			log.Fatal().Msgf("Error reading card security code: %v", err)
 			return
@@ -45,23 +55,13 @@ var updateCardSecretCmd = &cobra.Command{
 			return
 		}
 
-		card := models.Card{
-			Number:       number,
-			ExpiryDate:   date,
-			SecurityCode: code,
-			Holder:       holder,
-		}
-
-		content, err := encryptSecret(card)
+		card, err := models.ParseCard(number, date, code, holder)
 		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
+			log.Fatal().Msgf("Invalid card: %v", err)
 			return
 		}
 
-		resp, err := secretClient.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := updateSecret(name, owner, collection, card)
 		if err != nil {
 			log.Fatal().Msgf("Failed to update secret: %v", err)
 			return
@@ -78,18 +78,13 @@ func init() {
 	if err := updateCardSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
-	updateCardSecretCmd.Flags().String("number", "", "Card number")
-	if err := updateCardSecretCmd.MarkFlagRequired("number"); err != nil {
-		log.Error().Err(err)
-	}
-	updateCardSecretCmd.Flags().String("date", "", "Card expiry date")
+	updateCardSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
+	addSensitiveFlag(updateCardSecretCmd, "number", "", "Card number")
+	updateCardSecretCmd.Flags().String("date", "", "Card expiry date in MM/YY format")
 	if err := updateCardSecretCmd.MarkFlagRequired("date"); err != nil {
 		log.Error().Err(err)
 	}
-	updateCardSecretCmd.Flags().String("code", "", "Card security code")
-	if err := updateCardSecretCmd.MarkFlagRequired("code"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(updateCardSecretCmd, "code", "", "Card security code")
 	updateCardSecretCmd.Flags().String("holder", "", "Card holder")
 	if err := updateCardSecretCmd.MarkFlagRequired("holder"); err != nil {
 		log.Error().Err(err)
@@ -1,14 +1,13 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"os"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var updateCredentialsSecretCmd = &cobra.Command{
@@ -20,13 +19,34 @@ var updateCredentialsSecretCmd = &cobra.Command{
 			log.Fatal().Msgf("Error reading secret name: %v", err)
 		}
 
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+		}
+
 		login, err := cmd.Flags().GetString("login")
 		if err != nil {
 			log.Fatal().Msgf("Error reading login: %v", err)
 		}
 
-		password, err := cmd.Flags().GetString("password")
+		generate, err := cmd.Flags().GetBool("generate")
 		if err != nil {
+			log.Fatal().Msgf("Error reading generate flag: %v", err)
+		}
+
+		var password string
+		if generate {
+			var entropy float64
+			if password, entropy, err = generatePassword(cmd.Flags()); err != nil {
+				log.Fatal().Err(err).Msg("Failed to generate password")
+			}
+			fmt.Fprintf(os.Stderr, "Generated password with estimated entropy %.0f bits\n", entropy)
+		} else if password, err = readSensitiveFlag(cmd, "password", "Password", true); err != nil {
 			log.Fatal().Msgf("Error reading password: %v", err)
 		}
 
@@ -35,16 +55,7 @@ var updateCredentialsSecretCmd = &cobra.Command{
 			Password: password,
 		}
 
-		content, err := encryptSecret(credentials)
-		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
-			return
-		}
-
-		resp, err := secretClient.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := updateSecret(name, owner, collection, credentials)
 		if err != nil {
 			log.Fatal().Msgf("Failed to update secret: %v", err)
 			return
@@ -61,12 +72,14 @@ func init() {
 	if err := updateCredentialsSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
+	updateCredentialsSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
 	updateCredentialsSecretCmd.Flags().String("login", "", "Login")
 	if err := updateCredentialsSecretCmd.MarkFlagRequired("login"); err != nil {
 		log.Error().Err(err)
 	}
-	updateCredentialsSecretCmd.Flags().String("password", "", "Password")
-	if err := updateCredentialsSecretCmd.MarkFlagRequired("password"); err != nil {
-		log.Error().Err(err)
-	}
+	addSensitiveFlag(updateCredentialsSecretCmd, "password", "", "Password")
+	updateCredentialsSecretCmd.Flags().Bool("generate", false, "Generate random password instead of --password")
+	updateCredentialsSecretCmd.MarkFlagsMutuallyExclusive("password", "generate")
+	updateCredentialsSecretCmd.MarkFlagsMutuallyExclusive("password-file", "generate")
+	addGeneratorFlags(updateCredentialsSecretCmd.Flags())
 }
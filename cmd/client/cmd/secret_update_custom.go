@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+var updateCustomSecretCmd = &cobra.Command{
+	Use:   "custom",
+	Short: "Update fields of secret with custom fields",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+			return
+		}
+
+		removed, err := cmd.Flags().GetStringArray("remove-field")
+		if err != nil {
+			log.Fatal().Msgf("Error reading removed fields: %v", err)
+			return
+		}
+
+		_, current, err := getSecret(name, owner, collection)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get secret: %v", err)
+			return
+		}
+		secret, ok := current.(models.Custom)
+		if !ok {
+			log.Fatal().Msgf("Secret %s is %s, not custom", name, current.Type())
+			return
+		}
+
+		for _, field := range removed {
+			if !secret.Remove(field) {
+				log.Fatal().Msgf("Secret %s has no field %s", name, field)
+				return
+			}
+		}
+		if err = applyCustomFields(cmd, &secret); err != nil {
+			log.Fatal().Msgf("Error reading custom fields: %v", err)
+			return
+		}
+		if err = secret.Validate(); err != nil {
+			log.Fatal().Msgf("Error reading custom fields: %v", err)
+			return
+		}
+
+		resp, err := updateSecret(name, owner, collection, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to update secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s version %v updated successfully\n", resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	updateSecretCmd.AddCommand(updateCustomSecretCmd)
+
+	updateCustomSecretCmd.Flags().String("name", "", "Secret name")
+	if err := updateCustomSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	updateCustomSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
+	updateCustomSecretCmd.Flags().StringArray("remove-field", nil, "Name of field to remove, may be repeated")
+	addCustomFieldFlags(updateCustomSecretCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+var updateNoteSecretCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Edit note text and attachments",
+	Long: "Edit note text and attachments. The text is opened in $EDITOR unless only attachments, " +
+		"the title or --body-file are given, or --edit is set explicitly",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		attach, err := cmd.Flags().GetStringArray("attach")
+		if err != nil {
+			log.Fatal().Msgf("Error reading attachments: %v", err)
+			return
+		}
+
+		detach, err := cmd.Flags().GetStringArray("detach")
+		if err != nil {
+			log.Fatal().Msgf("Error reading detached attachments: %v", err)
+			return
+		}
+		if collection != nil && len(attach)+len(detach) > 0 {
+			log.Fatal().Msgf("Error reading attachments: %v", errCollectionAttachments)
+			return
+		}
+
+		edit, err := cmd.Flags().GetBool("edit")
+		if err != nil {
+			log.Fatal().Msgf("Error reading edit flag: %v", err)
+			return
+		}
+		if !cmd.Flags().Changed("edit") {
+			edit = len(attach)+len(detach) == 0 && !cmd.Flags().Changed("title") && !cmd.Flags().Changed("body-file")
+		}
+
+		_, current, err := getSecret(name, "", collection)
+		if err != nil {
+			log.Fatal().Msgf("Failed to get secret: %v", err)
+			return
+		}
+		note, ok := current.(models.Note)
+		if !ok {
+			log.Fatal().Msgf("Secret %s is %s, not note", name, current.Type())
+			return
+		}
+
+		if note, err = readNoteText(cmd, note, edit); err != nil {
+			log.Fatal().Msgf("Error reading note text: %v", err)
+			return
+		}
+
+		detached := make([]models.Attachment, 0, len(detach))
+		for _, attachmentName := range detach {
+			attachment, err := note.Detach(attachmentName)
+			if err != nil {
+				log.Fatal().Msgf("Error reading detached attachments: %v", err)
+				return
+			}
+			detached = append(detached, attachment)
+		}
+		if err = attachFiles(name, &note, attach); err != nil {
+			log.Fatal().Msgf("Failed to attach files: %v", err)
+			return
+		}
+
+		resp, err := updateSecret(name, "", collection, note)
+		if err != nil {
+			log.Fatal().Msgf("Failed to update secret: %v", err)
+			return
+		}
+		// Вложения удаляются только после сохранения заметки, которая на них больше не ссылается
+		deleteAttachments(name, detached)
+
+		fmt.Printf("Secret %s version %v updated successfully\n", resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	updateSecretCmd.AddCommand(updateNoteSecretCmd)
+
+	updateNoteSecretCmd.Flags().String("name", "", "Secret name")
+	if err := updateNoteSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	addNoteFlags(updateNoteSecretCmd)
+	updateNoteSecretCmd.Flags().StringArray("detach", nil, "Remove attachment with the given name, may be repeated")
+	updateNoteSecretCmd.Flags().Bool("edit", false, "Open the note text in $EDITOR")
+}
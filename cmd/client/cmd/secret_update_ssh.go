@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var updateSSHKeySecretCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "Update SSH key secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+			return
+		}
+
+		secret, err := readSSHKey(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading SSH key: %v", err)
+			return
+		}
+
+		resp, err := updateSecret(name, owner, collection, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to update secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s version %v updated successfully\n", resp.GetName(), resp.GetVersion())
+		fmt.Println(secret.PublicKey)
+	},
+}
+
+func init() {
+	updateSecretCmd.AddCommand(updateSSHKeySecretCmd)
+
+	updateSSHKeySecretCmd.Flags().String("name", "", "Secret name")
+	if err := updateSSHKeySecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	updateSSHKeySecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
+	addSSHKeyFlags(updateSSHKeySecretCmd)
+}
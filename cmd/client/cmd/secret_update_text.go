@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
-	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 )
 
 var updateTextSecretCmd = &cobra.Command{
@@ -21,26 +19,29 @@ var updateTextSecretCmd = &cobra.Command{
 			return
 		}
 
-		data, err := cmd.Flags().GetString("data")
+		collection, err := readCollectionRef(cmd)
 		if err != nil {
-			log.Fatal().Msgf("Error reading text data: %v", err)
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
 			return
 		}
 
-		text := models.Text{
-			Data: data,
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+			return
 		}
 
-		content, err := encryptSecret(text)
+		data, err := readSensitiveFlag(cmd, "data", "Text data", true)
 		if err != nil {
-			log.Fatal().Msgf("Failed to encrypt secret: %v", err)
+			log.Fatal().Msgf("Error reading text data: %v", err)
 			return
 		}
 
-		resp, err := secretClient.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
-			Name:    name,
-			Content: content,
-		})
+		text := models.Text{
+			Data: data,
+		}
+
+		resp, err := updateSecret(name, owner, collection, text)
 		if err != nil {
 			log.Fatal().Msgf("Failed to update secret: %v", err)
 			return
@@ -57,8 +58,6 @@ func init() {
 	if err := updateTextSecretCmd.MarkFlagRequired("name"); err != nil {
 		log.Error().Err(err)
 	}
-	updateTextSecretCmd.Flags().String("data", "", "Text data")
-	if err := updateTextSecretCmd.MarkFlagRequired("data"); err != nil {
-		log.Error().Err(err)
-	}
+	updateTextSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
+	addSensitiveFlag(updateTextSecretCmd, "data", "", "Text data")
 }
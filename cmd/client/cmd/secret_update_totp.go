@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var updateTOTPSecretCmd = &cobra.Command{
+	Use:   "totp",
+	Short: "Update TOTP secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret name: %v", err)
+			return
+		}
+
+		collection, err := readCollectionRef(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret collection: %v", err)
+			return
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			log.Fatal().Msgf("Error reading secret owner: %v", err)
+			return
+		}
+
+		secret, err := readTOTP(cmd)
+		if err != nil {
+			log.Fatal().Msgf("Error reading TOTP parameters: %v", err)
+			return
+		}
+
+		resp, err := updateSecret(name, owner, collection, secret)
+		if err != nil {
+			log.Fatal().Msgf("Failed to update secret: %v", err)
+			return
+		}
+
+		fmt.Printf("Secret %s version %v updated successfully\n", resp.GetName(), resp.GetVersion())
+	},
+}
+
+func init() {
+	updateSecretCmd.AddCommand(updateTOTPSecretCmd)
+
+	updateTOTPSecretCmd.Flags().String("name", "", "Secret name")
+	if err := updateTOTPSecretCmd.MarkFlagRequired("name"); err != nil {
+		log.Error().Err(err)
+	}
+	updateTOTPSecretCmd.Flags().String("owner", "", "Owner email of a secret shared with you")
+	addTOTPFlags(updateTOTPSecretCmd)
+}
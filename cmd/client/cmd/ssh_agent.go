@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/agent"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/sshagent"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+)
+
+// loadSSHKeys возвращает SSH-ключи пользователя. Если names не пуст, загружаются только
+// секреты с указанными названиями, и каждый из них должен быть SSH-ключом
+func loadSSHKeys(names []string) ([]sshagent.Key, error) {
+	resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var keys []sshagent.Key
+	for _, info := range resp.GetSecrets() {
+		if len(wanted) > 0 && !wanted[info.GetName()] {
+			continue
+		}
+		secret, err := decryptSecret(info.GetContent(), info.GetDataKey())
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", info.GetName(), err)
+		}
+		key, ok := secret.(models.SSHKey)
+		if !ok {
+			if wanted[info.GetName()] {
+				return nil, fmt.Errorf("secret %s is %s, not ssh", info.GetName(), secret.Type())
+			}
+			continue
+		}
+		keys = append(keys, sshagent.Key{Name: info.GetName(), SSHKey: key})
+		delete(wanted, info.GetName())
+	}
+	for _, name := range names {
+		if wanted[name] {
+			return nil, fmt.Errorf("secret %s not found", name)
+		}
+	}
+	return keys, nil
+}
+
+var sshAgentCmd = &cobra.Command{
+	Use:   "ssh-agent [flags] [name...]",
+	Short: "Serve SSH key secrets over ssh-agent protocol",
+	Long: "Serve SSH key secrets over ssh-agent protocol in foreground. " +
+		"Keys are kept in memory only; by default all SSH key secrets are served",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		socketPath, err := cmd.Flags().GetString("socket")
+		if err != nil {
+			log.Fatal().Msgf("Error reading socket path: %v", err)
+		}
+		if socketPath == "" {
+			socketPath = sshagent.DefaultSocketPath(currentProfile())
+		}
+
+		keys, err := loadSSHKeys(args)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load SSH keys")
+		}
+		if len(keys) == 0 {
+			log.Fatal().Msg("No SSH key secrets found")
+		}
+		keyring, err := sshagent.NewKeyring(keys)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load SSH keys")
+		}
+
+		signal.Ignore(syscall.SIGHUP)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		listener, err := agent.Listen(socketPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to listen SSH agent socket")
+		}
+		defer os.Remove(socketPath)
+
+		fmt.Printf("SSH_AUTH_SOCK=%s; export SSH_AUTH_SOCK;\n", socketPath)
+		fmt.Fprintf(os.Stderr, "Serving %d SSH keys, press Ctrl+C to stop\n", len(keys))
+
+		if err = sshagent.Serve(ctx, listener, keyring); err != nil {
+			log.Error().Err(err).Msg("Error on SSH agent Serve")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sshAgentCmd)
+
+	sshAgentCmd.Flags().String("socket", "", "SSH agent unix socket path, by default next to agent socket")
+}
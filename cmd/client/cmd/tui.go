@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/tui"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/version"
+)
+
+// vaultBackend операции терминального интерфейса с собственными секретами пользователя
+type vaultBackend struct{}
+
+var _ tui.Backend = vaultBackend{}
+
+// Sync получает и расшифровывает секреты пользователя, версия сервера передается в заголовке ответа
+func (vaultBackend) Sync() (*tui.Snapshot, error) {
+	var header metadata.MD
+	resp, err := secretClient.ListSecrets(context.Background(), &pb.ListSecretsRequest{}, grpc.Header(&header))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &tui.Snapshot{Items: make([]tui.Item, 0, len(resp.GetSecrets()))}
+	if values := header.Get(version.MetadataKey); len(values) > 0 {
+		snapshot.ServerVersion = values[0]
+	}
+	for _, info := range resp.GetSecrets() {
+		secret, err := decryptSecret(info.GetContent(), info.GetDataKey())
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Items = append(snapshot.Items, tui.Item{
+			Name:    info.GetName(),
+			Version: info.GetVersion(),
+			Secret:  secret,
+		})
+	}
+	return snapshot, nil
+}
+
+// Create шифрует и сохраняет новый секрет
+func (vaultBackend) Create(name string, s models.Secret) error {
+	_, err := createSecret(name, nil, s)
+	return err
+}
+
+// Update обновляет содержимое секрета
+func (vaultBackend) Update(name string, s models.Secret) error {
+	_, err := updateSecret(name, "", nil, s)
+	return err
+}
+
+// Delete перемещает секрет в корзину
+func (vaultBackend) Delete(name string) error {
+	_, err := secretClient.DeleteSecret(context.Background(), &pb.DeleteSecretRequest{Name: name})
+	return err
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and edit secrets in a full-screen terminal interface",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initSecretClients()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := tui.Run(vaultBackend{}); err != nil {
+			log.Fatal().Err(err).Msg("Failed to run terminal interface")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -16,10 +16,12 @@ import (
 var (
 	cfgFile  string
 	defaults = map[string]interface{}{
-		"grpc.address": "127.0.0.1:8081",
-		"db.url":       "",
-		"auth.key":     "",
-		"hasher.key":   "",
+		"grpc.address":           "127.0.0.1:8081",
+		"db.url":                 "",
+		"auth.key":               "",
+		"hasher.key":             "",
+		"trash.retention":        30 * 24 * time.Hour,
+		"trash.cleanup_interval": time.Hour,
 	}
 )
 
@@ -60,6 +62,9 @@ func init() {
 	rootCmd.PersistentFlags().StringP(
 		"hasher-key", "i", "", "Hash key")
 
+	rootCmd.PersistentFlags().Duration(
+		"trash-retention", 30*24*time.Hour, "Deleted secrets retention period, 0 disables purging")
+
 	cobra.OnInitialize(initConfig)
 }
 
@@ -0,0 +1,193 @@
+// Package agent реализует агент клиента, хранящий разблокированный мастер-ключ
+// и выполняющий по запросам других запусков клиента шифрование и расшифрование данных
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/memlock"
+)
+
+// Agent реализация proto.AgentServiceServer.
+// Мастер-ключ хранится в памяти, защищенной от выгрузки в swap, и затирается при блокировке
+type Agent struct {
+	mu          sync.Mutex
+	key         []byte
+	cipher      *gcm.MasterCipher
+	idleTimeout time.Duration
+	idleTimer   *time.Timer
+	stop        func()
+	pb.UnimplementedAgentServiceServer
+}
+
+var _ pb.AgentServiceServer = (*Agent)(nil)
+
+// New создает заблокированный агент. Агент блокируется автоматически, если в течение
+// idleTimeout к нему не поступало запросов шифрования; нулевое значение отключает автоблокировку.
+// Функция stop вызывается по запросу остановки агента
+func New(idleTimeout time.Duration, stop func()) *Agent {
+	return &Agent{
+		idleTimeout: idleTimeout,
+		stop:        stop,
+	}
+}
+
+// RegisterService функция регистрации агента на сервере gRPC
+func (a *Agent) RegisterService(s grpc.ServiceRegistrar) {
+	pb.RegisterAgentServiceServer(s, a)
+}
+
+// Unlock вычисляет мастер-ключ по паролю и разблокирует агент.
+// Если передан check - данные, зашифрованные мастер-ключом, - пароль принимается, только если они расшифровываются
+func (a *Agent) Unlock(_ context.Context, request *pb.UnlockRequest) (*pb.UnlockResponse, error) {
+	key := make([]byte, gcm.DerivedKeySize)
+	if err := gcm.DeriveKey(request.GetPassword(), key); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid master password")
+	}
+	if err := memlock.Lock(key); err != nil {
+		log.Warn().Err(err).Msg("Failed to lock master key in memory")
+	}
+	blockCipher, err := gcm.NewMasterFromKey(key)
+	if err != nil {
+		releaseKey(key)
+		return nil, status.Error(codes.Internal, "failed to create cipher")
+	}
+	if len(request.GetCheck()) > 0 {
+		if _, err = blockCipher.Decrypt(request.GetCheck()); err != nil {
+			releaseKey(key)
+			return nil, status.Error(codes.InvalidArgument, "wrong master password")
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.lock()
+	a.key = key
+	a.cipher = blockCipher
+	a.touch()
+
+	log.Info().Msg("Agent unlocked")
+	return &pb.UnlockResponse{}, nil
+}
+
+// Lock затирает мастер-ключ и блокирует агент
+func (a *Agent) Lock(_ context.Context, _ *pb.LockRequest) (*pb.LockResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.lock()
+	return &pb.LockResponse{}, nil
+}
+
+// Status возвращает состояние агента
+func (a *Agent) Status(_ context.Context, _ *pb.StatusRequest) (*pb.StatusResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return &pb.StatusResponse{
+		Locked:             a.cipher == nil,
+		IdleTimeoutSeconds: int64(a.idleTimeout / time.Second),
+	}, nil
+}
+
+// Stop блокирует и останавливает агент
+func (a *Agent) Stop(_ context.Context, _ *pb.StopRequest) (*pb.StopResponse, error) {
+	a.mu.Lock()
+	a.lock()
+	a.mu.Unlock()
+
+	if a.stop != nil {
+		a.stop()
+	}
+	return &pb.StopResponse{}, nil
+}
+
+// Encrypt шифрует данные мастер-ключом
+func (a *Agent) Encrypt(_ context.Context, request *pb.EncryptRequest) (*pb.EncryptResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cipher == nil {
+		return nil, status.Error(codes.FailedPrecondition, "agent is locked")
+	}
+	a.touch()
+
+	ciphertext, err := a.cipher.Encrypt(request.GetPlaintext())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to encrypt data")
+	}
+	return &pb.EncryptResponse{Ciphertext: ciphertext}, nil
+}
+
+// Decrypt расшифровывает данные мастер-ключом
+func (a *Agent) Decrypt(_ context.Context, request *pb.DecryptRequest) (*pb.DecryptResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cipher == nil {
+		return nil, status.Error(codes.FailedPrecondition, "agent is locked")
+	}
+	a.touch()
+
+	plaintext, err := a.cipher.Decrypt(request.GetCiphertext())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "failed to decrypt data")
+	}
+	return &pb.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// lock затирает мастер-ключ; вызывается при захваченном мьютексе
+func (a *Agent) lock() {
+	if a.idleTimer != nil {
+		a.idleTimer.Stop()
+		a.idleTimer = nil
+	}
+	if a.key == nil {
+		return
+	}
+
+	releaseKey(a.key)
+	a.key = nil
+	a.cipher = nil
+
+	log.Info().Msg("Agent locked")
+}
+
+// releaseKey затирает ключ и снимает блокировку его памяти
+func releaseKey(key []byte) {
+	memlock.Wipe(key)
+	if err := memlock.Unlock(key); err != nil {
+		log.Warn().Err(err).Msg("Failed to unlock master key memory")
+	}
+}
+
+// touch откладывает автоблокировку агента; вызывается при захваченном мьютексе
+func (a *Agent) touch() {
+	if a.idleTimeout <= 0 {
+		return
+	}
+	if a.idleTimer != nil {
+		a.idleTimer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(a.idleTimeout, func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		if a.idleTimer == timer {
+			a.lock()
+		}
+	})
+	a.idleTimer = timer
+}
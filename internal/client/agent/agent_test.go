@@ -0,0 +1,149 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+)
+
+const password = "11112222333344445555666677778888"
+
+func startAgent(t *testing.T, idleTimeout time.Duration) (pb.AgentServiceClient, <-chan error) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "gophkeeper")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "agent", "agent.sock")
+	listener, err := Listen(socketPath)
+	require.NoError(t, err)
+
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, listener, New(idleTimeout, cancel))
+	}()
+
+	_, err = Listen(socketPath)
+	assert.Error(t, err)
+
+	conn, err := Dial(socketPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewAgentServiceClient(conn), done
+}
+
+func TestAgent(t *testing.T) {
+	client, done := startAgent(t, 0)
+	ctx := context.Background()
+
+	expected, err := gcm.NewMaster(password)
+	require.NoError(t, err)
+	ciphertext, err := expected.Encrypt([]byte("secret"))
+	require.NoError(t, err)
+	legacy, err := gcm.New(password)
+	require.NoError(t, err)
+	legacyCiphertext, err := legacy.Encrypt([]byte("secret"))
+	require.NoError(t, err)
+
+	t.Run("Locked", func(t *testing.T) {
+		_, err := client.Decrypt(ctx, &pb.DecryptRequest{Ciphertext: ciphertext})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("ShortPassword", func(t *testing.T) {
+		_, err := client.Unlock(ctx, &pb.UnlockRequest{Password: "short"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("WrongPassword", func(t *testing.T) {
+		_, err := client.Unlock(ctx, &pb.UnlockRequest{
+			Password: "88887777666655554444333322221111",
+			Check:    ciphertext,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		resp, err := client.Status(ctx, &pb.StatusRequest{})
+		require.NoError(t, err)
+		assert.True(t, resp.GetLocked())
+	})
+	t.Run("Unlocked", func(t *testing.T) {
+		_, err := client.Unlock(ctx, &pb.UnlockRequest{Password: password, Check: legacyCiphertext})
+		require.NoError(t, err)
+
+		resp, err := client.Decrypt(ctx, &pb.DecryptRequest{Ciphertext: ciphertext})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), resp.GetPlaintext())
+
+		resp, err = client.Decrypt(ctx, &pb.DecryptRequest{Ciphertext: legacyCiphertext})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), resp.GetPlaintext())
+
+		agentCipher := NewCipher(client)
+		encrypted, err := agentCipher.Encrypt([]byte("data"))
+		require.NoError(t, err)
+		decrypted, err := expected.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), decrypted)
+
+		again, err := agentCipher.Encrypt([]byte("data"))
+		require.NoError(t, err)
+		assert.NotEqual(t, encrypted, again)
+	})
+	t.Run("Lock", func(t *testing.T) {
+		_, err := client.Lock(ctx, &pb.LockRequest{})
+		require.NoError(t, err)
+
+		resp, err := client.Status(ctx, &pb.StatusRequest{})
+		require.NoError(t, err)
+		assert.True(t, resp.GetLocked())
+
+		_, err = NewCipher(client).Encrypt([]byte("data"))
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("Stop", func(t *testing.T) {
+		_, err := client.Stop(ctx, &pb.StopRequest{})
+		require.NoError(t, err)
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("agent has not stopped")
+		}
+	})
+}
+
+func TestAgent_IdleTimeout(t *testing.T) {
+	client, _ := startAgent(t, 100*time.Millisecond)
+	ctx := context.Background()
+
+	_, err := client.Unlock(ctx, &pb.UnlockRequest{Password: password})
+	require.NoError(t, err)
+
+	resp, err := client.Status(ctx, &pb.StatusRequest{})
+	require.NoError(t, err)
+	assert.False(t, resp.GetLocked())
+	assert.Equal(t, int64(0), resp.GetIdleTimeoutSeconds())
+
+	assert.Eventually(t, func() bool {
+		resp, err := client.Status(ctx, &pb.StatusRequest{})
+		return err == nil && resp.GetLocked()
+	}, 5*time.Second, 50*time.Millisecond)
+}
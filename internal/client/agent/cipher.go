@@ -0,0 +1,38 @@
+package agent
+
+import (
+	"context"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher"
+)
+
+// Cipher реализация cipher.BlockCipher, выполняющая шифрование мастер-ключом на стороне агента
+type Cipher struct {
+	client pb.AgentServiceClient
+}
+
+var _ cipher.BlockCipher = (*Cipher)(nil)
+
+// NewCipher создает экземпляр Cipher, использующий клиента агента
+func NewCipher(client pb.AgentServiceClient) *Cipher {
+	return &Cipher{client: client}
+}
+
+// Encrypt выполняет шифрование переданной байтовой последовательности
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	resp, err := c.client.Encrypt(context.Background(), &pb.EncryptRequest{Plaintext: plaintext})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetCiphertext(), nil
+}
+
+// Decrypt выполняет расшифрование переданной байтовой последовательности
+func (c *Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	resp, err := c.client.Decrypt(context.Background(), &pb.DecryptRequest{Ciphertext: ciphertext})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPlaintext(), nil
+}
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultSocketPath возвращает путь к сокету агента профиля profile в каталоге, доступном только пользователю
+func DefaultSocketPath(profile string) string {
+	socketName := "agent-" + profile + ".sock"
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "gophkeeper", socketName)
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gophkeeper-%d", os.Getuid()), socketName)
+}
+
+// Listen создает Unix-сокет агента, доступный только текущему пользователю.
+// Оставшийся от предыдущего запуска сокет удаляется, если агент по нему не отвечает
+func Listen(socketPath string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(filepath.Dir(socketPath), 0700); err != nil {
+		return nil, err
+	}
+
+	if conn, err := net.Dial("unix", socketPath); err == nil {
+		_ = conn.Close()
+		return nil, errors.New("agent is already running")
+	}
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(socketPath, 0600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// Serve запускает агент на Unix-сокете и блокируется до отмены контекста или остановки агента
+func Serve(ctx context.Context, listener net.Listener, agent *Agent) error {
+	grpcServer := grpc.NewServer()
+	agent.RegisterService(grpcServer)
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+
+	defer func() {
+		agent.mu.Lock()
+		agent.lock()
+		agent.mu.Unlock()
+	}()
+
+	log.Info().Msgf("Agent listening on %s", listener.Addr())
+	return grpcServer.Serve(listener)
+}
+
+// Dial создает подключение к агенту по Unix-сокету
+func Dial(socketPath string) (*grpc.ClientConn, error) {
+	return grpc.Dial(
+		"unix://"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
@@ -0,0 +1,187 @@
+// Package archive реализует формат резервной копии хранилища, зашифрованной парольной фразой экспорта
+package archive
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/generate"
+)
+
+// Version версия формата архива
+const Version = 1
+
+const (
+	kdfScrypt = "scrypt"
+	saltSize  = 16
+	keySize   = 32
+
+	// Ограничения параметров scrypt, защищающие от архивов с чрезмерно дорогим вычислением ключа
+	maxN = 1 << 20
+	maxR = 32
+	maxP = 16
+)
+
+var (
+	// ErrEmptyPassphrase не задана парольная фраза
+	ErrEmptyPassphrase = errors.New("empty export passphrase")
+	// ErrUnsupportedVersion архив создан несовместимой версией клиента
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	// ErrInvalidMAC архив поврежден или парольная фраза неверна
+	ErrInvalidMAC = errors.New("archive is corrupted or passphrase is wrong")
+)
+
+// DefaultKDF параметры вычисления ключей по парольной фразе
+var DefaultKDF = KDF{Name: kdfScrypt, N: 1 << 15, R: 8, P: 1}
+
+// Item секрет в архиве. Data содержит секрет в формате models.EncodeSecret,
+// Attachments - расшифрованные вложения заметки, которые хранятся на сервере отдельно от нее
+type Item struct {
+	Name        string          `json:"name"`
+	Version     string          `json:"version"`
+	Data        json.RawMessage `json:"data"`
+	Attachments []Attachment    `json:"attachments,omitempty"`
+}
+
+// Attachment вложение заметки в архиве
+type Attachment struct {
+	Name string `json:"name"`
+	Data []byte `json:"data"`
+}
+
+// KDF параметры функции scrypt
+type KDF struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// Archive файл архива. Payload содержит зашифрованный список секретов,
+// MAC - HMAC-SHA256 от всех остальных полей
+type Archive struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	KDF       KDF       `json:"kdf"`
+	Payload   []byte    `json:"payload"`
+	MAC       []byte    `json:"mac,omitempty"`
+}
+
+// keys вычисляет по парольной фразе ключ шифрования и ключ MAC
+func (k KDF) keys(passphrase []byte) (encKey, macKey []byte, err error) {
+	if k.Name != kdfScrypt {
+		return nil, nil, fmt.Errorf("%w: unknown kdf %q", ErrUnsupportedVersion, k.Name)
+	}
+	if k.N > maxN || k.R > maxR || k.P > maxP {
+		return nil, nil, fmt.Errorf("%w: kdf parameters are too large", ErrUnsupportedVersion)
+	}
+	key, err := scrypt.Key(passphrase, k.Salt, k.N, k.R, k.P, 2*keySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key[:keySize], key[keySize:], nil
+}
+
+func (a Archive) mac(macKey []byte) ([]byte, error) {
+	a.MAC = nil
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	h := hmac.New(sha256.New, macKey)
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// Write шифрует секреты парольной фразой и записывает архив в w
+func Write(w io.Writer, passphrase []byte, items []Item) error {
+	if len(passphrase) == 0 {
+		return ErrEmptyPassphrase
+	}
+	if items == nil {
+		items = []Item{}
+	}
+
+	kdf := DefaultKDF
+	salt, err := generate.RandomBytes(saltSize)
+	if err != nil {
+		return err
+	}
+	kdf.Salt = salt
+
+	encKey, macKey, err := kdf.keys(passphrase)
+	if err != nil {
+		return err
+	}
+	payloadCipher, err := gcm.NewWithKey(encKey)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	archive := Archive{
+		Version:   Version,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		KDF:       kdf,
+	}
+	if archive.Payload, err = payloadCipher.Encrypt(plaintext); err != nil {
+		return err
+	}
+	if archive.MAC, err = archive.mac(macKey); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// Read проверяет целостность архива и расшифровывает секреты
+func Read(r io.Reader, passphrase []byte) (*Archive, []Item, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode archive: %w", err)
+	}
+	if archive.Version != Version {
+		return nil, nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, archive.Version)
+	}
+
+	encKey, macKey, err := archive.KDF.keys(passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	mac, err := archive.mac(macKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hmac.Equal(mac, archive.MAC) {
+		return nil, nil, ErrInvalidMAC
+	}
+
+	payloadCipher, err := gcm.NewWithKey(encKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := payloadCipher.Decrypt(archive.Payload)
+	if err != nil {
+		return nil, nil, ErrInvalidMAC
+	}
+
+	var items []Item
+	if err = json.Unmarshal(plaintext, &items); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode archive payload: %w", err)
+	}
+	return &archive, items, nil
+}
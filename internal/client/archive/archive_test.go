@@ -0,0 +1,79 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRead(t *testing.T) {
+	DefaultKDF.N = 1 << 10
+
+	items := []Item{
+		{Name: "github", Version: "v1", Data: json.RawMessage(`{"type":"credentials","data":{"login":"user"}}`)},
+		{Name: "motd", Version: "v2", Data: json.RawMessage(`{"type":"text","data":{"data":"hello"}}`)},
+		{
+			Name:        "readme",
+			Version:     "v3",
+			Data:        json.RawMessage(`{"type":"note","data":{"Title":"readme","Body":"see attachment"}}`),
+			Attachments: []Attachment{{Name: "id_rsa.pub", Data: []byte("ssh-ed25519 AAAA")}},
+		},
+	}
+	passphrase := []byte("correct horse battery staple")
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, passphrase, items))
+	assert.NotContains(t, buf.String(), "github")
+
+	t.Run("Read", func(t *testing.T) {
+		archive, got, err := Read(bytes.NewReader(buf.Bytes()), passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, Version, archive.Version)
+		assert.Equal(t, items, got)
+	})
+
+	t.Run("Wrong passphrase", func(t *testing.T) {
+		_, _, err := Read(bytes.NewReader(buf.Bytes()), []byte("wrong"))
+		assert.ErrorIs(t, err, ErrInvalidMAC)
+	})
+
+	tamper := func(t *testing.T, modify func(a *Archive)) []byte {
+		var a Archive
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &a))
+		modify(&a)
+		data, err := json.Marshal(a)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		data := tamper(t, func(a *Archive) { a.Payload[len(a.Payload)-1] ^= 1 })
+		_, _, err := Read(bytes.NewReader(data), passphrase)
+		assert.ErrorIs(t, err, ErrInvalidMAC)
+	})
+
+	t.Run("Tampered metadata", func(t *testing.T) {
+		data := tamper(t, func(a *Archive) { a.CreatedAt = a.CreatedAt.AddDate(-1, 0, 0) })
+		_, _, err := Read(bytes.NewReader(data), passphrase)
+		assert.ErrorIs(t, err, ErrInvalidMAC)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		data := tamper(t, func(a *Archive) { a.Version = Version + 1 })
+		_, _, err := Read(bytes.NewReader(data), passphrase)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("Expensive kdf", func(t *testing.T) {
+		data := tamper(t, func(a *Archive) { a.KDF.N = 1 << 30 })
+		_, _, err := Read(bytes.NewReader(data), passphrase)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+}
+
+func TestWrite_EmptyPassphrase(t *testing.T) {
+	assert.ErrorIs(t, Write(&bytes.Buffer{}, nil, nil), ErrEmptyPassphrase)
+}
@@ -1,17 +1,61 @@
 package config
 
+import "time"
+
 // Config содержит настройки клиента
 type Config struct {
-	GRPC       GRPCConfig       `mapstructure:"grpc"`
-	Encryption EncryptionConfig `mapstructure:"encryption"`
+	Profile    string             `mapstructure:"profile"`
+	Profiles   map[string]Profile `mapstructure:"profiles"`
+	GRPC       GRPCConfig         `mapstructure:"grpc"`
+	Encryption EncryptionConfig   `mapstructure:"encryption"`
+	Agent      AgentConfig        `mapstructure:"agent"`
+	Token      TokenConfig        `mapstructure:"token"`
+}
+
+// Profile настройки профиля сервера. Заданные в профиле значения
+// переопределяют одноименные настройки верхнего уровня
+type Profile struct {
+	GRPC       GRPCConfig       `mapstructure:"grpc" yaml:"grpc,omitempty"`
+	Encryption EncryptionConfig `mapstructure:"encryption" yaml:"encryption,omitempty"`
+	Agent      AgentConfig      `mapstructure:"agent" yaml:"agent,omitempty"`
+	Token      TokenConfig      `mapstructure:"token" yaml:"token,omitempty"`
 }
 
 // GRPCConfig настройки GRPC
 type GRPCConfig struct {
-	Address string `mapstructure:"address"`
+	Address string    `mapstructure:"address" yaml:"address,omitempty"`
+	TLS     TLSConfig `mapstructure:"tls" yaml:"tls,omitempty"`
+}
+
+// TLSConfig настройки TLS подключения к серверу
+type TLSConfig struct {
+	Enabled    bool   `mapstructure:"enabled" yaml:"enabled,omitempty"`
+	CAFile     string `mapstructure:"ca_file" yaml:"ca_file,omitempty"`
+	ServerName string `mapstructure:"server_name" yaml:"server_name,omitempty"`
 }
 
-// EncryptionConfig настройки шифрования
+// Источники мастер-ключа
+const (
+	KeySourceKey   = "key"
+	KeySourceAgent = "agent"
+)
+
+// EncryptionConfig настройки шифрования.
+// Source определяет источник мастер-ключа: key или agent; по умолчанию
+// используется ключ Key, если он задан, иначе агент
 type EncryptionConfig struct {
-	Key string `mapstructure:"key"`
+	Source string `mapstructure:"source" yaml:"source,omitempty"`
+	Key    string `mapstructure:"key" yaml:"key,omitempty"`
+}
+
+// AgentConfig настройки агента, хранящего разблокированный мастер-ключ
+type AgentConfig struct {
+	Socket      string        `mapstructure:"socket" yaml:"socket,omitempty"`
+	IdleTimeout time.Duration `mapstructure:"idle_timeout" yaml:"idle_timeout,omitempty"`
+}
+
+// TokenConfig настройки хранения токена доступа. По умолчанию токен хранится
+// в каталоге настроек пользователя в файле, названном по имени профиля
+type TokenConfig struct {
+	Path string `mapstructure:"path" yaml:"path,omitempty"`
 }
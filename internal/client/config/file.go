@@ -0,0 +1,173 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile профиль, используемый, если текущий профиль не выбран
+const DefaultProfile = "default"
+
+var (
+	// ErrProfileNotFound профиль не найден в конфигурационном файле
+	ErrProfileNotFound = errors.New("profile not found")
+	// ErrInvalidProfileName недопустимое имя профиля
+	ErrInvalidProfileName = errors.New("profile name must consist of lowercase letters, digits, '-' and '_'")
+)
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// ValidateProfileName проверяет, что имя профиля может использоваться в ключах настроек и именах файлов
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return ErrInvalidProfileName
+	}
+	return nil
+}
+
+// File конфигурационный файл клиента, изменяемый командами управления профилями.
+// Настройки, не относящиеся к профилям, сохраняются без изменений
+type File struct {
+	Path     string
+	settings map[string]interface{}
+}
+
+// DefaultFilePath возвращает путь к конфигурационному файлу в каталоге настроек пользователя
+func DefaultFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gophkeeper", "client-config.yaml"), nil
+}
+
+// OpenFile читает конфигурационный файл; отсутствующий файл считается пустым
+func OpenFile(path string) (*File, error) {
+	file := &File{
+		Path:     path,
+		settings: make(map[string]interface{}),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, &file.settings); err != nil {
+		return nil, err
+	}
+	if file.settings == nil {
+		file.settings = make(map[string]interface{})
+	}
+	return file, nil
+}
+
+// CurrentProfile возвращает имя текущего профиля
+func (f *File) CurrentProfile() string {
+	if name, ok := f.settings["profile"].(string); ok && name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// Profiles возвращает отсортированный список профилей
+func (f *File) Profiles() []string {
+	profiles := f.profiles()
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile возвращает настройки профиля
+func (f *File) Profile(name string) (Profile, error) {
+	settings, ok := f.profiles()[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return Profile{}, err
+	}
+	var profile Profile
+	if err = yaml.Unmarshal(data, &profile); err != nil {
+		return Profile{}, err
+	}
+	return profile, nil
+}
+
+// PutProfile добавляет или заменяет профиль
+func (f *File) PutProfile(name string, profile Profile) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	settings := make(map[string]interface{})
+	if err = yaml.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+
+	profiles := f.profiles()
+	profiles[name] = settings
+	f.settings["profiles"] = profiles
+	return nil
+}
+
+// RemoveProfile удаляет профиль; если профиль был текущим, текущим становится профиль по умолчанию
+func (f *File) RemoveProfile(name string) error {
+	profiles := f.profiles()
+	if _, ok := profiles[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	delete(profiles, name)
+	f.settings["profiles"] = profiles
+
+	if f.CurrentProfile() == name {
+		delete(f.settings, "profile")
+	}
+	return nil
+}
+
+// UseProfile делает профиль текущим
+func (f *File) UseProfile(name string) error {
+	if _, ok := f.profiles()[name]; !ok && name != DefaultProfile {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	f.settings["profile"] = name
+	return nil
+}
+
+// Save записывает конфигурационный файл, доступный только пользователю
+func (f *File) Save() error {
+	data, err := yaml.Marshal(f.settings)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(f.Path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(f.Path, data, 0600)
+}
+
+func (f *File) profiles() map[string]interface{} {
+	profiles, ok := f.settings["profiles"].(map[string]interface{})
+	if !ok {
+		profiles = make(map[string]interface{})
+	}
+	return profiles
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gophkeeper", "client-config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte("grpc:\n  address: 127.0.0.1:9090\n"), 0600))
+
+	file, err := OpenFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, file.CurrentProfile())
+	assert.Empty(t, file.Profiles())
+
+	t.Run("PutProfile", func(t *testing.T) {
+		assert.ErrorIs(t, file.PutProfile("Work.Profile", Profile{}), ErrInvalidProfileName)
+
+		require.NoError(t, file.PutProfile("work", Profile{
+			GRPC: GRPCConfig{
+				Address: "keeper.example.com:443",
+				TLS:     TLSConfig{Enabled: true},
+			},
+			Encryption: EncryptionConfig{Source: KeySourceAgent},
+		}))
+		require.NoError(t, file.PutProfile("personal", Profile{
+			GRPC:  GRPCConfig{Address: "127.0.0.1:9090"},
+			Token: TokenConfig{Path: "/mnt/usb/personal.token"},
+		}))
+		assert.Equal(t, []string{"personal", "work"}, file.Profiles())
+	})
+	t.Run("UseProfile", func(t *testing.T) {
+		assert.ErrorIs(t, file.UseProfile("unknown"), ErrProfileNotFound)
+		require.NoError(t, file.UseProfile("work"))
+		assert.Equal(t, "work", file.CurrentProfile())
+	})
+	t.Run("SaveAndOpen", func(t *testing.T) {
+		require.NoError(t, file.Save())
+
+		saved, err := OpenFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "work", saved.CurrentProfile())
+		assert.Equal(t, []string{"personal", "work"}, saved.Profiles())
+		assert.Equal(t, map[string]interface{}{"address": "127.0.0.1:9090"}, saved.settings["grpc"])
+
+		profile, err := saved.Profile("personal")
+		require.NoError(t, err)
+		assert.Equal(t, "/mnt/usb/personal.token", profile.Token.Path)
+		_, err = saved.Profile("unknown")
+		assert.ErrorIs(t, err, ErrProfileNotFound)
+	})
+	t.Run("RemoveProfile", func(t *testing.T) {
+		assert.ErrorIs(t, file.RemoveProfile("unknown"), ErrProfileNotFound)
+		require.NoError(t, file.RemoveProfile("work"))
+		assert.Equal(t, DefaultProfile, file.CurrentProfile())
+		assert.Equal(t, []string{"personal"}, file.Profiles())
+	})
+}
+
+func TestOpenFile_NotExist(t *testing.T) {
+	file, err := OpenFile(filepath.Join(t.TempDir(), "client-config.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, file.CurrentProfile())
+	assert.Empty(t, file.Profiles())
+}
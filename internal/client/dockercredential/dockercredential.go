@@ -0,0 +1,94 @@
+// Package dockercredential реализует формат обмена данными помощника учетных данных Docker
+// (docker credential helper) и сопоставляет адреса реестров названиям секретов
+package dockercredential
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+const (
+	// HelperName имя исполняемого файла помощника, под которым его запускает Docker
+	HelperName = "docker-credential-gophkeeper"
+	// SecretPrefix префикс названий секретов с учетными данными реестров
+	SecretPrefix = "docker:"
+)
+
+var (
+	// ErrCredentialsNotFound учетные данные реестра не найдены. Docker распознает ошибку по тексту
+	ErrCredentialsNotFound = errors.New("credentials not found in native keychain")
+	// ErrServerURLRequired не указан адрес реестра
+	ErrServerURLRequired = errors.New("no credentials server URL")
+	// ErrUsernameRequired не указано имя пользователя
+	ErrUsernameRequired = errors.New("no credentials username")
+)
+
+// Credentials учетные данные реестра в формате Docker
+type Credentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// ReadServerURL читает адрес реестра, переданный командам get и erase
+func ReadServerURL(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	serverURL := strings.TrimSpace(string(data))
+	if serverURL == "" {
+		return "", ErrServerURLRequired
+	}
+	return serverURL, nil
+}
+
+// ReadCredentials читает учетные данные, переданные команде store
+func ReadCredentials(r io.Reader) (Credentials, error) {
+	var c Credentials
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return Credentials{}, err
+	}
+	if strings.TrimSpace(c.ServerURL) == "" {
+		return Credentials{}, ErrServerURLRequired
+	}
+	if c.Username == "" {
+		return Credentials{}, ErrUsernameRequired
+	}
+	c.ServerURL = strings.TrimSpace(c.ServerURL)
+	return c, nil
+}
+
+// SecretName возвращает название секрета для адреса реестра. Адрес сохраняется без изменений,
+// так как Docker запрашивает учетные данные по адресам, полученным командой list
+func SecretName(serverURL string) string {
+	return SecretPrefix + serverURL
+}
+
+// ServerURL возвращает адрес реестра по названию секрета
+func ServerURL(name string) (string, bool) {
+	if !strings.HasPrefix(name, SecretPrefix) || len(name) == len(SecretPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(name, SecretPrefix), true
+}
+
+// FromSecret возвращает учетные данные реестра serverURL из секрета
+func FromSecret(serverURL string, secret models.Credentials) Credentials {
+	return Credentials{ServerURL: serverURL, Username: secret.Login, Secret: secret.Password}
+}
+
+// Credentials возвращает секрет с учетными данными
+func (c Credentials) Credentials() models.Credentials {
+	return models.Credentials{Login: c.Username, Password: c.Secret}
+}
+
+// WriteError записывает ошибку в формате, который Docker выводит пользователю
+func WriteError(w io.Writer, err error) {
+	fmt.Fprintln(w, err)
+}
@@ -0,0 +1,50 @@
+package dockercredential
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestReadServerURL(t *testing.T) {
+	serverURL, err := ReadServerURL(strings.NewReader("https://index.docker.io/v1/\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://index.docker.io/v1/", serverURL)
+
+	_, err = ReadServerURL(strings.NewReader("\n"))
+	assert.ErrorIs(t, err, ErrServerURLRequired)
+}
+
+func TestReadCredentials(t *testing.T) {
+	c, err := ReadCredentials(strings.NewReader(`{"ServerURL":"ghcr.io","Username":"gopher","Secret":"token"}`))
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{ServerURL: "ghcr.io", Username: "gopher", Secret: "token"}, c)
+	assert.Equal(t, models.Credentials{Login: "gopher", Password: "token"}, c.Credentials())
+
+	_, err = ReadCredentials(strings.NewReader(`{"Username":"gopher","Secret":"token"}`))
+	assert.ErrorIs(t, err, ErrServerURLRequired)
+
+	_, err = ReadCredentials(strings.NewReader(`{"ServerURL":"ghcr.io","Secret":"token"}`))
+	assert.ErrorIs(t, err, ErrUsernameRequired)
+
+	_, err = ReadCredentials(strings.NewReader(`ghcr.io`))
+	assert.Error(t, err)
+}
+
+func TestSecretName(t *testing.T) {
+	name := SecretName("https://index.docker.io/v1/")
+	assert.Equal(t, "docker:https://index.docker.io/v1/", name)
+
+	serverURL, ok := ServerURL(name)
+	assert.True(t, ok)
+	assert.Equal(t, "https://index.docker.io/v1/", serverURL)
+
+	_, ok = ServerURL("git:https://github.com")
+	assert.False(t, ok)
+	_, ok = ServerURL("docker:")
+	assert.False(t, ok)
+}
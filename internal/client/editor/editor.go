@@ -0,0 +1,111 @@
+// Package editor открывает данные секретов во внешнем текстовом редакторе пользователя
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultCommand редактор, используемый, если не заданы переменные окружения VISUAL и EDITOR
+const DefaultCommand = "vi"
+
+// sharedMemoryDir размещенный в памяти каталог, используемый при отсутствии XDG_RUNTIME_DIR
+const sharedMemoryDir = "/dev/shm"
+
+// Command возвращает команду редактора из переменных окружения VISUAL или EDITOR.
+// Команда может содержать аргументы, например "code --wait"
+func Command() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if args := strings.Fields(os.Getenv(name)); len(args) > 0 {
+			return args
+		}
+	}
+	return []string{DefaultCommand}
+}
+
+// TempDir возвращает каталог для временных файлов с открытыми данными. Предпочитаются
+// размещенные в памяти каталоги: XDG_RUNTIME_DIR, доступный только пользователю, и /dev/shm
+func TempDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return dir
+	}
+	if info, err := os.Stat(sharedMemoryDir); err == nil && info.IsDir() {
+		return sharedMemoryDir
+	}
+	return os.TempDir()
+}
+
+// Edit записывает content во временный файл с расширением ext, открывает его в редакторе
+// и возвращает измененное содержимое. Файл создается в отдельном каталоге с правами 0700,
+// после редактирования затирается и удаляется вместе с резервными копиями редактора
+func Edit(content []byte, ext string) (result []byte, err error) {
+	dir, err := os.MkdirTemp(TempDir(), "gophkeeper-edit-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cleanupErr := cleanup(dir); cleanupErr != nil && err == nil {
+			err = cleanupErr
+		}
+	}()
+
+	path := filepath.Join(dir, "secret"+ext)
+	if err = os.WriteFile(path, content, 0600); err != nil {
+		return nil, err
+	}
+
+	args := Command()
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %s: %w", args[0], err)
+	}
+	return os.ReadFile(path)
+}
+
+// cleanup затирает нулями и удаляет файлы каталога dir, затем сам каталог
+func cleanup(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			if err = wipe(filepath.Join(dir, entry.Name())); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+	if err = os.RemoveAll(dir); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return errors.New("failed to remove temporary files: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func wipe(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err == nil {
+		_, err = file.Write(make([]byte, info.Size()))
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
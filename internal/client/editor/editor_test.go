@@ -0,0 +1,69 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEditor создает сценарий, который дописывает строку в файл и сохраняет путь к нему в pathFile
+func fakeEditor(t *testing.T, pathFile string) string {
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	content := "#!/bin/sh\n" +
+		"echo \"$1\" > " + pathFile + "\n" +
+		"ls -ld \"$(dirname \"$1\")\" | cut -c1-10 >> " + pathFile + "\n" +
+		"echo edited >> \"$1\"\n" +
+		"echo backup > \"$1~\"\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0700))
+	return script
+}
+
+func TestCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, Command())
+
+	t.Setenv("VISUAL", "nano")
+	assert.Equal(t, []string{"nano"}, Command())
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, []string{DefaultCommand}, Command())
+}
+
+func TestEdit(t *testing.T) {
+	runtimeDir := t.TempDir()
+	pathFile := filepath.Join(t.TempDir(), "path")
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", fakeEditor(t, pathFile))
+
+	result, err := Edit([]byte("# Title\n"), ".md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Title\nedited\n", string(result))
+
+	info, err := os.ReadFile(pathFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(info), runtimeDir)
+	assert.Contains(t, string(info), ".md\ndrwx------")
+
+	entries, err := os.ReadDir(runtimeDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "temporary files must be removed")
+}
+
+func TestEdit_EditorFailed(t *testing.T) {
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	t.Setenv("VISUAL", "false")
+
+	_, err := Edit([]byte("data"), ".txt")
+	assert.Error(t, err)
+
+	entries, err := os.ReadDir(runtimeDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
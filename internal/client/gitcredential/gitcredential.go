@@ -0,0 +1,111 @@
+// Package gitcredential реализует формат обмена данными помощника учетных данных Git
+// (git credential helper) и сопоставляет учетные данные Git названиям секретов
+package gitcredential
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// SecretPrefix префикс названий секретов с учетными данными Git
+const SecretPrefix = "git:"
+
+var (
+	// ErrInvalidInput ввод не соответствует формату обмена данными Git
+	ErrInvalidInput = errors.New("invalid git credential input")
+	// ErrHostRequired в запросе не указан хост
+	ErrHostRequired = errors.New("git credential host required")
+)
+
+// Credential учетные данные, которыми обмениваются Git и помощник
+type Credential struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+}
+
+// Read читает атрибуты вида key=value до пустой строки или конца ввода.
+// Неизвестные атрибуты пропускаются, как того требует протокол
+func Read(r io.Reader) (Credential, error) {
+	var c Credential
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Credential{}, fmt.Errorf("%w: %q", ErrInvalidInput, line)
+		}
+		switch key {
+		case "protocol":
+			c.Protocol = value
+		case "host":
+			c.Host = value
+		case "path":
+			c.Path = value
+		case "username":
+			c.Username = value
+		case "password":
+			c.Password = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Credential{}, err
+	}
+	return c, nil
+}
+
+// Write записывает имя пользователя и пароль в формате ответа на запрос get
+func Write(w io.Writer, c Credential) error {
+	for _, value := range []string{c.Username, c.Password} {
+		if strings.ContainsAny(value, "\n\x00") {
+			return fmt.Errorf("%w: value contains newline or NUL", ErrInvalidInput)
+		}
+	}
+	_, err := fmt.Fprintf(w, "username=%s\npassword=%s\n", c.Username, c.Password)
+	return err
+}
+
+// SecretName возвращает название секрета для учетных данных: git:протокол://хост[/путь].
+// Путь передается Git только при включенном credential.useHttpPath
+func (c Credential) SecretName() (string, error) {
+	if c.Host == "" {
+		return "", ErrHostRequired
+	}
+	protocol := c.Protocol
+	if protocol == "" {
+		protocol = "https"
+	}
+	name := SecretPrefix + protocol + "://" + c.Host
+	if path := strings.Trim(c.Path, "/"); path != "" {
+		name += "/" + path
+	}
+	return name, nil
+}
+
+// Matches сообщает, подходят ли сохраненные учетные данные к запросу.
+// Пустые имя пользователя и пароль запроса подходят к любым значениям
+func (c Credential) Matches(stored models.Credentials) bool {
+	return (c.Username == "" || c.Username == stored.Login) &&
+		(c.Password == "" || c.Password == stored.Password)
+}
+
+// Secret возвращает секрет с учетными данными
+func (c Credential) Secret() models.Credentials {
+	return models.Credentials{Login: c.Username, Password: c.Password}
+}
+
+// WithSecret возвращает учетные данные запроса, дополненные сохраненными
+func (c Credential) WithSecret(stored models.Credentials) Credential {
+	c.Username, c.Password = stored.Login, stored.Password
+	return c
+}
@@ -0,0 +1,68 @@
+package gitcredential
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestRead(t *testing.T) {
+	c, err := Read(strings.NewReader("protocol=https\nhost=github.com\npath=owner/repo.git\n" +
+		"username=gopher\npassword=a=b\nwwwauth[]=Basic\n\nignored=1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Credential{
+		Protocol: "https",
+		Host:     "github.com",
+		Path:     "owner/repo.git",
+		Username: "gopher",
+		Password: "a=b",
+	}, c)
+
+	_, err = Read(strings.NewReader("host\n"))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, Credential{Host: "github.com", Username: "gopher", Password: "token"}))
+	assert.Equal(t, "username=gopher\npassword=token\n", buf.String())
+
+	assert.ErrorIs(t, Write(&buf, Credential{Password: "a\nhost=evil"}), ErrInvalidInput)
+}
+
+func TestCredential_SecretName(t *testing.T) {
+	tests := []struct {
+		name       string
+		credential Credential
+		want       string
+		err        error
+	}{
+		{name: "Host", credential: Credential{Protocol: "https", Host: "github.com"}, want: "git:https://github.com"},
+		{name: "Port", credential: Credential{Protocol: "http", Host: "git.local:8080"}, want: "git:http://git.local:8080"},
+		{name: "Path", credential: Credential{Protocol: "https", Host: "github.com", Path: "/owner/repo.git"}, want: "git:https://github.com/owner/repo.git"},
+		{name: "Default protocol", credential: Credential{Host: "github.com"}, want: "git:https://github.com"},
+		{name: "No host", credential: Credential{Protocol: "https"}, err: ErrHostRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := tt.credential.SecretName()
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, name)
+		})
+	}
+}
+
+func TestCredential_Matches(t *testing.T) {
+	stored := models.Credentials{Login: "gopher", Password: "token"}
+
+	assert.True(t, Credential{Host: "github.com"}.Matches(stored))
+	assert.True(t, Credential{Username: "gopher"}.Matches(stored))
+	assert.True(t, Credential{Username: "gopher", Password: "token"}.Matches(stored))
+	assert.False(t, Credential{Username: "other"}.Matches(stored))
+	assert.False(t, Credential{Username: "gopher", Password: "old"}.Matches(stored))
+}
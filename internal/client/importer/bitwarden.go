@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// Типы записей Bitwarden
+const (
+	bitwardenLogin      = 1
+	bitwardenSecureNote = 2
+	bitwardenCard       = 3
+	bitwardenIdentity   = 4
+)
+
+type bitwardenExport struct {
+	Encrypted bool `json:"encrypted"`
+	Folders   []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folders"`
+	Items []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	FolderID string `json:"folderId"`
+	Type     int    `json:"type"`
+	Name     string `json:"name"`
+	Notes    string `json:"notes"`
+	Login    *struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		TOTP     string `json:"totp"`
+	} `json:"login"`
+	Card *struct {
+		CardholderName string `json:"cardholderName"`
+		Number         string `json:"number"`
+		ExpMonth       string `json:"expMonth"`
+		ExpYear        string `json:"expYear"`
+		Code           string `json:"code"`
+	} `json:"card"`
+}
+
+// ParseBitwarden разбирает незашифрованный JSON-экспорт Bitwarden
+func ParseBitwarden(r io.Reader) (*Result, error) {
+	var export bitwardenExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to decode bitwarden export: %w", err)
+	}
+	if export.Encrypted {
+		return nil, ErrEncryptedExport
+	}
+
+	folders := make(map[string]string, len(export.Folders))
+	for _, folder := range export.Folders {
+		folders[folder.ID] = folder.Name
+	}
+
+	result := &Result{}
+	for _, item := range export.Items {
+		folder := folders[item.FolderID]
+		switch {
+		case item.Type == bitwardenLogin && item.Login != nil:
+			result.add(folder, item.Name, models.Credentials{
+				Login:    item.Login.Username,
+				Password: item.Login.Password,
+			})
+			if item.Login.TOTP != "" {
+				result.warn("%s: TOTP secret is not imported", entryName(folder, item.Name))
+			}
+			if item.Notes != "" {
+				result.warn("%s: notes are not imported", entryName(folder, item.Name))
+			}
+		case item.Type == bitwardenCard && item.Card != nil:
+			card := models.Card{
+				Number:       item.Card.Number,
+				ExpiryDate:   expiryDate(item.Card.ExpMonth, item.Card.ExpYear),
+				SecurityCode: item.Card.Code,
+				Holder:       item.Card.CardholderName,
+			}
+			result.add(folder, item.Name, card)
+			if err := card.Validate(); err != nil {
+				result.warn("%s: %v", entryName(folder, item.Name), err)
+			}
+			if item.Notes != "" {
+				result.warn("%s: notes are not imported", entryName(folder, item.Name))
+			}
+		case item.Type == bitwardenSecureNote:
+			result.add(folder, item.Name, models.Text{Data: item.Notes})
+		case item.Type == bitwardenIdentity:
+			result.warn("%s: identity items are not supported", entryName(folder, item.Name))
+		default:
+			result.warn("%s: unknown item type %d", entryName(folder, item.Name), item.Type)
+		}
+	}
+	return result, nil
+}
+
+// expiryDate приводит срок действия карты к виду MM/YY
+func expiryDate(month, year string) string {
+	if month == "" && year == "" {
+		return ""
+	}
+	if len(month) == 1 {
+		month = "0" + month
+	}
+	if len(year) == 4 {
+		year = year[2:]
+	}
+	return strings.Join([]string{month, year}, "/")
+}
@@ -0,0 +1,45 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestParseBitwarden(t *testing.T) {
+	export := `{
+  "encrypted": false,
+  "folders": [{"id": "f1", "name": "Work"}],
+  "items": [
+    {"folderId": "f1", "type": 1, "name": "GitHub", "notes": "2fa enabled",
+     "login": {"username": "user", "password": "pass", "totp": null}},
+    {"folderId": null, "type": 3, "name": "Visa",
+     "card": {"cardholderName": "IVAN", "number": "4111", "expMonth": "3", "expYear": "2027", "code": "123"}},
+    {"folderId": null, "type": 2, "name": "Wi-Fi", "notes": "password", "secureNote": {"type": 0}},
+    {"folderId": null, "type": 4, "name": "Passport", "identity": {}}
+  ]
+}`
+
+	result, err := ParseBitwarden(strings.NewReader(export))
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Name: "Work/GitHub", Secret: models.Credentials{Login: "user", Password: "pass"}},
+		{Name: "Visa", Secret: models.Card{Number: "4111", ExpiryDate: "03/27", SecurityCode: "123", Holder: "IVAN"}},
+		{Name: "Wi-Fi", Secret: models.Text{Data: "password"}},
+	}, result.Entries)
+	assert.Equal(t, []string{
+		"Work/GitHub: notes are not imported",
+		"Visa: invalid card: number must contain 12 to 19 digits",
+		"Passport: identity items are not supported",
+	}, result.Warnings)
+
+	_, err = ParseBitwarden(strings.NewReader(`{"encrypted": true}`))
+	assert.ErrorIs(t, err, ErrEncryptedExport)
+
+	_, err = ParseBitwarden(strings.NewReader(`[`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// Варианты заголовков колонок CSV-экспорта Chrome, 1Password и Bitwarden
+var csvColumns = map[string][]string{
+	"name":     {"name", "title"},
+	"folder":   {"folder", "group"},
+	"login":    {"username", "login", "login_username", "user name"},
+	"password": {"password", "login_password"},
+	"notes":    {"note", "notes"},
+}
+
+// ParseCSV разбирает CSV-экспорт паролей Chrome, 1Password или Bitwarden.
+// Колонки определяются по заголовку, записи без логина и пароля импортируются как заметки
+func ParseCSV(r io.Reader) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, title := range header {
+		title = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(title, "\ufeff")))
+		for column, titles := range csvColumns {
+			for _, t := range titles {
+				if _, ok := columns[column]; !ok && t == title {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("csv header has no name or title column")
+	}
+
+	result := &Result{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv line %d: %w", line, err)
+		}
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+
+		folder, name := value("folder"), value("name")
+		login, password, notes := value("login"), value("password"), value("notes")
+		switch {
+		case login != "" || password != "":
+			result.add(folder, name, models.Credentials{Login: login, Password: password})
+			if notes != "" {
+				result.warn("%s: notes are not imported", entryName(folder, name))
+			}
+		case notes != "":
+			result.add(folder, name, models.Text{Data: notes})
+		default:
+			result.warn("line %d: empty entry is skipped", line)
+		}
+	}
+	return result, nil
+}
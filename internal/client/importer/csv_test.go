@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+		want   []Entry
+	}{
+		{
+			name: "Chrome",
+			export: "name,url,username,password,note\n" +
+				"github.com,https://github.com/,user,pass,\n" +
+				"wifi,,,,\"home\nnetwork\"\n",
+			want: []Entry{
+				{Name: "github.com", Secret: models.Credentials{Login: "user", Password: "pass"}},
+				{Name: "wifi", Secret: models.Text{Data: "home\nnetwork"}},
+			},
+		},
+		{
+			name: "1Password",
+			export: "\ufeffTitle,Url,Username,Password,OTPAuth,Favorite,Archived,Tags,Notes\n" +
+				"Mail,https://mail.ru,user@mail.ru,secret,,false,false,,\n",
+			want: []Entry{
+				{Name: "Mail", Secret: models.Credentials{Login: "user@mail.ru", Password: "secret"}},
+			},
+		},
+		{
+			name: "Bitwarden",
+			export: "folder,favorite,type,name,notes,fields,reprompt,login_uri,login_username,login_password,login_totp\n" +
+				"Work,,login,GitLab,,,0,https://gitlab.com,dev,qwerty,\n",
+			want: []Entry{
+				{Name: "Work/GitLab", Secret: models.Credentials{Login: "dev", Password: "qwerty"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseCSV(strings.NewReader(tt.export))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.Entries)
+		})
+	}
+
+	_, err := ParseCSV(strings.NewReader("url,username,password\n"))
+	assert.Error(t, err)
+}
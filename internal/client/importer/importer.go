@@ -0,0 +1,152 @@
+// Package importer разбирает файлы экспорта других менеджеров паролей
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// Format формат файла экспорта
+type Format string
+
+const (
+	FormatBitwarden Format = "bitwarden"
+	FormatKeePass   Format = "keepass"
+	FormatCSV       Format = "csv"
+)
+
+// Policy правило обработки записей, название которых совпадает с существующим секретом
+type Policy string
+
+const (
+	PolicySkip      Policy = "skip"
+	PolicyOverwrite Policy = "overwrite"
+	PolicyRename    Policy = "rename"
+)
+
+var (
+	// ErrUnknownFormat неизвестный формат файла экспорта
+	ErrUnknownFormat = errors.New("unknown import format")
+	// ErrUnknownPolicy неизвестное правило обработки дубликатов
+	ErrUnknownPolicy = errors.New("unknown duplicate policy")
+	// ErrEncryptedExport файл экспорта зашифрован
+	ErrEncryptedExport = errors.New("encrypted export is not supported")
+)
+
+// Entry запись, подлежащая импорту
+type Entry struct {
+	Name   string
+	Secret models.Secret
+}
+
+// Result результат разбора файла экспорта. Warnings содержит описание пропущенных данных
+type Result struct {
+	Entries  []Entry
+	Warnings []string
+}
+
+func (r *Result) add(folder, name string, secret models.Secret) {
+	r.Entries = append(r.Entries, Entry{Name: entryName(folder, name), Secret: secret})
+}
+
+func (r *Result) warn(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Parse разбирает файл экспорта заданного формата
+func Parse(format Format, r io.Reader) (*Result, error) {
+	switch format {
+	case FormatBitwarden:
+		return ParseBitwarden(r)
+	case FormatKeePass:
+		return ParseKeePass(r)
+	case FormatCSV:
+		return ParseCSV(r)
+	}
+	return nil, fmt.Errorf("%w %q, expected bitwarden|keepass|csv", ErrUnknownFormat, format)
+}
+
+// entryName добавляет к названию записи путь папки в виде префикса: Work/GitHub
+func entryName(folder, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "untitled"
+	}
+	folder = strings.Trim(strings.TrimSpace(folder), "/")
+	if folder == "" {
+		return name
+	}
+	return folder + "/" + name
+}
+
+// ActionKind действие, выполняемое с записью при импорте
+type ActionKind string
+
+const (
+	ActionCreate ActionKind = "create"
+	ActionUpdate ActionKind = "overwrite"
+	ActionSkip   ActionKind = "skip"
+)
+
+// Action запланированное действие с записью. Name - итоговое название секрета
+type Action struct {
+	Kind  ActionKind
+	Name  string
+	Entry Entry
+}
+
+// ParsePolicy проверяет название правила обработки дубликатов
+func ParsePolicy(name string) (Policy, error) {
+	switch policy := Policy(name); policy {
+	case PolicySkip, PolicyOverwrite, PolicyRename:
+		return policy, nil
+	}
+	return "", fmt.Errorf("%w %q, expected skip|overwrite|rename", ErrUnknownPolicy, name)
+}
+
+// Plan сопоставляет записи с существующими секретами existing и определяет действие для каждой записи.
+// Записи с одинаковыми названиями внутри файла также считаются дубликатами
+func Plan(entries []Entry, existing []string, policy Policy) []Action {
+	existingNames := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		existingNames[name] = true
+	}
+	planned := make(map[string]bool, len(entries))
+
+	actions := make([]Action, 0, len(entries))
+	for _, entry := range entries {
+		action := Action{Kind: ActionCreate, Name: entry.Name, Entry: entry}
+		if existingNames[entry.Name] || planned[entry.Name] {
+			switch {
+			case policy == PolicyRename:
+				action.Name = freeName(entry.Name, existingNames, planned)
+			case policy == PolicyOverwrite && !planned[entry.Name]:
+				action.Kind = ActionUpdate
+			default:
+				action.Kind = ActionSkip
+			}
+		}
+		if action.Kind != ActionSkip {
+			planned[action.Name] = true
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+func freeName(name string, taken ...map[string]bool) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		free := true
+		for _, names := range taken {
+			free = free && !names[candidate]
+		}
+		if free {
+			return candidate
+		}
+	}
+}
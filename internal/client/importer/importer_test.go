@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestParse(t *testing.T) {
+	_, err := Parse("lastpass", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("rename")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyRename, policy)
+
+	_, err = ParsePolicy("merge")
+	assert.ErrorIs(t, err, ErrUnknownPolicy)
+}
+
+func TestPlan(t *testing.T) {
+	entries := []Entry{
+		{Name: "github", Secret: models.Credentials{Login: "a"}},
+		{Name: "gitlab", Secret: models.Credentials{Login: "b"}},
+		{Name: "gitlab", Secret: models.Credentials{Login: "c"}},
+	}
+	existing := []string{"github", "github (2)"}
+
+	kinds := func(actions []Action) []string {
+		var result []string
+		for _, action := range actions {
+			result = append(result, string(action.Kind)+" "+action.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		policy Policy
+		want   []string
+	}{
+		{policy: PolicySkip, want: []string{"skip github", "create gitlab", "skip gitlab"}},
+		{policy: PolicyOverwrite, want: []string{"overwrite github", "create gitlab", "skip gitlab"}},
+		{policy: PolicyRename, want: []string{"create github (3)", "create gitlab", "create gitlab (2)"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			assert.Equal(t, tt.want, kinds(Plan(entries, existing, tt.policy)))
+		})
+	}
+}
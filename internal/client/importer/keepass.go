@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+type keePassFile struct {
+	Meta struct {
+		RecycleBinEnabled bool   `xml:"RecycleBinEnabled"`
+		RecycleBinUUID    string `xml:"RecycleBinUUID"`
+		Binaries          []struct {
+			ID         string `xml:"ID,attr"`
+			Compressed bool   `xml:"Compressed,attr"`
+			Data       string `xml:",chardata"`
+		} `xml:"Binaries>Binary"`
+	} `xml:"Meta"`
+	Root struct {
+		Groups []keePassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keePassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keePassEntry `xml:"Entry"`
+	Groups  []keePassGroup `xml:"Group"`
+}
+
+type keePassEntry struct {
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"String"`
+	Binaries []struct {
+		Key   string `xml:"Key"`
+		Value struct {
+			Ref string `xml:"Ref,attr"`
+		} `xml:"Value"`
+	} `xml:"Binary"`
+}
+
+func (e keePassEntry) field(key string) string {
+	for _, s := range e.Strings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+// ParseKeePass разбирает XML-экспорт KeePass 2.x. Корневая группа в название секретов не включается,
+// записи корзины и истории изменений пропускаются
+func ParseKeePass(r io.Reader) (*Result, error) {
+	var file keePassFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode keepass export: %w", err)
+	}
+
+	binaries := make(map[string][]byte, len(file.Meta.Binaries))
+	for _, binary := range file.Meta.Binaries {
+		data, err := decodeKeePassBinary(binary.Data, binary.Compressed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode keepass binary %s: %w", binary.ID, err)
+		}
+		binaries[binary.ID] = data
+	}
+
+	recycleBin := ""
+	if file.Meta.RecycleBinEnabled {
+		recycleBin = file.Meta.RecycleBinUUID
+	}
+
+	result := &Result{}
+	var walk func(folder string, group keePassGroup)
+	walk = func(folder string, group keePassGroup) {
+		if recycleBin != "" && group.UUID == recycleBin {
+			return
+		}
+		for _, entry := range group.Entries {
+			addKeePassEntry(result, folder, entry, binaries)
+		}
+		for _, child := range group.Groups {
+			walk(path.Join(folder, child.Name), child)
+		}
+	}
+	for _, root := range file.Root.Groups {
+		walk("", root)
+	}
+	return result, nil
+}
+
+func addKeePassEntry(result *Result, folder string, entry keePassEntry, binaries map[string][]byte) {
+	title := entry.field("Title")
+	name := entryName(folder, title)
+	login, password, notes := entry.field("UserName"), entry.field("Password"), entry.field("Notes")
+
+	switch {
+	case login != "" || password != "":
+		result.add(folder, title, models.Credentials{Login: login, Password: password})
+		if notes != "" {
+			result.warn("%s: notes are not imported", name)
+		}
+	case notes != "":
+		result.add(folder, title, models.Text{Data: notes})
+	case len(entry.Binaries) == 0:
+		result.warn("%s: empty entry is skipped", name)
+	}
+
+	for _, binary := range entry.Binaries {
+		data, ok := binaries[binary.Value.Ref]
+		if !ok {
+			result.warn("%s: attachment %s not found", name, binary.Key)
+			continue
+		}
+		result.add(name, binary.Key, models.Bin{Data: data})
+	}
+
+	for _, s := range entry.Strings {
+		switch s.Key {
+		case "Title", "UserName", "Password", "Notes":
+		case "URL":
+			if strings.TrimSpace(s.Value) != "" {
+				result.warn("%s: URL is not imported", name)
+			}
+		default:
+			if strings.TrimSpace(s.Value) != "" {
+				result.warn("%s: custom field %s is not imported", name, s.Key)
+			}
+		}
+	}
+}
+
+func decodeKeePassBinary(data string, compressed bool) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+	if err != nil || !compressed {
+		return decoded, err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(decoded))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
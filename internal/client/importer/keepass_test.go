@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestParseKeePass(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write([]byte("ssh key"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	export := `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+  <Meta>
+    <RecycleBinEnabled>True</RecycleBinEnabled>
+    <RecycleBinUUID>bin</RecycleBinUUID>
+    <Binaries>
+      <Binary ID="0" Compressed="True">` + base64.StdEncoding.EncodeToString(compressed.Bytes()) + `</Binary>
+    </Binaries>
+  </Meta>
+  <Root>
+    <Group>
+      <UUID>root</UUID>
+      <Name>Database</Name>
+      <Entry>
+        <String><Key>Title</Key><Value>Mail</Value></String>
+        <String><Key>UserName</Key><Value>user@mail.ru</Value></String>
+        <String><Key>Password</Key><Value ProtectInMemory="True">secret</Value></String>
+        <String><Key>URL</Key><Value>https://mail.ru</Value></String>
+        <History>
+          <Entry>
+            <String><Key>Title</Key><Value>Mail</Value></String>
+            <String><Key>Password</Key><Value>old</Value></String>
+          </Entry>
+        </History>
+      </Entry>
+      <Group>
+        <UUID>servers</UUID>
+        <Name>Servers</Name>
+        <Entry>
+          <String><Key>Title</Key><Value>prod</Value></String>
+          <String><Key>Notes</Key><Value>ssh root@prod</Value></String>
+          <String><Key>URL</Key><Value></Value></String>
+          <Binary><Key>id_rsa</Key><Value Ref="0"/></Binary>
+        </Entry>
+      </Group>
+      <Group>
+        <UUID>bin</UUID>
+        <Name>Recycle Bin</Name>
+        <Entry>
+          <String><Key>Title</Key><Value>deleted</Value></String>
+          <String><Key>Password</Key><Value>deleted</Value></String>
+        </Entry>
+      </Group>
+    </Group>
+  </Root>
+</KeePassFile>`
+
+	result, err := ParseKeePass(strings.NewReader(export))
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Name: "Mail", Secret: models.Credentials{Login: "user@mail.ru", Password: "secret"}},
+		{Name: "Servers/prod", Secret: models.Text{Data: "ssh root@prod"}},
+		{Name: "Servers/prod/id_rsa", Secret: models.Bin{Data: []byte("ssh key")}},
+	}, result.Entries)
+	assert.Equal(t, []string{"Mail: URL is not imported"}, result.Warnings)
+}
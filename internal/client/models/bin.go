@@ -16,3 +16,10 @@ func (b Bin) Type() SecretType {
 func (b Bin) String() string {
 	return "BINARY DATA"
 }
+
+// Fields возвращает поля секрета в фиксированном порядке
+func (b Bin) Fields() []Field {
+	return []Field{
+		{Name: "data", Value: string(b.Data), Binary: true},
+	}
+}
@@ -14,3 +14,8 @@ func TestBin_String(t *testing.T) {
 	secret := Bin{Data: []byte("data")}
 	assert.Equal(t, "BINARY DATA", secret.String())
 }
+
+func TestBin_Fields(t *testing.T) {
+	secret := Bin{Data: []byte{0, 1}}
+	assert.Equal(t, []Field{{Name: "data", Value: "\x00\x01", Binary: true}}, secret.Fields())
+}
@@ -1,11 +1,64 @@
 package models
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var _ Secret = (*Card)(nil)
 
+// Платежные системы, определяемые по номеру карты
+const (
+	CardNetworkVisa       = "Visa"
+	CardNetworkMastercard = "Mastercard"
+	CardNetworkAmex       = "American Express"
+	CardNetworkMir        = "Mir"
+	CardNetworkMaestro    = "Maestro"
+	CardNetworkDiscover   = "Discover"
+	CardNetworkJCB        = "JCB"
+	CardNetworkUnionPay   = "UnionPay"
+	CardNetworkDiners     = "Diners Club"
+	CardNetworkUnknown    = "Unknown"
+)
+
+const (
+	minCardNumberLength = 12
+	maxCardNumberLength = 19
+	cardMaskedDigits    = 4
+	cardMask            = "****"
+)
+
+// ErrInvalidCard недопустимые данные банковской карты
+var ErrInvalidCard = errors.New("invalid card")
+
+// cardNetworkRanges диапазоны идентификаторов эмитента (IIN). Более специфичные диапазоны
+// указаны раньше пересекающихся с ними общих
+var cardNetworkRanges = []struct {
+	network  string
+	from, to int
+}{
+	{CardNetworkMir, 2200, 2204},
+	{CardNetworkMastercard, 2221, 2720},
+	{CardNetworkDiners, 300, 305},
+	{CardNetworkAmex, 34, 34},
+	{CardNetworkAmex, 37, 37},
+	{CardNetworkJCB, 3528, 3589},
+	{CardNetworkDiners, 36, 36},
+	{CardNetworkDiners, 38, 39},
+	{CardNetworkVisa, 4, 4},
+	{CardNetworkMastercard, 51, 55},
+	{CardNetworkMaestro, 50, 50},
+	{CardNetworkMaestro, 56, 58},
+	{CardNetworkDiscover, 6011, 6011},
+	{CardNetworkDiscover, 644, 649},
+	{CardNetworkDiscover, 65, 65},
+	{CardNetworkUnionPay, 62, 62},
+	{CardNetworkMaestro, 6, 6},
+}
+
 // Card данные банковской карты
 type Card struct {
 	Number       string
@@ -14,13 +67,157 @@ type Card struct {
 	Holder       string
 }
 
+// ParseCard нормализует и проверяет данные карты: из номера удаляются пробелы и дефисы,
+// срок действия приводится к виду MM/YY
+func ParseCard(number, expiryDate, securityCode, holder string) (Card, error) {
+	c := Card{
+		Number:       strings.Map(dropCardSeparators, number),
+		ExpiryDate:   expiryDate,
+		SecurityCode: strings.TrimSpace(securityCode),
+		Holder:       strings.TrimSpace(holder),
+	}
+	expiry, err := ParseCardExpiry(expiryDate)
+	if err != nil {
+		return Card{}, err
+	}
+	c.ExpiryDate = expiry.Format("01/06")
+	return c, c.Validate()
+}
+
+func dropCardSeparators(r rune) rune {
+	if r == ' ' || r == '-' {
+		return -1
+	}
+	return r
+}
+
+// ParseCardExpiry разбирает срок действия карты в формате MM/YY или MM/YYYY
+// и возвращает первый день указанного месяца
+func ParseCardExpiry(s string) (time.Time, error) {
+	month, year, ok := strings.Cut(strings.ReplaceAll(s, " ", ""), "/")
+	if !ok || len(month) == 0 || len(month) > 2 || (len(year) != 2 && len(year) != 4) {
+		return time.Time{}, fmt.Errorf("%w: expiry date %q is not in MM/YY format", ErrInvalidCard, s)
+	}
+	m, err := strconv.Atoi(month)
+	if err != nil || m < 1 || m > 12 {
+		return time.Time{}, fmt.Errorf("%w: invalid expiry month %q", ErrInvalidCard, month)
+	}
+	y, err := strconv.Atoi(year)
+	if err != nil || y < 0 {
+		return time.Time{}, fmt.Errorf("%w: invalid expiry year %q", ErrInvalidCard, year)
+	}
+	if len(year) == 2 {
+		y += 2000
+	}
+	return time.Date(y, time.Month(m), 1, 0, 0, 0, 0, time.Local), nil
+}
+
 // Type возвращает тип хранимой информации
 func (c Card) Type() SecretType {
 	return secretTypeCard
 }
 
-// String функция отображения приватной информации
+// String функция отображения приватной информации. Номер карты маскируется, код безопасности скрывается
 func (c Card) String() string {
 	return fmt.Sprintf("Number: %s, ExpiryDate: %s, SecurityCode: %s, Holder: %s",
-		c.Number, c.ExpiryDate, c.SecurityCode, c.Holder)
+		c.MaskedNumber(), c.ExpiryDate, maskedValue(c.SecurityCode), c.Holder)
+}
+
+// Fields возвращает поля секрета в фиксированном порядке
+func (c Card) Fields() []Field {
+	return []Field{
+		{Name: "number", Value: c.Number, Hidden: true, Masked: c.MaskedNumber()},
+		{Name: "expiry_date", Value: c.ExpiryDate},
+		{Name: "security_code", Value: c.SecurityCode, Hidden: true},
+		{Name: "holder", Value: c.Holder},
+	}
+}
+
+// MaskedNumber возвращает номер карты, в котором видны только последние четыре цифры
+func (c Card) MaskedNumber() string {
+	if len(c.Number) <= cardMaskedDigits {
+		return maskedValue(c.Number)
+	}
+	return cardMask + " " + c.Number[len(c.Number)-cardMaskedDigits:]
+}
+
+// Network определяет платежную систему по идентификатору эмитента
+func (c Card) Network() string {
+	for _, r := range cardNetworkRanges {
+		length := len(strconv.Itoa(r.from))
+		if len(c.Number) < length {
+			continue
+		}
+		prefix, err := strconv.Atoi(c.Number[:length])
+		if err == nil && prefix >= r.from && prefix <= r.to {
+			return r.network
+		}
+	}
+	return CardNetworkUnknown
+}
+
+// Expiry возвращает момент окончания срока действия карты: начало месяца, следующего за указанным на карте
+func (c Card) Expiry() (time.Time, error) {
+	month, err := ParseCardExpiry(c.ExpiryDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return month.AddDate(0, 1, 0), nil
+}
+
+// Validate проверяет номер карты алгоритмом Луна, срок действия и длину кода безопасности
+func (c Card) Validate() error {
+	if len(c.Number) < minCardNumberLength || len(c.Number) > maxCardNumberLength || !isDigits(c.Number) {
+		return fmt.Errorf("%w: number must contain %d to %d digits", ErrInvalidCard, minCardNumberLength, maxCardNumberLength)
+	}
+	if !luhnValid(c.Number) {
+		return fmt.Errorf("%w: number checksum mismatch", ErrInvalidCard)
+	}
+	if _, err := ParseCardExpiry(c.ExpiryDate); err != nil {
+		return err
+	}
+
+	codeLength := 3
+	if c.Network() == CardNetworkAmex {
+		codeLength = 4
+	}
+	if c.SecurityCode != "" && (len(c.SecurityCode) != codeLength || !isDigits(c.SecurityCode)) {
+		return fmt.Errorf("%w: security code must contain %d digits", ErrInvalidCard, codeLength)
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// luhnValid проверяет контрольную цифру номера алгоритмом Луна
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// maskedValue возвращает маску непустого значения
+func maskedValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return cardMask
 }
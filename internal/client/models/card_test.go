@@ -2,8 +2,10 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCard_Type(t *testing.T) {
@@ -12,12 +14,90 @@ func TestCard_Type(t *testing.T) {
 
 func TestCard_String(t *testing.T) {
 	secret := Card{
-		Number:       "Number",
-		ExpiryDate:   "ExpiryDate",
-		SecurityCode: "SecurityCode",
+		Number:       "4111111111111111",
+		ExpiryDate:   "12/30",
+		SecurityCode: "123",
 		Holder:       "Holder",
 	}
 
-	expected := "Number: Number, ExpiryDate: ExpiryDate, SecurityCode: SecurityCode, Holder: Holder"
+	expected := "Number: **** 1111, ExpiryDate: 12/30, SecurityCode: ****, Holder: Holder"
 	assert.Equal(t, expected, secret.String())
 }
+
+func TestCard_Fields(t *testing.T) {
+	secret := Card{Number: "4111111111111111", ExpiryDate: "12/30", SecurityCode: "123", Holder: "IVAN"}
+	assert.Equal(t, []Field{
+		{Name: "number", Value: "4111111111111111", Hidden: true, Masked: "**** 1111"},
+		{Name: "expiry_date", Value: "12/30"},
+		{Name: "security_code", Value: "123", Hidden: true},
+		{Name: "holder", Value: "IVAN"},
+	}, secret.Fields())
+}
+
+func TestParseCard(t *testing.T) {
+	card, err := ParseCard("4111 1111-1111 1111", "1/2030", "123", " IVAN IVANOV ")
+	require.NoError(t, err)
+	assert.Equal(t, Card{
+		Number:       "4111111111111111",
+		ExpiryDate:   "01/30",
+		SecurityCode: "123",
+		Holder:       "IVAN IVANOV",
+	}, card)
+}
+
+func TestCard_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    Card
+		wantErr bool
+	}{
+		{name: "Visa", card: Card{Number: "4111111111111111", ExpiryDate: "12/30", SecurityCode: "123"}},
+		{name: "Amex", card: Card{Number: "378282246310005", ExpiryDate: "12/30", SecurityCode: "1234"}},
+		{name: "No security code", card: Card{Number: "5555555555554444", ExpiryDate: "12/30"}},
+		{name: "Checksum mismatch", card: Card{Number: "4111111111111112", ExpiryDate: "12/30"}, wantErr: true},
+		{name: "Too short", card: Card{Number: "42", ExpiryDate: "12/30"}, wantErr: true},
+		{name: "Not digits", card: Card{Number: "4111x11111111111", ExpiryDate: "12/30"}, wantErr: true},
+		{name: "Invalid month", card: Card{Number: "4111111111111111", ExpiryDate: "13/30"}, wantErr: true},
+		{name: "Invalid format", card: Card{Number: "4111111111111111", ExpiryDate: "2030-12"}, wantErr: true},
+		{name: "Amex short code", card: Card{Number: "378282246310005", ExpiryDate: "12/30", SecurityCode: "123"}, wantErr: true},
+		{name: "Visa long code", card: Card{Number: "4111111111111111", ExpiryDate: "12/30", SecurityCode: "1234"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.card.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCard)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCard_Network(t *testing.T) {
+	tests := map[string]string{
+		"4111111111111111": CardNetworkVisa,
+		"5555555555554444": CardNetworkMastercard,
+		"2221000000000009": CardNetworkMastercard,
+		"2200000000000004": CardNetworkMir,
+		"378282246310005":  CardNetworkAmex,
+		"6011111111111117": CardNetworkDiscover,
+		"3530111333300000": CardNetworkJCB,
+		"6200000000000005": CardNetworkUnionPay,
+		"30569309025904":   CardNetworkDiners,
+		"6759649826438453": CardNetworkMaestro,
+		"9999999999999995": CardNetworkUnknown,
+	}
+	for number, network := range tests {
+		assert.Equal(t, network, Card{Number: number}.Network(), number)
+	}
+}
+
+func TestCard_Expiry(t *testing.T) {
+	expiry, err := Card{ExpiryDate: "12/30"}.Expiry()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2031, time.January, 1, 0, 0, 0, 0, time.Local), expiry)
+
+	_, err = Card{ExpiryDate: "soon"}.Expiry()
+	assert.ErrorIs(t, err, ErrInvalidCard)
+}
@@ -19,3 +19,11 @@ func (c Credentials) Type() SecretType {
 func (c Credentials) String() string {
 	return fmt.Sprintf("Login: %s, Password: %s", c.Login, c.Password)
 }
+
+// Fields возвращает поля секрета в фиксированном порядке
+func (c Credentials) Fields() []Field {
+	return []Field{
+		{Name: "login", Value: c.Login},
+		{Name: "password", Value: c.Password, Hidden: true},
+	}
+}
@@ -14,3 +14,8 @@ func TestCredentials_String(t *testing.T) {
 	secret := Credentials{Login: "Login", Password: "Password"}
 	assert.Equal(t, "Login: Login, Password: Password", secret.String())
 }
+
+func TestCredentials_Fields(t *testing.T) {
+	secret := Credentials{Login: "Login", Password: "Password"}
+	assert.Equal(t, []Field{{Name: "login", Value: "Login"}, {Name: "password", Value: "Password", Hidden: true}}, secret.Fields())
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var _ Secret = (*Custom)(nil)
+
+// FieldKind тип пользовательского поля
+type FieldKind string
+
+// Типы пользовательских полей
+const (
+	FieldKindText   FieldKind = "text"
+	FieldKindHidden FieldKind = "hidden"
+	FieldKindURL    FieldKind = "url"
+	FieldKindDate   FieldKind = "date"
+)
+
+// FieldKinds поддерживаемые типы пользовательских полей
+var FieldKinds = []FieldKind{FieldKindText, FieldKindHidden, FieldKindURL, FieldKindDate}
+
+// CustomDateLayout формат значений полей типа date
+const CustomDateLayout = "2006-01-02"
+
+// ErrInvalidCustomField недопустимое пользовательское поле
+var ErrInvalidCustomField = errors.New("invalid custom field")
+
+// ParseFieldKind проверяет название типа пользовательского поля
+func ParseFieldKind(name string) (FieldKind, error) {
+	for _, kind := range FieldKinds {
+		if string(kind) == name {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown field type %q", ErrInvalidCustomField, name)
+}
+
+// CustomField пользовательское поле секрета
+type CustomField struct {
+	Name  string
+	Kind  FieldKind
+	Value string
+}
+
+// ParseCustomField разбирает описание поля вида name=value или name:type=value.
+// Если тип не указан, поле получает тип kind. Пустой kind означает, что тип определит Custom.Set,
+// в этом случае значение поля проверяется вместе с секретом
+func ParseCustomField(s string, kind FieldKind) (CustomField, error) {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return CustomField{}, fmt.Errorf("%w %q, expected name=value", ErrInvalidCustomField, s)
+	}
+	field := CustomField{Name: name, Kind: kind, Value: value}
+	if name, kindName, ok := strings.Cut(name, ":"); ok {
+		parsed, err := ParseFieldKind(kindName)
+		if err != nil {
+			return CustomField{}, err
+		}
+		field.Name, field.Kind = name, parsed
+	}
+	if field.Kind == "" {
+		if strings.TrimSpace(field.Name) == "" {
+			return CustomField{}, fmt.Errorf("%w: empty field name", ErrInvalidCustomField)
+		}
+		return field, nil
+	}
+	return field, field.Validate()
+}
+
+// Validate проверяет название, тип и формат значения поля. Пустое значение допустимо для любого типа
+func (f CustomField) Validate() error {
+	if strings.TrimSpace(f.Name) == "" {
+		return fmt.Errorf("%w: empty field name", ErrInvalidCustomField)
+	}
+	if _, err := ParseFieldKind(string(f.Kind)); err != nil {
+		return err
+	}
+	if f.Value == "" {
+		return nil
+	}
+
+	switch f.Kind {
+	case FieldKindURL:
+		u, err := url.Parse(f.Value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("%w: field %s is not an absolute url", ErrInvalidCustomField, f.Name)
+		}
+	case FieldKindDate:
+		if _, err := time.Parse(CustomDateLayout, f.Value); err != nil {
+			return fmt.Errorf("%w: field %s is not a date in YYYY-MM-DD format", ErrInvalidCustomField, f.Name)
+		}
+	}
+	return nil
+}
+
+// Custom секрет с упорядоченным списком пользовательских полей.
+// Template содержит название шаблона, по которому создан секрет
+type Custom struct {
+	Template string
+	Entries  []CustomField
+}
+
+// Type возвращает тип хранимой информации
+func (c Custom) Type() SecretType {
+	return secretTypeCustom
+}
+
+// String функция отображения приватной информации
+func (c Custom) String() string {
+	values := make([]string, 0, len(c.Entries))
+	for _, entry := range c.Entries {
+		value := entry.Value
+		if entry.Kind == FieldKindHidden {
+			value = maskedValue(value)
+		}
+		values = append(values, fmt.Sprintf("%s: %s", entry.Name, value))
+	}
+	return strings.Join(values, ", ")
+}
+
+// Fields возвращает поля секрета в порядке их добавления
+func (c Custom) Fields() []Field {
+	fields := make([]Field, 0, len(c.Entries))
+	for _, entry := range c.Entries {
+		fields = append(fields, Field{
+			Name:   entry.Name,
+			Value:  entry.Value,
+			Hidden: entry.Kind == FieldKindHidden,
+		})
+	}
+	return fields
+}
+
+// Validate проверяет поля секрета и уникальность их названий
+func (c Custom) Validate() error {
+	names := make(map[string]bool, len(c.Entries))
+	for _, entry := range c.Entries {
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+		if names[entry.Name] {
+			return fmt.Errorf("%w: duplicate field %s", ErrInvalidCustomField, entry.Name)
+		}
+		names[entry.Name] = true
+	}
+	return nil
+}
+
+// Set изменяет значение поля с названием field.Name или добавляет поле в конец списка.
+// Если тип поля не указан, сохраняется тип существующего поля
+func (c *Custom) Set(field CustomField) {
+	for i, entry := range c.Entries {
+		if entry.Name == field.Name {
+			if field.Kind == "" {
+				field.Kind = entry.Kind
+			}
+			c.Entries[i] = field
+			return
+		}
+	}
+	if field.Kind == "" {
+		field.Kind = FieldKindText
+	}
+	c.Entries = append(c.Entries, field)
+}
+
+// Remove удаляет поле name и сообщает, было ли оно найдено
+func (c *Custom) Remove(name string) bool {
+	for i, entry := range c.Entries {
+		if entry.Name == name {
+			c.Entries = append(c.Entries[:i], c.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustom_Type(t *testing.T) {
+	assert.Equal(t, secretTypeCustom, Custom{}.Type())
+}
+
+func TestCustom_Fields(t *testing.T) {
+	custom := Custom{Entries: []CustomField{
+		{Name: "host", Kind: FieldKindURL, Value: "https://db.example.com"},
+		{Name: "password", Kind: FieldKindHidden, Value: "qwerty"},
+		{Name: "expires", Kind: FieldKindDate, Value: "2030-01-31"},
+	}}
+	assert.Equal(t, []Field{
+		{Name: "host", Value: "https://db.example.com"},
+		{Name: "password", Value: "qwerty", Hidden: true},
+		{Name: "expires", Value: "2030-01-31"},
+	}, custom.Fields())
+	assert.Equal(t, "host: https://db.example.com, password: ****, expires: 2030-01-31", custom.String())
+}
+
+func TestParseCustomField(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		kind     FieldKind
+		expected CustomField
+	}{
+		{"user=admin", FieldKindText, CustomField{Name: "user", Kind: FieldKindText, Value: "admin"}},
+		{"token=a=b", FieldKindHidden, CustomField{Name: "token", Kind: FieldKindHidden, Value: "a=b"}},
+		{"site:url=https://example.com/login", FieldKindText, CustomField{Name: "site", Kind: FieldKindURL, Value: "https://example.com/login"}},
+		{"expires:date=2030-01-31", FieldKindText, CustomField{Name: "expires", Kind: FieldKindDate, Value: "2030-01-31"}},
+		{"answer:hidden=", FieldKindText, CustomField{Name: "answer", Kind: FieldKindHidden}},
+		{"expires=soon", "", CustomField{Name: "expires", Value: "soon"}},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			field, err := ParseCustomField(tc.input, tc.kind)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, field)
+		})
+	}
+
+	for _, input := range []string{"user", "=value", "site:url=example.com", "expires:date=31.01.2030", "x:number=1"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseCustomField(input, FieldKindText)
+			assert.ErrorIs(t, err, ErrInvalidCustomField)
+			_, err = ParseCustomField(input, "")
+			assert.ErrorIs(t, err, ErrInvalidCustomField)
+		})
+	}
+}
+
+func TestCustom_Validate(t *testing.T) {
+	custom := Custom{Entries: []CustomField{
+		{Name: "user", Kind: FieldKindText, Value: "admin"},
+		{Name: "user", Kind: FieldKindHidden, Value: "root"},
+	}}
+	assert.ErrorIs(t, custom.Validate(), ErrInvalidCustomField)
+
+	custom.Entries[1].Name = "password"
+	assert.NoError(t, custom.Validate())
+
+	custom.Entries[1].Kind = ""
+	assert.ErrorIs(t, custom.Validate(), ErrInvalidCustomField)
+}
+
+func TestCustom_SetRemove(t *testing.T) {
+	var custom Custom
+	custom.Set(CustomField{Name: "user", Value: "admin"})
+	custom.Set(CustomField{Name: "password", Kind: FieldKindHidden, Value: "qwerty"})
+	custom.Set(CustomField{Name: "host", Kind: FieldKindURL, Value: "https://db.example.com"})
+	custom.Set(CustomField{Name: "password", Value: "secret"})
+
+	assert.Equal(t, []CustomField{
+		{Name: "user", Kind: FieldKindText, Value: "admin"},
+		{Name: "password", Kind: FieldKindHidden, Value: "secret"},
+		{Name: "host", Kind: FieldKindURL, Value: "https://db.example.com"},
+	}, custom.Entries)
+
+	assert.True(t, custom.Remove("password"))
+	assert.False(t, custom.Remove("password"))
+	assert.Equal(t, []CustomField{
+		{Name: "user", Kind: FieldKindText, Value: "admin"},
+		{Name: "host", Kind: FieldKindURL, Value: "https://db.example.com"},
+	}, custom.Entries)
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+)
+
+var _ Secret = (*Note)(nil)
+
+// MaxAttachmentSize максимальный размер вложения заметки
+const MaxAttachmentSize = 3 << 20
+
+var (
+	// ErrAttachmentExists вложение с таким именем уже есть в заметке
+	ErrAttachmentExists = errors.New("attachment already exists")
+	// ErrAttachmentNotFound вложение с таким именем отсутствует в заметке
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrAttachmentTooLarge размер вложения превышает MaxAttachmentSize
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+)
+
+// Attachment вложение заметки. Содержимое хранится на сервере отдельно от заметки
+// и зашифровано ключом Key, который известен только владельцам ключа заметки
+type Attachment struct {
+	ID   string
+	Name string
+	Size int
+	Key  []byte
+}
+
+// Note заметка с заголовком, текстом в формате Markdown и вложениями
+type Note struct {
+	Title       string
+	Body        string
+	Attachments []Attachment `json:",omitempty"`
+}
+
+// NewAttachment создает вложение name и шифрует его содержимое data новым ключом.
+// Идентификатор вложения назначается сервером при сохранении зашифрованного содержимого
+func NewAttachment(name string, data []byte) (Attachment, []byte, error) {
+	if len(data) > MaxAttachmentSize {
+		return Attachment{}, nil, fmt.Errorf("%w: %s is %d bytes, limit is %d", ErrAttachmentTooLarge, name, len(data), MaxAttachmentSize)
+	}
+	key, err := gcm.NewKey()
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+	blockCipher, err := gcm.NewWithKey(key)
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+	content, err := blockCipher.Encrypt(data)
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+	return Attachment{Name: name, Size: len(data), Key: key}, content, nil
+}
+
+// Open расшифровывает содержимое вложения
+func (a Attachment) Open(content []byte) ([]byte, error) {
+	blockCipher, err := gcm.NewWithKey(a.Key)
+	if err != nil {
+		return nil, err
+	}
+	return blockCipher.Decrypt(content)
+}
+
+// Type возвращает тип хранимой информации
+func (n Note) Type() SecretType {
+	return secretTypeNote
+}
+
+// String функция отображения приватной информации
+func (n Note) String() string {
+	return fmt.Sprintf("Title: %s, Body: %s, Attachments: %s", n.Title, n.Body, strings.Join(n.attachmentNames(), ", "))
+}
+
+// Fields возвращает поля секрета в фиксированном порядке. Поле attachments содержит имена вложений по одному в строке
+func (n Note) Fields() []Field {
+	return []Field{
+		{Name: "title", Value: n.Title},
+		{Name: "body", Value: n.Body},
+		{Name: "attachments", Value: strings.Join(n.attachmentNames(), "\n")},
+	}
+}
+
+func (n Note) attachmentNames() []string {
+	names := make([]string, 0, len(n.Attachments))
+	for _, attachment := range n.Attachments {
+		names = append(names, attachment.Name)
+	}
+	return names
+}
+
+// Attachment возвращает вложение с именем name
+func (n Note) Attachment(name string) (Attachment, error) {
+	for _, attachment := range n.Attachments {
+		if attachment.Name == name {
+			return attachment, nil
+		}
+	}
+	return Attachment{}, fmt.Errorf("%w: %s", ErrAttachmentNotFound, name)
+}
+
+// Attach добавляет вложение. Имена вложений заметки уникальны
+func (n *Note) Attach(attachment Attachment) error {
+	if _, err := n.Attachment(attachment.Name); err == nil {
+		return fmt.Errorf("%w: %s", ErrAttachmentExists, attachment.Name)
+	}
+	n.Attachments = append(n.Attachments, attachment)
+	return nil
+}
+
+// Detach удаляет вложение с именем name и возвращает его
+func (n *Note) Detach(name string) (Attachment, error) {
+	for i, attachment := range n.Attachments {
+		if attachment.Name == name {
+			n.Attachments = append(n.Attachments[:i:i], n.Attachments[i+1:]...)
+			return attachment, nil
+		}
+	}
+	return Attachment{}, fmt.Errorf("%w: %s", ErrAttachmentNotFound, name)
+}
+
+// ParseNote разбирает текст заметки из редактора: первая строка вида "# Заголовок" становится
+// заголовком, остальной текст - содержимым заметки
+func ParseNote(text string) (title, body string) {
+	text = strings.TrimLeft(text, "\n")
+	first, rest, _ := strings.Cut(text, "\n")
+	if strings.HasPrefix(first, "# ") {
+		return strings.TrimSpace(strings.TrimPrefix(first, "# ")), strings.TrimLeft(rest, "\n")
+	}
+	return "", text
+}
+
+// Markdown возвращает текст заметки для редактирования: заголовок первой строкой, затем содержимое
+func (n Note) Markdown() string {
+	if n.Title == "" {
+		return n.Body
+	}
+	return "# " + n.Title + "\n\n" + n.Body
+}
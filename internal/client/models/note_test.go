@@ -0,0 +1,89 @@
+package models
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNote_Type(t *testing.T) {
+	assert.Equal(t, secretTypeNote, Note{}.Type())
+}
+
+func TestNote_Fields(t *testing.T) {
+	note := Note{
+		Title:       "Server",
+		Body:        "Certificates",
+		Attachments: []Attachment{{Name: "ca.pem"}, {Name: "server.pem"}},
+	}
+	assert.Equal(t, []Field{
+		{Name: "title", Value: "Server"},
+		{Name: "body", Value: "Certificates"},
+		{Name: "attachments", Value: "ca.pem\nserver.pem"},
+	}, note.Fields())
+	assert.Equal(t, "Title: Server, Body: Certificates, Attachments: ca.pem, server.pem", note.String())
+}
+
+func TestNewAttachment(t *testing.T) {
+	data := []byte("-----BEGIN CERTIFICATE-----")
+	attachment, content, err := NewAttachment("ca.pem", data)
+	require.NoError(t, err)
+	assert.Equal(t, "ca.pem", attachment.Name)
+	assert.Equal(t, len(data), attachment.Size)
+	assert.False(t, bytes.Contains(content, data))
+
+	opened, err := attachment.Open(content)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+
+	other, _, err := NewAttachment("other", data)
+	require.NoError(t, err)
+	_, err = other.Open(content)
+	assert.Error(t, err)
+
+	_, _, err = NewAttachment("large", make([]byte, MaxAttachmentSize+1))
+	assert.ErrorIs(t, err, ErrAttachmentTooLarge)
+}
+
+func TestNote_Attachments(t *testing.T) {
+	var note Note
+	require.NoError(t, note.Attach(Attachment{ID: "1", Name: "ca.pem"}))
+	require.NoError(t, note.Attach(Attachment{ID: "2", Name: "key.pem"}))
+	assert.ErrorIs(t, note.Attach(Attachment{ID: "3", Name: "ca.pem"}), ErrAttachmentExists)
+
+	attachment, err := note.Attachment("key.pem")
+	require.NoError(t, err)
+	assert.Equal(t, "2", attachment.ID)
+
+	detached, err := note.Detach("ca.pem")
+	require.NoError(t, err)
+	assert.Equal(t, "1", detached.ID)
+	assert.Equal(t, []Attachment{{ID: "2", Name: "key.pem"}}, note.Attachments)
+
+	_, err = note.Detach("ca.pem")
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+}
+
+func TestParseNote(t *testing.T) {
+	tests := []struct {
+		text  string
+		title string
+		body  string
+	}{
+		{text: "# Server\n\nSee *attachments*\n", title: "Server", body: "See *attachments*\n"},
+		{text: "\n# Server\n", title: "Server", body: ""},
+		{text: "Just text\n# Not a title\n", title: "", body: "Just text\n# Not a title\n"},
+	}
+	for _, tt := range tests {
+		title, body := ParseNote(tt.text)
+		assert.Equal(t, tt.title, title, tt.text)
+		assert.Equal(t, tt.body, body, tt.text)
+	}
+
+	note := Note{Title: "Server", Body: "See *attachments*\n"}
+	title, body := ParseNote(note.Markdown())
+	assert.Equal(t, note.Title, title)
+	assert.Equal(t, note.Body, body)
+}
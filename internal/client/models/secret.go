@@ -13,6 +13,10 @@ const (
 	secretTypeText        SecretType = "text"
 	secretTypeBin         SecretType = "bin"
 	secretTypeCard        SecretType = "card"
+	secretTypeTOTP        SecretType = "totp"
+	secretTypeSSHKey      SecretType = "ssh"
+	secretTypeCustom      SecretType = "custom"
+	secretTypeNote        SecretType = "note"
 )
 
 // Secret приватные данные пользователя
@@ -21,6 +25,19 @@ type Secret interface {
 	Type() SecretType
 	// String функция отображения приватной информации
 	String() string
+	// Fields возвращает поля секрета в фиксированном порядке
+	Fields() []Field
+}
+
+// Field именованное поле секрета. Binary означает, что значение содержит произвольные байты,
+// Hidden - что значение скрывается при просмотре. Masked - отображаемое вместо скрытого значения
+// представление, например номер карты с последними четырьмя цифрами
+type Field struct {
+	Name   string
+	Value  string
+	Binary bool
+	Hidden bool
+	Masked string
 }
 
 type container struct {
@@ -60,6 +77,30 @@ func DecodeSecret(data []byte) (Secret, error) {
 			return nil, err
 		}
 		return card, nil
+	case secretTypeTOTP:
+		var t TOTP
+		if err := json.Unmarshal(c.Data, &t); err != nil {
+			return nil, err
+		}
+		return t, nil
+	case secretTypeSSHKey:
+		var key SSHKey
+		if err := json.Unmarshal(c.Data, &key); err != nil {
+			return nil, err
+		}
+		return key, nil
+	case secretTypeCustom:
+		var custom Custom
+		if err := json.Unmarshal(c.Data, &custom); err != nil {
+			return nil, err
+		}
+		return custom, nil
+	case secretTypeNote:
+		var note Note
+		if err := json.Unmarshal(c.Data, &note); err != nil {
+			return nil, err
+		}
+		return note, nil
 	default:
 		return nil, errors.New("unknown secret type")
 	}
@@ -43,6 +43,57 @@ func TestEncodeSecret(t *testing.T) {
 		}
 		expected := []byte(`{"type":"card","data":{"Number":"Number","ExpiryDate":"ExpiryDate","SecurityCode":"SecurityCode","Holder":"Holder"}}`)
 
+		data, err := EncodeSecret(secret)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+	t.Run("EncodeTOTP", func(t *testing.T) {
+		secret := TOTP{
+			Issuer:    "Issuer",
+			Account:   "Account",
+			Secret:    "JBSWY3DPEHPK3PXP",
+			Algorithm: TOTPAlgorithmSHA1,
+			Digits:    6,
+			Period:    30,
+		}
+		expected := []byte(`{"type":"totp","data":{"Issuer":"Issuer","Account":"Account","Secret":"JBSWY3DPEHPK3PXP","Algorithm":"SHA1","Digits":6,"Period":30}}`)
+
+		data, err := EncodeSecret(secret)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+	t.Run("EncodeSSHKey", func(t *testing.T) {
+		secret := SSHKey{
+			PrivateKey: "PrivateKey",
+			PublicKey:  "PublicKey",
+			Comment:    "Comment",
+			Passphrase: "Passphrase",
+		}
+		expected := []byte(`{"type":"ssh","data":{"PrivateKey":"PrivateKey","PublicKey":"PublicKey","Comment":"Comment","Passphrase":"Passphrase"}}`)
+
+		data, err := EncodeSecret(secret)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+	t.Run("EncodeCustom", func(t *testing.T) {
+		secret := Custom{
+			Template: "Template",
+			Entries:  []CustomField{{Name: "Name", Kind: FieldKindHidden, Value: "Value"}},
+		}
+		expected := []byte(`{"type":"custom","data":{"Template":"Template","Entries":[{"Name":"Name","Kind":"hidden","Value":"Value"}]}}`)
+
+		data, err := EncodeSecret(secret)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+	t.Run("EncodeNote", func(t *testing.T) {
+		secret := Note{
+			Title:       "Title",
+			Body:        "Body",
+			Attachments: []Attachment{{ID: "ID", Name: "Name", Size: 3, Key: []byte("Key")}},
+		}
+		expected := []byte(`{"type":"note","data":{"Title":"Title","Body":"Body","Attachments":[{"ID":"ID","Name":"Name","Size":3,"Key":"S2V5"}]}}`)
+
 		data, err := EncodeSecret(secret)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, data)
@@ -98,4 +149,59 @@ func TestDecodeSecret(t *testing.T) {
 		assert.Equal(t, "SecurityCode", card.SecurityCode)
 		assert.Equal(t, "Holder", card.Holder)
 	})
+	t.Run("DecodeTOTP", func(t *testing.T) {
+		data := []byte(`{"type":"totp","data":{"Issuer":"Issuer","Account":"Account","Secret":"JBSWY3DPEHPK3PXP","Algorithm":"SHA1","Digits":6,"Period":30}}`)
+
+		secret, err := DecodeSecret(data)
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Type(), secretTypeTOTP)
+
+		otp, ok := secret.(TOTP)
+		assert.True(t, ok)
+		assert.Equal(t, "Issuer", otp.Issuer)
+		assert.Equal(t, "Account", otp.Account)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", otp.Secret)
+		assert.Equal(t, TOTPAlgorithmSHA1, otp.Algorithm)
+		assert.Equal(t, 6, otp.Digits)
+		assert.Equal(t, 30, otp.Period)
+	})
+	t.Run("DecodeSSHKey", func(t *testing.T) {
+		data := []byte(`{"type":"ssh","data":{"PrivateKey":"PrivateKey","PublicKey":"PublicKey","Comment":"Comment","Passphrase":"Passphrase"}}`)
+
+		secret, err := DecodeSecret(data)
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Type(), secretTypeSSHKey)
+
+		key, ok := secret.(SSHKey)
+		assert.True(t, ok)
+		assert.Equal(t, "PrivateKey", key.PrivateKey)
+		assert.Equal(t, "PublicKey", key.PublicKey)
+		assert.Equal(t, "Comment", key.Comment)
+		assert.Equal(t, "Passphrase", key.Passphrase)
+	})
+	t.Run("DecodeCustom", func(t *testing.T) {
+		data := []byte(`{"type":"custom","data":{"Template":"Template","Entries":[{"Name":"Name","Kind":"hidden","Value":"Value"}]}}`)
+
+		secret, err := DecodeSecret(data)
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Type(), secretTypeCustom)
+
+		custom, ok := secret.(Custom)
+		assert.True(t, ok)
+		assert.Equal(t, "Template", custom.Template)
+		assert.Equal(t, []CustomField{{Name: "Name", Kind: FieldKindHidden, Value: "Value"}}, custom.Entries)
+	})
+	t.Run("DecodeNote", func(t *testing.T) {
+		data := []byte(`{"type":"note","data":{"Title":"Title","Body":"Body","Attachments":[{"ID":"ID","Name":"Name","Size":3,"Key":"S2V5"}]}}`)
+
+		secret, err := DecodeSecret(data)
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Type(), secretTypeNote)
+
+		note, ok := secret.(Note)
+		assert.True(t, ok)
+		assert.Equal(t, "Title", note.Title)
+		assert.Equal(t, "Body", note.Body)
+		assert.Equal(t, []Attachment{{ID: "ID", Name: "Name", Size: 3, Key: []byte("Key")}}, note.Attachments)
+	})
 }
@@ -0,0 +1,345 @@
+package models
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var _ Secret = (*SSHKey)(nil)
+
+// Алгоритмы генерируемых SSH-ключей
+const (
+	SSHKeyTypeEd25519 = "ed25519"
+	SSHKeyTypeRSA     = "rsa"
+)
+
+// DefaultSSHKeyRSABits размер генерируемого RSA-ключа по умолчанию
+const DefaultSSHKeyRSABits = 3072
+
+const (
+	openSSHMagic          = "openssh-key-v1\x00"
+	openSSHPrivateKeyType = "OPENSSH PRIVATE KEY"
+	openSSHBlockSize      = 8
+	minSSHKeyRSABits      = 2048
+)
+
+var (
+	// ErrInvalidSSHKey недопустимый SSH-ключ
+	ErrInvalidSSHKey = errors.New("invalid ssh key")
+	// ErrSSHKeyPassphrase не указана или неверна парольная фраза SSH-ключа
+	ErrSSHKeyPassphrase = errors.New("invalid or missing ssh key passphrase")
+)
+
+// SSHKey SSH-ключ: закрытый ключ в формате OpenSSH или PEM, открытый ключ в формате authorized_keys,
+// комментарий и парольная фраза, если закрытый ключ зашифрован
+type SSHKey struct {
+	PrivateKey string
+	PublicKey  string
+	Comment    string
+	Passphrase string
+}
+
+// Type возвращает тип хранимой информации
+func (k SSHKey) Type() SecretType {
+	return secretTypeSSHKey
+}
+
+// String функция отображения приватной информации
+func (k SSHKey) String() string {
+	return fmt.Sprintf("PublicKey: %s, Comment: %s, PrivateKey: %s, Passphrase: %s",
+		k.PublicKey, k.Comment, maskedValue(k.PrivateKey), maskedValue(k.Passphrase))
+}
+
+// Fields возвращает поля секрета в фиксированном порядке
+func (k SSHKey) Fields() []Field {
+	return []Field{
+		{Name: "public_key", Value: k.PublicKey},
+		{Name: "comment", Value: k.Comment},
+		{Name: "private_key", Value: k.PrivateKey, Hidden: true},
+		{Name: "passphrase", Value: k.Passphrase, Hidden: true},
+	}
+}
+
+// RawPrivateKey возвращает расшифрованный закрытый ключ для подписи или добавления в ssh-agent
+func (k SSHKey) RawPrivateKey() (interface{}, error) {
+	raw, _, err := parseRawSSHKey([]byte(k.PrivateKey), k.Passphrase)
+	return raw, err
+}
+
+// Fingerprint возвращает отпечаток SHA256 открытого ключа
+func (k SSHKey) Fingerprint() (string, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+	return ssh.FingerprintSHA256(publicKey), nil
+}
+
+// WithComment возвращает ключ с измененным комментарием
+func (k SSHKey) WithComment(comment string) (SSHKey, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey))
+	if err != nil {
+		return SSHKey{}, fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+	k.Comment = comment
+	k.PublicKey = authorizedKey(publicKey, comment)
+	return k, nil
+}
+
+// Validate проверяет, что закрытый ключ расшифровывается и соответствует открытому
+func (k SSHKey) Validate() error {
+	raw, err := k.RawPrivateKey()
+	if err != nil {
+		return err
+	}
+	signer, err := ssh.NewSignerFromKey(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+	if !bytes.Equal(publicKey.Marshal(), signer.PublicKey().Marshal()) {
+		return fmt.Errorf("%w: public key does not match private key", ErrInvalidSSHKey)
+	}
+	return nil
+}
+
+// GenerateSSHKey генерирует ключ алгоритма keyType. Закрытый ключ сохраняется в формате OpenSSH без шифрования:
+// он защищен шифрованием хранилища. bits используется только для RSA, 0 означает размер по умолчанию
+func GenerateSSHKey(keyType string, bits int, comment string) (SSHKey, error) {
+	var raw interface{}
+	switch keyType {
+	case SSHKeyTypeEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return SSHKey{}, err
+		}
+		raw = key
+	case SSHKeyTypeRSA:
+		if bits == 0 {
+			bits = DefaultSSHKeyRSABits
+		}
+		if bits < minSSHKeyRSABits {
+			return SSHKey{}, fmt.Errorf("%w: rsa key size must be at least %d bits", ErrInvalidSSHKey, minSSHKeyRSABits)
+		}
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return SSHKey{}, err
+		}
+		raw = key
+	default:
+		return SSHKey{}, fmt.Errorf("%w: unsupported key type %q", ErrInvalidSSHKey, keyType)
+	}
+
+	block, err := marshalOpenSSHPrivateKey(raw, comment)
+	if err != nil {
+		return SSHKey{}, err
+	}
+	signer, err := ssh.NewSignerFromKey(raw)
+	if err != nil {
+		return SSHKey{}, err
+	}
+	return SSHKey{
+		PrivateKey: string(pem.EncodeToMemory(block)),
+		PublicKey:  authorizedKey(signer.PublicKey(), comment),
+		Comment:    comment,
+	}, nil
+}
+
+// ParseSSHKey импортирует закрытый ключ в формате OpenSSH или PEM. Зашифрованный ключ сохраняется как есть
+// вместе с парольной фразой. Если comment пуст, используется комментарий из ключа формата OpenSSH
+func ParseSSHKey(data []byte, passphrase, comment string) (SSHKey, error) {
+	raw, encrypted, err := parseRawSSHKey(data, passphrase)
+	if err != nil {
+		return SSHKey{}, err
+	}
+	if !encrypted {
+		passphrase = ""
+	}
+	signer, err := ssh.NewSignerFromKey(raw)
+	if err != nil {
+		return SSHKey{}, fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+
+	if comment == "" && !encrypted {
+		comment = openSSHComment(data)
+	}
+	return SSHKey{
+		PrivateKey: string(data),
+		PublicKey:  authorizedKey(signer.PublicKey(), comment),
+		Comment:    comment,
+		Passphrase: passphrase,
+	}, nil
+}
+
+// parseRawSSHKey разбирает закрытый ключ. Парольная фраза используется, только если ключ зашифрован
+func parseRawSSHKey(data []byte, passphrase string) (raw interface{}, encrypted bool, err error) {
+	raw, err = ssh.ParseRawPrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return nil, true, ErrSSHKeyPassphrase
+		}
+		encrypted = true
+		raw, err = ssh.ParseRawPrivateKeyWithPassphrase(data, []byte(passphrase))
+	}
+	switch {
+	case errors.Is(err, x509.IncorrectPasswordError):
+		return nil, encrypted, ErrSSHKeyPassphrase
+	case err != nil:
+		return nil, encrypted, fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+
+	// Ключи ed25519 разбираются как указатели, а ssh-agent ожидает значения
+	if key, ok := raw.(*ed25519.PrivateKey); ok {
+		raw = *key
+	}
+	return raw, encrypted, nil
+}
+
+// authorizedKey возвращает открытый ключ в формате строки authorized_keys
+func authorizedKey(publicKey ssh.PublicKey, comment string) string {
+	line := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(publicKey)), "\n")
+	if comment != "" {
+		line += " " + comment
+	}
+	return line
+}
+
+type openSSHKey struct {
+	CipherName   string
+	KdfName      string
+	KdfOpts      string
+	NumKeys      uint32
+	PubKey       []byte
+	PrivKeyBlock []byte
+}
+
+type openSSHPrivateKeyHeader struct {
+	Check1  uint32
+	Check2  uint32
+	Keytype string
+	Rest    []byte `ssh:"rest"`
+}
+
+// openSSHComment возвращает комментарий незашифрованного ключа формата OpenSSH
+func openSSHComment(data []byte) string {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != openSSHPrivateKeyType || !bytes.HasPrefix(block.Bytes, []byte(openSSHMagic)) {
+		return ""
+	}
+	var key openSSHKey
+	if err := ssh.Unmarshal(block.Bytes[len(openSSHMagic):], &key); err != nil || key.CipherName != "none" {
+		return ""
+	}
+	var header openSSHPrivateKeyHeader
+	if err := ssh.Unmarshal(key.PrivKeyBlock, &header); err != nil {
+		return ""
+	}
+
+	switch header.Keytype {
+	case ssh.KeyAlgoED25519:
+		var fields struct {
+			Pub     []byte
+			Priv    []byte
+			Comment string
+			Pad     []byte `ssh:"rest"`
+		}
+		if ssh.Unmarshal(header.Rest, &fields) == nil {
+			return fields.Comment
+		}
+	case ssh.KeyAlgoRSA:
+		var fields struct {
+			N, E, D, Iqmp, P, Q *big.Int
+			Comment             string
+			Pad                 []byte `ssh:"rest"`
+		}
+		if ssh.Unmarshal(header.Rest, &fields) == nil {
+			return fields.Comment
+		}
+	case ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+		var fields struct {
+			Curve   string
+			Pub     []byte
+			D       *big.Int
+			Comment string
+			Pad     []byte `ssh:"rest"`
+		}
+		if ssh.Unmarshal(header.Rest, &fields) == nil {
+			return fields.Comment
+		}
+	}
+	return ""
+}
+
+// marshalOpenSSHPrivateKey кодирует незашифрованный закрытый ключ в формат openssh-key-v1
+func marshalOpenSSHPrivateKey(raw interface{}, comment string) (*pem.Block, error) {
+	signer, err := ssh.NewSignerFromKey(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []byte
+	switch key := raw.(type) {
+	case ed25519.PrivateKey:
+		fields = ssh.Marshal(struct {
+			Pub     []byte
+			Priv    []byte
+			Comment string
+		}{key.Public().(ed25519.PublicKey), key, comment})
+	case *rsa.PrivateKey:
+		key.Precompute()
+		fields = ssh.Marshal(struct {
+			N, E, D, Iqmp, P, Q *big.Int
+			Comment             string
+		}{key.N, big.NewInt(int64(key.E)), key.D, key.Precomputed.Qinv, key.Primes[0], key.Primes[1], comment})
+	default:
+		return nil, fmt.Errorf("%w: unsupported private key %T", ErrInvalidSSHKey, raw)
+	}
+
+	check, err := randomUint32()
+	if err != nil {
+		return nil, err
+	}
+	private := ssh.Marshal(openSSHPrivateKeyHeader{
+		Check1:  check,
+		Check2:  check,
+		Keytype: signer.PublicKey().Type(),
+		Rest:    fields,
+	})
+	for i := 1; len(private)%openSSHBlockSize != 0; i++ {
+		private = append(private, byte(i))
+	}
+
+	key := ssh.Marshal(openSSHKey{
+		CipherName:   "none",
+		KdfName:      "none",
+		NumKeys:      1,
+		PubKey:       signer.PublicKey().Marshal(),
+		PrivKeyBlock: private,
+	})
+	return &pem.Block{
+		Type:  openSSHPrivateKeyType,
+		Bytes: append([]byte(openSSHMagic), key...),
+	}, nil
+}
+
+func randomUint32() (uint32, error) {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]), nil
+}
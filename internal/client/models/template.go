@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TemplateField поле шаблона секретов
+type TemplateField struct {
+	Name string
+	Kind FieldKind
+}
+
+// ParseTemplateField разбирает описание поля шаблона вида name или name:type. По умолчанию поле имеет тип text
+func ParseTemplateField(s string) (TemplateField, error) {
+	field := TemplateField{Name: s, Kind: FieldKindText}
+	if name, kindName, ok := strings.Cut(s, ":"); ok {
+		kind, err := ParseFieldKind(kindName)
+		if err != nil {
+			return TemplateField{}, err
+		}
+		field.Name, field.Kind = name, kind
+	}
+	return field, CustomField{Name: field.Name, Kind: field.Kind}.Validate()
+}
+
+// Template пользовательский шаблон секретов: упорядоченный список полей с типами.
+// Шаблоны хранятся на сервере в зашифрованном виде
+type Template struct {
+	Fields []TemplateField
+}
+
+// Validate проверяет поля шаблона и уникальность их названий
+func (t Template) Validate() error {
+	if len(t.Fields) == 0 {
+		return fmt.Errorf("%w: template has no fields", ErrInvalidCustomField)
+	}
+	return t.NewCustom("").Validate()
+}
+
+// NewCustom создает секрет с пустыми полями шаблона name
+func (t Template) NewCustom(name string) Custom {
+	custom := Custom{Template: name}
+	for _, field := range t.Fields {
+		custom.Entries = append(custom.Entries, CustomField{Name: field.Name, Kind: field.Kind})
+	}
+	return custom
+}
+
+// EncodeTemplate кодирует шаблон для шифрования и сохранения на сервере
+func EncodeTemplate(t Template) ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// DecodeTemplate декодирует шаблон
+func DecodeTemplate(data []byte) (Template, error) {
+	var t Template
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Template{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate(t *testing.T) {
+	template := Template{Fields: []TemplateField{
+		{Name: "host", Kind: FieldKindURL},
+		{Name: "user", Kind: FieldKindText},
+		{Name: "password", Kind: FieldKindHidden},
+	}}
+	require.NoError(t, template.Validate())
+
+	data, err := EncodeTemplate(template)
+	require.NoError(t, err)
+	decoded, err := DecodeTemplate(data)
+	require.NoError(t, err)
+	assert.Equal(t, template, decoded)
+
+	assert.Equal(t, Custom{Template: "database", Entries: []CustomField{
+		{Name: "host", Kind: FieldKindURL},
+		{Name: "user", Kind: FieldKindText},
+		{Name: "password", Kind: FieldKindHidden},
+	}}, template.NewCustom("database"))
+
+	assert.ErrorIs(t, Template{}.Validate(), ErrInvalidCustomField)
+	duplicate := Template{Fields: []TemplateField{{Name: "a", Kind: FieldKindText}, {Name: "a", Kind: FieldKindDate}}}
+	assert.ErrorIs(t, duplicate.Validate(), ErrInvalidCustomField)
+}
+
+func TestParseTemplateField(t *testing.T) {
+	field, err := ParseTemplateField("user")
+	require.NoError(t, err)
+	assert.Equal(t, TemplateField{Name: "user", Kind: FieldKindText}, field)
+
+	field, err = ParseTemplateField("expires:date")
+	require.NoError(t, err)
+	assert.Equal(t, TemplateField{Name: "expires", Kind: FieldKindDate}, field)
+
+	_, err = ParseTemplateField(":hidden")
+	assert.ErrorIs(t, err, ErrInvalidCustomField)
+	_, err = ParseTemplateField("pin:number")
+	assert.ErrorIs(t, err, ErrInvalidCustomField)
+}
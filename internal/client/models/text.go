@@ -18,3 +18,10 @@ func (t Text) Type() SecretType {
 func (t Text) String() string {
 	return fmt.Sprintf("TextData: %s", t.Data)
 }
+
+// Fields возвращает поля секрета в фиксированном порядке
+func (t Text) Fields() []Field {
+	return []Field{
+		{Name: "data", Value: t.Data, Hidden: true},
+	}
+}
@@ -14,3 +14,8 @@ func TestText_String(t *testing.T) {
 	secret := Text{Data: "data"}
 	assert.Equal(t, "TextData: data", secret.String())
 }
+
+func TestText_Fields(t *testing.T) {
+	secret := Text{Data: "Data"}
+	assert.Equal(t, []Field{{Name: "data", Value: "Data", Hidden: true}}, secret.Fields())
+}
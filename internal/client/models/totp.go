@@ -0,0 +1,181 @@
+package models
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+var _ Secret = (*TOTP)(nil)
+
+// Алгоритмы HMAC одноразовых паролей
+const (
+	TOTPAlgorithmSHA1   = "SHA1"
+	TOTPAlgorithmSHA256 = "SHA256"
+	TOTPAlgorithmSHA512 = "SHA512"
+)
+
+// Параметры одноразовых паролей по умолчанию
+const (
+	DefaultTOTPDigits = 6
+	DefaultTOTPPeriod = 30
+)
+
+const totpScheme = "otpauth"
+
+// ErrInvalidTOTP недопустимые параметры одноразовых паролей
+var ErrInvalidTOTP = errors.New("invalid totp parameters")
+
+var totpAlgorithms = map[string]otp.Algorithm{
+	TOTPAlgorithmSHA1:   otp.AlgorithmSHA1,
+	TOTPAlgorithmSHA256: otp.AlgorithmSHA256,
+	TOTPAlgorithmSHA512: otp.AlgorithmSHA512,
+}
+
+// TOTP параметры генерации одноразовых паролей по времени (RFC 6238)
+type TOTP struct {
+	Issuer    string
+	Account   string
+	Secret    string
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+// Type возвращает тип хранимой информации
+func (t TOTP) Type() SecretType {
+	return secretTypeTOTP
+}
+
+// String функция отображения приватной информации
+func (t TOTP) String() string {
+	return fmt.Sprintf("Issuer: %s, Account: %s, Secret: %s, Algorithm: %s, Digits: %d, Period: %d",
+		t.Issuer, t.Account, maskedValue(t.Secret), t.Algorithm, t.Digits, t.Period)
+}
+
+// Fields возвращает поля секрета в фиксированном порядке
+func (t TOTP) Fields() []Field {
+	return []Field{
+		{Name: "issuer", Value: t.Issuer},
+		{Name: "account", Value: t.Account},
+		{Name: "secret", Value: t.Secret, Hidden: true},
+		{Name: "algorithm", Value: t.Algorithm},
+		{Name: "digits", Value: strconv.Itoa(t.Digits)},
+		{Name: "period", Value: strconv.Itoa(t.Period)},
+	}
+}
+
+// normalizedSecret возвращает ключ в верхнем регистре без пробелов и выравнивания
+func (t TOTP) normalizedSecret() string {
+	secret := strings.ToUpper(strings.Join(strings.Fields(t.Secret), ""))
+	return strings.TrimRight(secret, "=")
+}
+
+// Validate проверяет ключ в кодировке base32, алгоритм, количество цифр и период
+func (t TOTP) Validate() error {
+	secret := t.normalizedSecret()
+	if secret == "" {
+		return fmt.Errorf("%w: empty secret", ErrInvalidTOTP)
+	}
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret); err != nil {
+		return fmt.Errorf("%w: secret is not base32", ErrInvalidTOTP)
+	}
+	if _, ok := totpAlgorithms[t.Algorithm]; !ok {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidTOTP, t.Algorithm)
+	}
+	if t.Digits < 6 || t.Digits > 8 {
+		return fmt.Errorf("%w: digits must be between 6 and 8", ErrInvalidTOTP)
+	}
+	if t.Period <= 0 {
+		return fmt.Errorf("%w: period must be positive", ErrInvalidTOTP)
+	}
+	return nil
+}
+
+// Code возвращает одноразовый пароль на момент now и время до его смены
+func (t TOTP) Code(now time.Time) (string, time.Duration, error) {
+	if err := t.Validate(); err != nil {
+		return "", 0, err
+	}
+	code, err := totp.GenerateCodeCustom(t.normalizedSecret(), now, totp.ValidateOpts{
+		Period:    uint(t.Period),
+		Digits:    otp.Digits(t.Digits),
+		Algorithm: totpAlgorithms[t.Algorithm],
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	period := time.Duration(t.Period) * time.Second
+	elapsed := time.Duration(now.UnixNano()) % period
+	return code, period - elapsed, nil
+}
+
+// URI возвращает параметры в формате otpauth://totp/
+func (t TOTP) URI() string {
+	label := t.Account
+	if t.Issuer != "" {
+		label = t.Issuer + ":" + t.Account
+	}
+	query := url.Values{}
+	query.Set("secret", t.normalizedSecret())
+	if t.Issuer != "" {
+		query.Set("issuer", t.Issuer)
+	}
+	query.Set("algorithm", t.Algorithm)
+	query.Set("digits", strconv.Itoa(t.Digits))
+	query.Set("period", strconv.Itoa(t.Period))
+	u := url.URL{Scheme: totpScheme, Host: "totp", Path: "/" + label, RawQuery: query.Encode()}
+	return u.String()
+}
+
+// ParseTOTPURI разбирает URI вида otpauth://totp/Issuer:account?secret=...
+// Отсутствующие алгоритм, количество цифр и период заменяются значениями по умолчанию
+func ParseTOTPURI(uri string) (TOTP, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil {
+		return TOTP{}, fmt.Errorf("%w: %v", ErrInvalidTOTP, err)
+	}
+	if u.Scheme != totpScheme || u.Host != "totp" {
+		return TOTP{}, fmt.Errorf("%w: expected otpauth://totp/ uri", ErrInvalidTOTP)
+	}
+
+	query := u.Query()
+	t := TOTP{
+		Account:   strings.TrimPrefix(u.Path, "/"),
+		Secret:    query.Get("secret"),
+		Algorithm: strings.ToUpper(query.Get("algorithm")),
+		Digits:    DefaultTOTPDigits,
+		Period:    DefaultTOTPPeriod,
+	}
+	if i := strings.Index(t.Account, ":"); i >= 0 {
+		t.Issuer, t.Account = t.Account[:i], strings.TrimSpace(t.Account[i+1:])
+	}
+	if issuer := query.Get("issuer"); issuer != "" {
+		t.Issuer = issuer
+	}
+	if t.Algorithm == "" {
+		t.Algorithm = TOTPAlgorithmSHA1
+	}
+	if digits := query.Get("digits"); digits != "" {
+		if t.Digits, err = strconv.Atoi(digits); err != nil {
+			return TOTP{}, fmt.Errorf("%w: invalid digits %q", ErrInvalidTOTP, digits)
+		}
+	}
+	if period := query.Get("period"); period != "" {
+		if t.Period, err = strconv.Atoi(period); err != nil {
+			return TOTP{}, fmt.Errorf("%w: invalid period %q", ErrInvalidTOTP, period)
+		}
+	}
+	if err = t.Validate(); err != nil {
+		return TOTP{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP_Type(t *testing.T) {
+	assert.Equal(t, secretTypeTOTP, TOTP{}.Type())
+}
+
+func TestTOTP_String(t *testing.T) {
+	secret := TOTP{Issuer: "GitHub", Account: "gopher", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30}
+	assert.Equal(t, "Issuer: GitHub, Account: gopher, Secret: ****, Algorithm: SHA1, Digits: 6, Period: 30", secret.String())
+}
+
+func TestTOTP_Fields(t *testing.T) {
+	secret := TOTP{Issuer: "GitHub", Account: "gopher", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30}
+	assert.Equal(t, []Field{
+		{Name: "issuer", Value: "GitHub"},
+		{Name: "account", Value: "gopher"},
+		{Name: "secret", Value: "JBSWY3DPEHPK3PXP", Hidden: true},
+		{Name: "algorithm", Value: "SHA1"},
+		{Name: "digits", Value: "6"},
+		{Name: "period", Value: "30"},
+	}, secret.Fields())
+}
+
+func TestTOTP_Code(t *testing.T) {
+	// Тестовые значения RFC 6238
+	for _, tc := range []struct {
+		algorithm string
+		secret    string
+		unix      int64
+		code      string
+	}{
+		{TOTPAlgorithmSHA1, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", 59, "94287082"},
+		{TOTPAlgorithmSHA1, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", 1111111109, "07081804"},
+		{TOTPAlgorithmSHA256, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA", 59, "46119246"},
+		{TOTPAlgorithmSHA512, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNA", 59, "90693936"},
+	} {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			secret := TOTP{Secret: tc.secret, Algorithm: tc.algorithm, Digits: 8, Period: 30}
+			code, remaining, err := secret.Code(time.Unix(tc.unix, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tc.code, code)
+			assert.Equal(t, time.Duration(30-tc.unix%30)*time.Second, remaining)
+		})
+	}
+
+	secret := TOTP{Secret: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq", Algorithm: TOTPAlgorithmSHA1, Digits: 6, Period: 30}
+	code, _, err := secret.Code(time.Unix(59, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+}
+
+func TestTOTP_Validate(t *testing.T) {
+	valid := TOTP{Secret: "JBSWY3DPEHPK3PXP", Algorithm: TOTPAlgorithmSHA1, Digits: 6, Period: 30}
+	assert.NoError(t, valid.Validate())
+
+	for name, modify := range map[string]func(*TOTP){
+		"Empty secret":   func(t *TOTP) { t.Secret = "" },
+		"Not base32":     func(t *TOTP) { t.Secret = "secret!" },
+		"MD5":            func(t *TOTP) { t.Algorithm = "MD5" },
+		"Too few digits": func(t *TOTP) { t.Digits = 4 },
+		"Zero period":    func(t *TOTP) { t.Period = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			secret := valid
+			modify(&secret)
+			assert.ErrorIs(t, secret.Validate(), ErrInvalidTOTP)
+		})
+	}
+}
+
+func TestParseTOTPURI(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		uri      string
+		expected TOTP
+		err      bool
+	}{
+		{
+			name: "Defaults",
+			uri:  "otpauth://totp/Example:alice@google.com?secret=JBSWY3DPEHPK3PXP&issuer=Example",
+			expected: TOTP{
+				Issuer: "Example", Account: "alice@google.com", Secret: "JBSWY3DPEHPK3PXP",
+				Algorithm: TOTPAlgorithmSHA1, Digits: 6, Period: 30,
+			},
+		},
+		{
+			name: "All parameters",
+			uri:  "otpauth://totp/ACME%20Co:john.doe@email.com?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ&issuer=ACME%20Co&algorithm=sha256&digits=8&period=60",
+			expected: TOTP{
+				Issuer: "ACME Co", Account: "john.doe@email.com", Secret: "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ",
+				Algorithm: TOTPAlgorithmSHA256, Digits: 8, Period: 60,
+			},
+		},
+		{
+			name: "Without issuer",
+			uri:  "otpauth://totp/gopher?secret=JBSWY3DPEHPK3PXP",
+			expected: TOTP{
+				Account: "gopher", Secret: "JBSWY3DPEHPK3PXP",
+				Algorithm: TOTPAlgorithmSHA1, Digits: 6, Period: 30,
+			},
+		},
+		{
+			name: "HOTP",
+			uri:  "otpauth://hotp/gopher?secret=JBSWY3DPEHPK3PXP&counter=1",
+			err:  true,
+		},
+		{
+			name: "Invalid digits",
+			uri:  "otpauth://totp/gopher?secret=JBSWY3DPEHPK3PXP&digits=six",
+			err:  true,
+		},
+		{
+			name: "Missing secret",
+			uri:  "otpauth://totp/gopher",
+			err:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			secret, err := ParseTOTPURI(tc.uri)
+			if tc.err {
+				assert.ErrorIs(t, err, ErrInvalidTOTP)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, secret)
+
+			parsed, err := ParseTOTPURI(secret.URI())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
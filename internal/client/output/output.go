@@ -0,0 +1,264 @@
+package output
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// Format формат вывода секретов
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatEnv   Format = "env"
+	FormatRaw   Format = "raw"
+)
+
+// maskedValue отображается в таблице вместо скрытых полей
+const maskedValue = "****"
+
+// Formats поддерживаемые форматы вывода
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML, FormatEnv, FormatRaw}
+
+var (
+	ErrUnknownFormat = errors.New("unknown output format")
+	ErrUnknownField  = errors.New("unknown secret field")
+)
+
+// ParseFormat проверяет название формата вывода
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q, expected one of %s", ErrUnknownFormat, name, formatNames())
+}
+
+func formatNames() string {
+	names := make([]string, 0, len(Formats))
+	for _, format := range Formats {
+		names = append(names, string(format))
+	}
+	return strings.Join(names, "|")
+}
+
+// Record расшифрованный секрет вместе с метаданными.
+// Сериализация в json и yaml является стабильной схемой вывода клиента и содержит значения всех полей.
+// Reveal отключает маскирование скрытых полей в табличном выводе
+type Record struct {
+	Name       string            `json:"name" yaml:"name"`
+	Type       string            `json:"type" yaml:"type"`
+	Version    string            `json:"version" yaml:"version"`
+	Owner      string            `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Permission string            `json:"permission,omitempty" yaml:"permission,omitempty"`
+	Fields     map[string]string `json:"fields" yaml:"fields"`
+	Reveal     bool              `json:"-" yaml:"-"`
+
+	secret models.Secret
+}
+
+// NewRecord создает запись для вывода секрета. Бинарные поля кодируются в base64
+func NewRecord(name, version string, secret models.Secret) Record {
+	record := Record{
+		Name:    name,
+		Type:    string(secret.Type()),
+		Version: version,
+		Fields:  make(map[string]string),
+		secret:  secret,
+	}
+	for _, field := range secret.Fields() {
+		record.Fields[field.Name] = encodeField(field)
+	}
+	return record
+}
+
+func encodeField(field models.Field) string {
+	if field.Binary {
+		return base64.StdEncoding.EncodeToString([]byte(field.Value))
+	}
+	return field.Value
+}
+
+// WriteRecord выводит один секрет в заданном формате
+func WriteRecord(w io.Writer, format Format, record Record) error {
+	switch format {
+	case FormatTable:
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "NAME\t%s\n", record.Name)
+		fmt.Fprintf(writer, "TYPE\t%s\n", record.Type)
+		fmt.Fprintf(writer, "VERSION\t%s\n", record.Version)
+		if record.Owner != "" {
+			fmt.Fprintf(writer, "OWNER\t%s\n", record.Owner)
+		}
+		if record.Permission != "" {
+			fmt.Fprintf(writer, "PERMISSION\t%s\n", record.Permission)
+		}
+		for _, field := range record.secret.Fields() {
+			fmt.Fprintf(writer, "%s\t%s\n", envName(field.Name), tableValue(field, record.Reveal))
+		}
+		return writer.Flush()
+	case FormatJSON:
+		return writeJSON(w, record)
+	case FormatYAML:
+		return writeYAML(w, record)
+	case FormatEnv:
+		return writeEnv(w, "", record)
+	case FormatRaw:
+		return writeRaw(w, record)
+	}
+	return fmt.Errorf("%w %q", ErrUnknownFormat, format)
+}
+
+// WriteList выводит список секретов в заданном формате
+func WriteList(w io.Writer, format Format, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+	switch format {
+	case FormatTable:
+		shared := false
+		for _, record := range records {
+			shared = shared || record.Owner != ""
+		}
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if shared {
+			fmt.Fprintln(writer, "NAME\tTYPE\tVERSION\tOWNER\tPERMISSION\tDATA")
+		} else {
+			fmt.Fprintln(writer, "NAME\tTYPE\tVERSION\tDATA")
+		}
+		for _, r := range records {
+			data := listData(r)
+			if shared {
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Name, r.Type, r.Version, r.Owner, r.Permission, data)
+			} else {
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", r.Name, r.Type, r.Version, data)
+			}
+		}
+		return writer.Flush()
+	case FormatJSON:
+		return writeJSON(w, records)
+	case FormatYAML:
+		return writeYAML(w, records)
+	case FormatEnv:
+		for _, record := range records {
+			if err := writeEnv(w, envName(record.Name)+"_", record); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatRaw:
+		for _, record := range records {
+			if err := writeRaw(w, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrUnknownFormat, format)
+}
+
+// WriteField выводит значение одного поля секрета без форматирования.
+// Бинарные данные выводятся как есть, без перевода строки
+func WriteField(w io.Writer, record Record, name string) error {
+	fields := record.secret.Fields()
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.Name == name {
+			return writeValue(w, field)
+		}
+		names = append(names, field.Name)
+	}
+	return fmt.Errorf("%w %q, secret %s has fields: %s", ErrUnknownField, name, record.Name, strings.Join(names, ", "))
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func writeEnv(w io.Writer, prefix string, record Record) error {
+	for _, field := range record.secret.Fields() {
+		if _, err := fmt.Fprintf(w, "%s%s=%s\n", prefix, envName(field.Name), shellQuote(encodeField(field))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRaw(w io.Writer, record Record) error {
+	for _, field := range record.secret.Fields() {
+		if err := writeValue(w, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeValue(w io.Writer, field models.Field) error {
+	if field.Binary {
+		_, err := io.WriteString(w, field.Value)
+		return err
+	}
+	_, err := fmt.Fprintln(w, field.Value)
+	return err
+}
+
+// listData возвращает поля секрета одной строкой для колонки DATA; скрытые поля маскируются
+func listData(record Record) string {
+	fields := record.secret.Fields()
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, field.Name+": "+tableValue(field, record.Reveal))
+	}
+	return strings.Join(values, ", ")
+}
+
+func tableValue(field models.Field, reveal bool) string {
+	switch {
+	case field.Hidden && !reveal && field.Masked != "":
+		return field.Masked
+	case field.Hidden && !reveal && field.Value != "":
+		return maskedValue
+	case field.Binary:
+		return fmt.Sprintf("<%d bytes>", len(field.Value))
+	}
+	return strings.ReplaceAll(field.Value, "\n", `\n`)
+}
+
+// envName приводит имя к виду переменной окружения: GITHUB_TOKEN
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+// shellQuote заключает значение в одинарные кавычки для безопасного eval в shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,178 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("json")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = ParseFormat("xml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWriteRecord(t *testing.T) {
+	record := NewRecord("github", "v1", models.Credentials{Login: "user", Password: "it's secret"})
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "Table",
+			format: FormatTable,
+			want: "NAME      github\n" +
+				"TYPE      credentials\n" +
+				"VERSION   v1\n" +
+				"LOGIN     user\n" +
+				"PASSWORD  ****\n",
+		},
+		{
+			name:   "JSON",
+			format: FormatJSON,
+			want: `{
+  "name": "github",
+  "type": "credentials",
+  "version": "v1",
+  "fields": {
+    "login": "user",
+    "password": "it's secret"
+  }
+}
+`,
+		},
+		{
+			name:   "YAML",
+			format: FormatYAML,
+			want: `name: github
+type: credentials
+version: v1
+fields:
+  login: user
+  password: it's secret
+`,
+		},
+		{
+			name:   "Env",
+			format: FormatEnv,
+			want:   "LOGIN='user'\nPASSWORD='it'\\''s secret'\n",
+		},
+		{
+			name:   "Raw",
+			format: FormatRaw,
+			want:   "user\nit's secret\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteRecord(&buf, tt.format, record))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteRecord_HiddenFields(t *testing.T) {
+	card := models.Card{Number: "4111111111111111", ExpiryDate: "12/30", SecurityCode: "123"}
+	record := NewRecord("visa", "v1", card)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRecord(&buf, FormatTable, record))
+	assert.Equal(t, "NAME           visa\n"+
+		"TYPE           card\n"+
+		"VERSION        v1\n"+
+		"NUMBER         **** 1111\n"+
+		"EXPIRY_DATE    12/30\n"+
+		"SECURITY_CODE  ****\n"+
+		"HOLDER         \n", buf.String())
+
+	record.Reveal = true
+	buf.Reset()
+	require.NoError(t, WriteRecord(&buf, FormatTable, record))
+	assert.Contains(t, buf.String(), "NUMBER         4111111111111111\n")
+	assert.Contains(t, buf.String(), "SECURITY_CODE  123\n")
+
+	buf.Reset()
+	require.NoError(t, WriteRecord(&buf, FormatEnv, NewRecord("visa", "v1", card)))
+	assert.Contains(t, buf.String(), "NUMBER='4111111111111111'\n")
+}
+
+func TestWriteRecord_HiddenSecretFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret models.Secret
+		hidden []string
+		value  string
+	}{
+		{name: "Credentials", secret: models.Credentials{Login: "user", Password: "pass"}, hidden: []string{"PASSWORD"}, value: "pass"},
+		{name: "TOTP", secret: models.TOTP{Issuer: "GitHub", Secret: "JBSWY3DPEHPK3PXP", Digits: 6, Period: 30}, hidden: []string{"SECRET"}, value: "JBSWY3DPEHPK3PXP"},
+		{name: "SSHKey", secret: models.SSHKey{PublicKey: "ssh-ed25519 AAAA", PrivateKey: "private", Passphrase: "phrase"}, hidden: []string{"PRIVATE_KEY", "PASSPHRASE"}, value: "private"},
+		{name: "Text", secret: models.Text{Data: "data"}, hidden: []string{"DATA"}, value: "data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := NewRecord("name", "v1", tt.secret)
+
+			var buf bytes.Buffer
+			require.NoError(t, WriteRecord(&buf, FormatTable, record))
+			assert.NotContains(t, buf.String(), tt.value)
+			for _, header := range tt.hidden {
+				assert.Regexp(t, "(?m)^"+header+` +\*\*\*\*$`, buf.String())
+			}
+
+			record.Reveal = true
+			buf.Reset()
+			require.NoError(t, WriteRecord(&buf, FormatTable, record))
+			assert.Contains(t, buf.String(), tt.value)
+		})
+	}
+}
+
+func TestWriteList(t *testing.T) {
+	records := []Record{
+		NewRecord("github", "v1", models.Credentials{Login: "user", Password: "pass"}),
+		NewRecord("ssh-key", "v2", models.Bin{Data: []byte{0, 1, 2}}),
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteList(&buf, FormatJSON, nil))
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteList(&buf, FormatEnv, records))
+		assert.Equal(t, "GITHUB_LOGIN='user'\nGITHUB_PASSWORD='pass'\nSSH_KEY_DATA='AAEC'\n", buf.String())
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteList(&buf, FormatTable, records))
+		assert.Equal(t, "NAME     TYPE         VERSION  DATA\n"+
+			"github   credentials  v1       login: user, password: ****\n"+
+			"ssh-key  bin          v2       data: <3 bytes>\n", buf.String())
+	})
+}
+
+func TestWriteField(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteField(&buf, NewRecord("github", "v1", models.Credentials{Login: "user", Password: "pass"}), "password"))
+	assert.Equal(t, "pass\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteField(&buf, NewRecord("key", "v1", models.Bin{Data: []byte{0, 1}}), "data"))
+	assert.Equal(t, []byte{0, 1}, buf.Bytes())
+
+	err := WriteField(&buf, NewRecord("github", "v1", models.Credentials{}), "token")
+	assert.ErrorIs(t, err, ErrUnknownField)
+}
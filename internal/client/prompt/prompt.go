@@ -0,0 +1,145 @@
+// Package prompt запрашивает чувствительные значения без отображения на экране
+// и читает их из стандартного ввода, файла или файлового дескриптора
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const fdPrefix = "fd:"
+
+var (
+	// ErrMismatch введенные значения не совпадают
+	ErrMismatch = errors.New("values don't match")
+	// ErrEmpty введено пустое значение
+	ErrEmpty = errors.New("empty value")
+	// ErrInvalidSource недопустимый источник значения
+	ErrInvalidSource = errors.New("invalid value source")
+)
+
+// Prompter запрашивает значения у пользователя
+type Prompter struct {
+	in     *os.File
+	out    io.Writer
+	reader *bufio.Reader
+}
+
+// New создает Prompter, читающий значения из in и выводящий приглашения в out
+func New(in *os.File, out io.Writer) *Prompter {
+	return &Prompter{
+		in:     in,
+		out:    out,
+		reader: bufio.NewReader(in),
+	}
+}
+
+// Default Prompter стандартного ввода
+var Default = New(os.Stdin, os.Stderr)
+
+// IsTerminal сообщает, подключен ли ввод к терминалу
+func (p *Prompter) IsTerminal() bool {
+	return term.IsTerminal(int(p.in.Fd()))
+}
+
+// Secret запрашивает значение без отображения вводимых символов. При confirm значение
+// запрашивается повторно для подтверждения. Если ввод не является терминалом,
+// значение читается из очередной строки ввода без подтверждения
+func (p *Prompter) Secret(label string, confirm bool) (string, error) {
+	if !p.IsTerminal() {
+		return p.readLine()
+	}
+
+	value, err := p.readHidden(label + ": ")
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", ErrEmpty
+	}
+	if confirm {
+		repeated, err := p.readHidden("Repeat " + strings.ToLower(label[:1]) + label[1:] + ": ")
+		if err != nil {
+			return "", err
+		}
+		if repeated != value {
+			return "", ErrMismatch
+		}
+	}
+	return value, nil
+}
+
+// Confirm запрашивает подтверждение вида "label [y/N]: ". Пустой ответ означает defaultYes
+func (p *Prompter) Confirm(label string, defaultYes bool) (bool, error) {
+	hint := "[y/N]"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+	fmt.Fprintf(p.out, "%s %s: ", label, hint)
+	answer, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		return defaultYes, nil
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *Prompter) readHidden(prompt string) (string, error) {
+	fmt.Fprint(p.out, prompt)
+	value, err := term.ReadPassword(int(p.in.Fd()))
+	fmt.Fprintln(p.out)
+	return string(value), err
+}
+
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// ReadSource читает значение целиком из источника source: "-" - стандартный ввод,
+// "fd:N" - открытый файловый дескриптор N, иначе путь к файлу. Завершающий перевод строки отбрасывается
+func (p *Prompter) ReadSource(source string) (string, error) {
+	var data []byte
+	var err error
+	switch {
+	case source == "-":
+		data, err = io.ReadAll(p.reader)
+	case strings.HasPrefix(source, fdPrefix):
+		fd, convErr := strconv.ParseUint(strings.TrimPrefix(source, fdPrefix), 10, 32)
+		if convErr != nil {
+			return "", fmt.Errorf("%w %q", ErrInvalidSource, source)
+		}
+		file := os.NewFile(uintptr(fd), source)
+		if file == nil {
+			return "", fmt.Errorf("%w %q", ErrInvalidSource, source)
+		}
+		defer file.Close()
+		data, err = io.ReadAll(file)
+	default:
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	value := string(data)
+	if strings.HasSuffix(value, "\n") {
+		value = strings.TrimSuffix(strings.TrimSuffix(value, "\n"), "\r")
+	}
+	return value, nil
+}
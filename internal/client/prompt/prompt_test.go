@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPipePrompter(t *testing.T, input string) *Prompter {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Close() })
+
+	_, err = w.WriteString(input)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return New(r, &bytes.Buffer{})
+}
+
+func TestPrompter_Secret(t *testing.T) {
+	p := newPipePrompter(t, "first\r\nsecond")
+	assert.False(t, p.IsTerminal())
+
+	value, err := p.Secret("Password", true)
+	require.NoError(t, err)
+	assert.Equal(t, "first", value)
+
+	value, err = p.Secret("Card security code", false)
+	require.NoError(t, err)
+	assert.Equal(t, "second", value)
+
+	_, err = p.Secret("Password", false)
+	assert.Error(t, err)
+}
+
+func TestPrompter_Confirm(t *testing.T) {
+	p := newPipePrompter(t, "y\n\nno\n")
+
+	confirmed, err := p.Confirm("Save changes?", false)
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = p.Confirm("Save changes?", true)
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = p.Confirm("Save changes?", true)
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+}
+
+func TestPrompter_ReadSource(t *testing.T) {
+	t.Run("Stdin", func(t *testing.T) {
+		value, err := newPipePrompter(t, "multi\nline\n").ReadSource("-")
+		require.NoError(t, err)
+		assert.Equal(t, "multi\nline", value)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+		require.NoError(t, os.WriteFile(path, []byte("secret\r\n"), 0600))
+
+		value, err := newPipePrompter(t, "").ReadSource(path)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", value)
+	})
+
+	t.Run("File descriptor", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		_, err = w.WriteString("from fd")
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		value, err := newPipePrompter(t, "").ReadSource(fmt.Sprintf("fd:%d", r.Fd()))
+		// дескриптор уже закрыт ReadSource
+		_ = r.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "from fd", value)
+	})
+
+	t.Run("Invalid descriptor", func(t *testing.T) {
+		_, err := newPipePrompter(t, "").ReadSource("fd:stdin")
+		assert.ErrorIs(t, err, ErrInvalidSource)
+	})
+}
@@ -0,0 +1,42 @@
+// Package render заполняет шаблоны text/template значениями полей секретов
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/secretref"
+)
+
+// Render выполняет шаблон text. В шаблоне доступна функция {{ secret "name" "field" }};
+// поле можно опустить для секретов с единственным полем. Ошибка получения секрета
+// прерывает выполнение шаблона
+func Render(w io.Writer, name, text string, resolver *secretref.Resolver) error {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"secret": func(secret string, field ...string) (string, error) {
+				if len(field) > 1 {
+					return "", fmt.Errorf("secret %s: expected at most one field, got %d", secret, len(field))
+				}
+				ref := secretref.Reference{Name: secret}
+				if len(field) == 1 {
+					ref.Field = field[0]
+				}
+				return resolver.Resolve(ref)
+			},
+		}).
+		Parse(text)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, nil); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,60 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/secretref"
+)
+
+func TestRender(t *testing.T) {
+	resolver := secretref.NewResolver(func(name string) (models.Secret, error) {
+		switch name {
+		case "prod/db":
+			return models.Credentials{Login: "admin", Password: "qwerty"}, nil
+		case "motd":
+			return models.Text{Data: "hello"}, nil
+		}
+		return nil, errors.New("not found")
+	})
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Fields",
+			text: "user: {{ secret \"prod/db\" \"login\" }}\npassword: {{ secret \"prod/db\" \"password\" | printf \"%q\" }}\n",
+			want: "user: admin\npassword: \"qwerty\"\n",
+		},
+		{
+			name: "Single field",
+			text: `motd: {{ secret "motd" }}`,
+			want: "motd: hello",
+		},
+		{name: "Missing secret", text: `{{ secret "missing" "password" }}`, wantErr: true},
+		{name: "Missing field", text: `{{ secret "prod/db" "token" }}`, wantErr: true},
+		{name: "Too many arguments", text: `{{ secret "prod/db" "login" "password" }}`, wantErr: true},
+		{name: "Syntax error", text: `{{ secret "prod/db"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Render(&buf, "app.tmpl", tt.text, resolver)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, buf.String())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
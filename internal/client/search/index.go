@@ -0,0 +1,133 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/utils"
+)
+
+// Index локальный поисковый индекс, позволяющий не расшифровывать секреты,
+// версия которых не изменилась с момента индексации
+type Index struct {
+	documents map[string]Document
+}
+
+type encryptedIndex struct {
+	DataKey   []byte `json:"data_key"`
+	Documents []byte `json:"documents"`
+}
+
+// NewIndex создает пустой индекс
+func NewIndex() *Index {
+	return &Index{documents: make(map[string]Document)}
+}
+
+// ProfilePath возвращает путь к файлу индекса профиля в каталоге настроек пользователя
+func ProfilePath(profile string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gophkeeper", "index", profile+".idx"), nil
+}
+
+// Lookup возвращает документ секрета name, если в индексе сохранена версия version
+func (i *Index) Lookup(name, version string) (Document, bool) {
+	doc, ok := i.documents[name]
+	if !ok || doc.Version != version {
+		return Document{}, false
+	}
+	return doc, true
+}
+
+// Put добавляет или заменяет документ секрета
+func (i *Index) Put(doc Document) {
+	i.documents[doc.Name] = doc
+}
+
+// Retain удаляет из индекса документы секретов, отсутствующих в names
+func (i *Index) Retain(names []string) {
+	keep := make(map[string]bool, len(names))
+	for _, name := range names {
+		keep[name] = true
+	}
+	for name := range i.documents {
+		if !keep[name] {
+			delete(i.documents, name)
+		}
+	}
+}
+
+// LoadIndex читает индекс из файла path и расшифровывает его шифром мастер-ключа.
+// Если файл не существует, возвращается пустой индекс
+func LoadIndex(path string, blockCipher cipher.BlockCipher) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewIndex(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var encrypted encryptedIndex
+	if err = json.Unmarshal(data, &encrypted); err != nil {
+		return nil, err
+	}
+	dataKey, err := blockCipher.Decrypt(encrypted.DataKey)
+	if err != nil {
+		return nil, err
+	}
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := dataCipher.Decrypt(encrypted.Documents)
+	if err != nil {
+		return nil, err
+	}
+
+	index := NewIndex()
+	if err = json.Unmarshal(plaintext, &index.documents); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// Save шифрует индекс новым ключом данных, зашифрованным мастер-ключом,
+// и атомарно записывает его в файл, доступный только пользователю
+func (i *Index) Save(path string, blockCipher cipher.BlockCipher) error {
+	dataKey, err := gcm.NewKey()
+	if err != nil {
+		return err
+	}
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(i.documents)
+	if err != nil {
+		return err
+	}
+
+	var encrypted encryptedIndex
+	if encrypted.DataKey, err = blockCipher.Encrypt(dataKey); err != nil {
+		return err
+	}
+	if encrypted.Documents, err = dataCipher.Encrypt(plaintext); err != nil {
+		return err
+	}
+	data, err := json.Marshal(encrypted)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return utils.WritePrivateFile(path, data)
+}
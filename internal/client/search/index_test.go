@@ -0,0 +1,60 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+)
+
+func TestIndex(t *testing.T) {
+	index := NewIndex()
+	doc := NewDocument("github", "2", models.Credentials{Login: "gopher"})
+	index.Put(doc)
+	index.Put(NewDocument("todo", "1", models.Text{Data: "note"}))
+
+	found, ok := index.Lookup("github", "2")
+	assert.True(t, ok)
+	assert.Equal(t, doc, found)
+	_, ok = index.Lookup("github", "1")
+	assert.False(t, ok)
+
+	index.Retain([]string{"github"})
+	_, ok = index.Lookup("todo", "1")
+	assert.False(t, ok)
+}
+
+func TestIndexSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index", "default.idx")
+	blockCipher, err := gcm.New("11112222333344445555666677778888")
+	require.NoError(t, err)
+
+	index, err := LoadIndex(path, blockCipher)
+	require.NoError(t, err)
+	doc := NewDocument("github", "2", models.Credentials{Login: "gopher"})
+	index.Put(doc)
+	require.NoError(t, index.Save(path, blockCipher))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "gopher")
+
+	loaded, err := LoadIndex(path, blockCipher)
+	require.NoError(t, err)
+	found, ok := loaded.Lookup("github", "2")
+	assert.True(t, ok)
+	assert.Equal(t, doc, found)
+
+	otherCipher, err := gcm.New("88887777666655554444333322221111")
+	require.NoError(t, err)
+	_, err = LoadIndex(path, otherCipher)
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+// Package search реализует нечеткий поиск по названиям и расшифрованным полям секретов на клиенте
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// FieldName псевдополе результата, совпавшего по названию секрета
+const FieldName = "name"
+
+const (
+	// Множители релевантности совпадений по названию и по полям секрета
+	nameWeight  = 2
+	fieldWeight = 1
+
+	exactScore    = 1000
+	prefixScore   = 500
+	containsScore = 200
+
+	// maxFuzzyLength максимальная длина значения, для которого выполняется нечеткое сравнение.
+	// В длинных текстах совпадением считается только вхождение подстроки
+	maxFuzzyLength = 128
+)
+
+// searchableFields поля секретов, по которым выполняется поиск.
+// Пароли, номера карт, коды безопасности, ключи одноразовых паролей, SSH-ключи и вложения заметок в поиске не участвуют
+var searchableFields = map[string]bool{
+	"login":   true,
+	"holder":  true,
+	"data":    true,
+	"issuer":  true,
+	"account": true,
+	"comment": true,
+	"title":   true,
+	"body":    true,
+}
+
+// Document название и поля секрета, по которым выполняется поиск
+type Document struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Type    models.SecretType `json:"type"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// NewDocument создает документ секрета s с названием name и версией version.
+// У пользовательских секретов в поиске участвуют все поля, кроме скрытых
+func NewDocument(name, version string, s models.Secret) Document {
+	doc := Document{
+		Name:    name,
+		Version: version,
+		Type:    s.Type(),
+		Fields:  make(map[string]string),
+	}
+	_, custom := s.(models.Custom)
+	for _, field := range s.Fields() {
+		searchable := searchableFields[field.Name] || custom && !field.Hidden
+		if !field.Binary && searchable && field.Value != "" {
+			doc.Fields[field.Name] = field.Value
+		}
+	}
+	return doc
+}
+
+// Result найденный секрет. Field - название поля с наилучшим совпадением или FieldName
+type Result struct {
+	Name  string
+	Type  models.SecretType
+	Field string
+	Score int
+}
+
+// Find ищет query среди документов и возвращает не более limit результатов по убыванию релевантности.
+// Если limit не положителен, возвращаются все результаты
+func Find(query string, docs []Document, limit int) []Result {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	var results []Result
+	for _, doc := range docs {
+		result := Result{Name: doc.Name, Type: doc.Type}
+		if score, ok := match(query, doc.Name); ok {
+			result.Field, result.Score = FieldName, score*nameWeight
+		}
+		names := make([]string, 0, len(doc.Fields))
+		for name := range doc.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if score, ok := match(query, doc.Fields[name]); ok && score*fieldWeight > result.Score {
+				result.Field, result.Score = name, score*fieldWeight
+			}
+		}
+		if result.Field != "" {
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Name < results[j].Name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// match сравнивает query со значением value без учета регистра. Полное совпадение, совпадение
+// начала и вхождение подстроки ранжируются выше нечеткого совпадения символов по порядку
+func match(query, value string) (int, bool) {
+	lowerQuery, lowerValue := strings.ToLower(query), strings.ToLower(value)
+	switch {
+	case lowerValue == lowerQuery:
+		return exactScore, true
+	case strings.HasPrefix(lowerValue, lowerQuery):
+		return prefixScore, true
+	case strings.Contains(lowerValue, lowerQuery):
+		return containsScore, true
+	case len(value) > maxFuzzyLength:
+		return 0, false
+	}
+
+	matches := fuzzy.Find(query, []string{value})
+	if len(matches) == 0 {
+		return 0, false
+	}
+	// Оценка нечеткого совпадения ограничена снизу, чтобы любое совпадение имело положительный вес
+	score := matches[0].Score
+	if score < 1 {
+		score = 1
+	}
+	if score >= containsScore {
+		score = containsScore - 1
+	}
+	return score, true
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func testDocuments() []Document {
+	return []Document{
+		NewDocument("aws/prod", "1", models.Credentials{Login: "deploy", Password: "aws-secret"}),
+		NewDocument("github", "1", models.Credentials{Login: "gopher@example.com", Password: "qwerty"}),
+		NewDocument("visa", "1", models.Card{Number: "4111111111111111", Holder: "IVAN PETROV", SecurityCode: "123"}),
+		NewDocument("todo", "1", models.Text{Data: "Renew the AWS reserved instances before March"}),
+		NewDocument("backup", "1", models.Bin{Data: []byte("aws")}),
+		NewDocument("2fa", "1", models.TOTP{Issuer: "GitLab", Account: "root", Secret: "JBSWY3DPEHPK3PXP"}),
+	}
+}
+
+func TestNewDocument(t *testing.T) {
+	doc := NewDocument("visa", "3", models.Card{Number: "4111111111111111", Holder: "IVAN PETROV", SecurityCode: "123"})
+	assert.Equal(t, Document{
+		Name:    "visa",
+		Version: "3",
+		Type:    models.Card{}.Type(),
+		Fields:  map[string]string{"holder": "IVAN PETROV"},
+	}, doc)
+
+	doc = NewDocument("backup", "1", models.Bin{Data: []byte("data")})
+	assert.Empty(t, doc.Fields)
+
+	doc = NewDocument("db", "2", models.Custom{Entries: []models.CustomField{
+		{Name: "host", Kind: models.FieldKindURL, Value: "https://db.example.com"},
+		{Name: "password", Kind: models.FieldKindHidden, Value: "qwerty"},
+	}})
+	assert.Equal(t, map[string]string{"host": "https://db.example.com"}, doc.Fields)
+
+	doc = NewDocument("server", "1", models.Note{
+		Title:       "Server",
+		Body:        "See certificates",
+		Attachments: []models.Attachment{{Name: "ca.pem"}},
+	})
+	assert.Equal(t, map[string]string{"title": "Server", "body": "See certificates"}, doc.Fields)
+}
+
+func TestFind(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		query    string
+		expected []Result
+	}{
+		{
+			name:  "Name and text content",
+			query: "aws",
+			expected: []Result{
+				{Name: "aws/prod", Type: models.Credentials{}.Type(), Field: FieldName, Score: prefixScore * nameWeight},
+				{Name: "todo", Type: models.Text{}.Type(), Field: "data", Score: containsScore},
+			},
+		},
+		{
+			name:  "Login",
+			query: "gopher",
+			expected: []Result{
+				{Name: "github", Type: models.Credentials{}.Type(), Field: "login", Score: prefixScore},
+			},
+		},
+		{
+			name:  "Card holder",
+			query: "petrov",
+			expected: []Result{
+				{Name: "visa", Type: models.Card{}.Type(), Field: "holder", Score: containsScore},
+			},
+		},
+		{
+			name:  "TOTP issuer",
+			query: "gitlab",
+			expected: []Result{
+				{Name: "2fa", Type: models.TOTP{}.Type(), Field: "issuer", Score: exactScore},
+			},
+		},
+		{
+			name:  "Passwords are not searchable",
+			query: "qwerty",
+		},
+		{
+			name:  "Empty query",
+			query: " ",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Find(tc.query, testDocuments(), 0))
+		})
+	}
+}
+
+func TestFindFuzzy(t *testing.T) {
+	results := Find("gthb", testDocuments(), 0)
+	require.Len(t, results, 1)
+	assert.Equal(t, "github", results[0].Name)
+	assert.Equal(t, FieldName, results[0].Field)
+	assert.Less(t, results[0].Score, containsScore*nameWeight)
+}
+
+func TestFindLimit(t *testing.T) {
+	results := Find("o", testDocuments(), 2)
+	require.Len(t, results, 2)
+	assert.GreaterOrEqual(t, results[0].Score, results[1].Score)
+}
@@ -0,0 +1,302 @@
+// Package secretedit представляет секрет в виде документа YAML для редактирования,
+// проверяет отредактированный документ и сравнивает поля двух версий секрета
+package secretedit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+var (
+	// ErrNotEditable секреты этого типа не редактируются как текст
+	ErrNotEditable = errors.New("secret type can't be edited as text")
+	// ErrInvalidDocument документ не соответствует формату редактирования
+	ErrInvalidDocument = errors.New("invalid secret document")
+	// ErrTypeChanged в документе изменен тип секрета
+	ErrTypeChanged = errors.New("secret type can't be changed")
+	// ErrUnknownField поле отсутствует у секретов этого типа
+	ErrUnknownField = errors.New("unknown secret field")
+	// ErrReadOnlyField поле вычисляется или изменяется отдельной командой
+	ErrReadOnlyField = errors.New("field is read-only")
+)
+
+// maskedValue отображается в различиях вместо значений скрытых полей
+const maskedValue = "********"
+
+// document формат редактируемого документа. Поля хранятся узлом, чтобы сохранить их порядок
+type document struct {
+	Type   string    `yaml:"type"`
+	Fields yaml.Node `yaml:"fields"`
+}
+
+type field struct {
+	name  string
+	value string
+}
+
+// Marshal возвращает документ YAML с типом и полями секрета name.
+// Поля пользовательских секретов записываются вместе с типом: host:url
+func Marshal(name string, secret models.Secret) ([]byte, error) {
+	if secret.Type() == (models.Bin{}).Type() {
+		return nil, fmt.Errorf("%w: %s", ErrNotEditable, secret.Type())
+	}
+
+	fields := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range editableFields(secret) {
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.value}
+		if strings.Contains(f.value, "\n") {
+			value.Style = yaml.LiteralStyle
+		}
+		fields.Content = append(fields.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.name}, value)
+	}
+	root := &yaml.Node{
+		Kind: yaml.MappingNode,
+		HeadComment: fmt.Sprintf("Secret %s. Edit the field values and save the file to apply changes.\n"+
+			"Leave the file unchanged or empty it to cancel. Lines starting with # are ignored.", name),
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "type"},
+			{Kind: yaml.ScalarNode, Value: string(secret.Type())},
+			{Kind: yaml.ScalarNode, Value: "fields"},
+			fields,
+		},
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// editableFields возвращает поля секрета в том виде, в котором они записываются в документ
+func editableFields(secret models.Secret) []field {
+	if custom, ok := secret.(models.Custom); ok {
+		fields := make([]field, 0, len(custom.Entries))
+		for _, entry := range custom.Entries {
+			kind := entry.Kind
+			if kind == "" {
+				kind = models.FieldKindText
+			}
+			fields = append(fields, field{name: entry.Name + ":" + string(kind), value: entry.Value})
+		}
+		return fields
+	}
+
+	fields := make([]field, 0)
+	for _, f := range secret.Fields() {
+		fields = append(fields, field{name: f.Name, value: f.Value})
+	}
+	return fields
+}
+
+// Unmarshal разбирает отредактированный документ и возвращает новую версию секрета previous.
+// Значения проверяются по правилам типа секрета
+func Unmarshal(data []byte, previous models.Secret) (models.Secret, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	if doc.Type != string(previous.Type()) {
+		return nil, fmt.Errorf("%w: %s to %q", ErrTypeChanged, previous.Type(), doc.Type)
+	}
+	fields, err := parseFields(&doc.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	switch secret := previous.(type) {
+	case models.Custom:
+		return buildCustom(fields, secret)
+	case models.Bin:
+		return nil, fmt.Errorf("%w: %s", ErrNotEditable, secret.Type())
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.name] = f.value
+	}
+	known := make(map[string]bool)
+	for _, f := range previous.Fields() {
+		known[f.Name] = true
+	}
+	for _, f := range fields {
+		if !known[f.name] {
+			return nil, fmt.Errorf("%w %q for %s secret", ErrUnknownField, f.name, previous.Type())
+		}
+	}
+
+	switch secret := previous.(type) {
+	case models.Credentials:
+		return models.Credentials{Login: values["login"], Password: values["password"]}, nil
+	case models.Text:
+		return models.Text{Data: values["data"]}, nil
+	case models.Card:
+		return models.ParseCard(values["number"], values["expiry_date"], values["security_code"], values["holder"])
+	case models.TOTP:
+		return buildTOTP(values)
+	case models.SSHKey:
+		return buildSSHKey(values, secret)
+	case models.Note:
+		if values["attachments"] != fieldValue(secret, "attachments") {
+			return nil, fmt.Errorf("%w: attachments, use secret update note --attach or --detach", ErrReadOnlyField)
+		}
+		return models.Note{Title: values["title"], Body: values["body"], Attachments: secret.Attachments}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotEditable, previous.Type())
+}
+
+// parseFields возвращает поля документа в исходном порядке
+func parseFields(node *yaml.Node) ([]field, error) {
+	if node.Kind == 0 || node.Tag == "!!null" {
+		return nil, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: fields must be a mapping", ErrInvalidDocument)
+	}
+
+	fields := make([]field, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%w: value of field %s must be a string", ErrInvalidDocument, key.Value)
+		}
+		f := field{name: key.Value, value: value.Value}
+		if value.Tag == "!!null" {
+			f.value = ""
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func fieldValue(secret models.Secret, name string) string {
+	for _, f := range secret.Fields() {
+		if f.Name == name {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+func buildCustom(fields []field, previous models.Custom) (models.Secret, error) {
+	custom := models.Custom{Template: previous.Template}
+	for _, f := range fields {
+		entry := models.CustomField{Name: f.name, Kind: models.FieldKindText, Value: f.value}
+		if name, kind, ok := strings.Cut(f.name, ":"); ok {
+			parsed, err := models.ParseFieldKind(kind)
+			if err != nil {
+				return nil, err
+			}
+			entry.Name, entry.Kind = name, parsed
+		}
+		custom.Entries = append(custom.Entries, entry)
+	}
+	return custom, custom.Validate()
+}
+
+func buildTOTP(values map[string]string) (models.Secret, error) {
+	digits, err := strconv.Atoi(values["digits"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: digits must be a number", models.ErrInvalidTOTP)
+	}
+	period, err := strconv.Atoi(values["period"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: period must be a number", models.ErrInvalidTOTP)
+	}
+	t := models.TOTP{
+		Issuer:    values["issuer"],
+		Account:   values["account"],
+		Secret:    values["secret"],
+		Algorithm: values["algorithm"],
+		Digits:    digits,
+		Period:    period,
+	}
+	return t, t.Validate()
+}
+
+// buildSSHKey разбирает закрытый ключ заново, открытый ключ вычисляется по закрытому
+func buildSSHKey(values map[string]string, previous models.SSHKey) (models.Secret, error) {
+	key, err := models.ParseSSHKey([]byte(values["private_key"]), values["passphrase"], values["comment"])
+	if err != nil {
+		return nil, err
+	}
+	if values["public_key"] != previous.PublicKey && values["public_key"] != key.PublicKey {
+		return nil, fmt.Errorf("%w: public_key is derived from private_key", ErrReadOnlyField)
+	}
+	// Пустой комментарий в документе не заменяется комментарием из файла ключа
+	if values["comment"] == "" && key.Comment != "" {
+		return key.WithComment("")
+	}
+	return key, nil
+}
+
+// Change изменение поля секрета. Пустое Old означает добавленное поле, пустое New - удаленное
+type Change struct {
+	Field  string
+	Old    string
+	New    string
+	Hidden bool
+}
+
+// String возвращает строку различий: "+ поле: значение", "- поле: значение" или "~ поле: старое -> новое".
+// Значения скрытых полей маскируются
+func (c Change) String() string {
+	oldValue, newValue := displayValue(c.Old), displayValue(c.New)
+	if c.Hidden {
+		oldValue, newValue = maskedValue, maskedValue
+	}
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("+ %s: %s", c.Field, newValue)
+	case c.New == "":
+		return fmt.Sprintf("- %s: %s", c.Field, oldValue)
+	case c.Hidden:
+		return fmt.Sprintf("~ %s: %s (changed)", c.Field, maskedValue)
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.Field, oldValue, newValue)
+}
+
+func displayValue(value string) string {
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+// Diff возвращает изменения полей секрета в порядке полей новой версии, затем удаленные поля
+func Diff(previous, current models.Secret) []Change {
+	oldFields := make(map[string]models.Field)
+	for _, f := range previous.Fields() {
+		oldFields[f.Name] = f
+	}
+
+	var changes []Change
+	seen := make(map[string]bool)
+	for _, f := range current.Fields() {
+		seen[f.Name] = true
+		old := oldFields[f.Name]
+		if old.Value != f.Value {
+			changes = append(changes, Change{
+				Field:  f.Name,
+				Old:    old.Value,
+				New:    f.Value,
+				Hidden: old.Hidden || f.Hidden,
+			})
+		}
+	}
+	for _, f := range previous.Fields() {
+		if !seen[f.Name] && f.Value != "" {
+			changes = append(changes, Change{Field: f.Name, Old: f.Value, Hidden: f.Hidden})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,131 @@
+package secretedit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal("github", models.Credentials{Login: "user", Password: "123"})
+	require.NoError(t, err)
+	assert.Equal(t, "# Secret github. Edit the field values and save the file to apply changes.\n"+
+		"# Leave the file unchanged or empty it to cancel. Lines starting with # are ignored.\n"+
+		"type: credentials\n"+
+		"fields:\n"+
+		"  login: user\n"+
+		"  password: \"123\"\n", string(data))
+
+	_, err = Marshal("backup", models.Bin{Data: []byte("data")})
+	assert.ErrorIs(t, err, ErrNotEditable)
+}
+
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	key, err := models.GenerateSSHKey(models.SSHKeyTypeEd25519, 0, "gopher@example.com")
+	require.NoError(t, err)
+
+	secrets := []models.Secret{
+		models.Credentials{Login: "user", Password: "yes"},
+		models.Text{Data: "line 1\nline 2\n"},
+		models.Card{Number: "4111111111111111", ExpiryDate: "12/30", SecurityCode: "123", Holder: "IVAN"},
+		models.TOTP{Issuer: "GitHub", Account: "user", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30},
+		key,
+		models.Custom{Template: "db", Entries: []models.CustomField{
+			{Name: "host", Kind: models.FieldKindURL, Value: "https://db.example.com"},
+			{Name: "password", Kind: models.FieldKindHidden, Value: "qwerty"},
+		}},
+		models.Note{Title: "Server", Body: "# Notes\n\n- item\n", Attachments: []models.Attachment{{ID: "1", Name: "ca.pem"}}},
+	}
+	for _, secret := range secrets {
+		t.Run(string(secret.Type()), func(t *testing.T) {
+			data, err := Marshal("name", secret)
+			require.NoError(t, err)
+
+			edited, err := Unmarshal(data, secret)
+			require.NoError(t, err)
+			assert.Equal(t, secret, edited)
+			assert.Empty(t, Diff(secret, edited))
+		})
+	}
+}
+
+func TestUnmarshal_Edit(t *testing.T) {
+	previous := models.Custom{Entries: []models.CustomField{
+		{Name: "host", Kind: models.FieldKindURL, Value: "https://db.example.com"},
+		{Name: "user", Kind: models.FieldKindText, Value: "admin"},
+	}}
+	edited, err := Unmarshal([]byte(`
+type: custom
+fields:
+  user: root
+  password:hidden: secret
+`), previous)
+	require.NoError(t, err)
+	assert.Equal(t, models.Custom{Entries: []models.CustomField{
+		{Name: "user", Kind: models.FieldKindText, Value: "root"},
+		{Name: "password", Kind: models.FieldKindHidden, Value: "secret"},
+	}}, edited)
+
+	card, err := Unmarshal([]byte("type: card\nfields:\n  number: 4111 1111 1111 1111\n  expiry_date: 1/2031\n"), models.Card{})
+	require.NoError(t, err)
+	assert.Equal(t, models.Card{Number: "4111111111111111", ExpiryDate: "01/31"}, card)
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	note := models.Note{Title: "Server", Attachments: []models.Attachment{{ID: "1", Name: "ca.pem"}}}
+	tests := []struct {
+		name     string
+		document string
+		previous models.Secret
+		err      error
+	}{
+		{name: "Syntax", document: "type: [", previous: models.Text{}, err: ErrInvalidDocument},
+		{name: "Type changed", document: "type: text\nfields:\n  data: x\n", previous: models.Credentials{}, err: ErrTypeChanged},
+		{name: "Unknown field", document: "type: text\nfields:\n  body: x\n", previous: models.Text{}, err: ErrUnknownField},
+		{name: "Nested value", document: "type: text\nfields:\n  data: [x]\n", previous: models.Text{}, err: ErrInvalidDocument},
+		{name: "Invalid card", document: "type: card\nfields:\n  number: \"4111111111111112\"\n  expiry_date: 12/30\n", previous: models.Card{}, err: models.ErrInvalidCard},
+		{name: "Invalid custom field", document: "type: custom\nfields:\n  host:url: db\n", previous: models.Custom{}, err: models.ErrInvalidCustomField},
+		{name: "Attachments", document: "type: note\nfields:\n  title: Server\n  attachments: key.pem\n", previous: note, err: ErrReadOnlyField},
+		{name: "Binary", document: "type: bin\n", previous: models.Bin{}, err: ErrNotEditable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Unmarshal([]byte(tt.document), tt.previous)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	previous := models.Custom{Entries: []models.CustomField{
+		{Name: "host", Kind: models.FieldKindText, Value: "db1"},
+		{Name: "token", Kind: models.FieldKindHidden, Value: "old"},
+		{Name: "port", Kind: models.FieldKindText, Value: "5432"},
+	}}
+	current := models.Custom{Entries: []models.CustomField{
+		{Name: "host", Kind: models.FieldKindText, Value: "db2"},
+		{Name: "token", Kind: models.FieldKindHidden, Value: "new"},
+		{Name: "user", Kind: models.FieldKindText, Value: "admin"},
+	}}
+
+	changes := Diff(previous, current)
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	assert.Equal(t, []string{
+		"~ host: db1 -> db2",
+		"~ token: ******** (changed)",
+		"+ user: admin",
+		"- port: 5432",
+	}, lines)
+
+	changes = Diff(models.Credentials{Login: "user", Password: "old"}, models.Credentials{Login: "user", Password: "new"})
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Hidden)
+	assert.False(t, strings.Contains(changes[0].String(), "new"))
+}
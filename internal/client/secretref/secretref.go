@@ -0,0 +1,158 @@
+// Package secretref разбирает ссылки на поля секретов вида name#field и получает их значения
+package secretref
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+var (
+	// ErrInvalidReference недопустимая ссылка на секрет
+	ErrInvalidReference = errors.New("invalid secret reference")
+	// ErrInvalidMapping недопустимое сопоставление переменной окружения и секрета
+	ErrInvalidMapping = errors.New("invalid env mapping")
+	// ErrFieldNotFound поле отсутствует в секрете
+	ErrFieldNotFound = errors.New("secret field not found")
+	// ErrFieldRequired у секрета несколько полей, и поле в ссылке не указано
+	ErrFieldRequired = errors.New("secret has several fields, field name required")
+	// ErrBinaryValue значение поля содержит нулевые байты
+	ErrBinaryValue = errors.New("binary field value can't be used")
+)
+
+var variableRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Reference ссылка на поле секрета. Пустое поле допустимо для секретов с единственным полем
+type Reference struct {
+	Name  string
+	Field string
+}
+
+// Parse разбирает ссылку вида name#field или name
+func Parse(s string) (Reference, error) {
+	name, field := s, ""
+	if i := strings.LastIndex(s, "#"); i >= 0 {
+		name, field = s[:i], s[i+1:]
+		if field == "" {
+			return Reference{}, fmt.Errorf("%w %q: empty field", ErrInvalidReference, s)
+		}
+	}
+	if name == "" {
+		return Reference{}, fmt.Errorf("%w %q: empty secret name", ErrInvalidReference, s)
+	}
+	return Reference{Name: name, Field: field}, nil
+}
+
+// String возвращает ссылку в виде name#field
+func (r Reference) String() string {
+	if r.Field == "" {
+		return r.Name
+	}
+	return r.Name + "#" + r.Field
+}
+
+// Mapping сопоставление переменной окружения и поля секрета
+type Mapping struct {
+	Variable  string
+	Reference Reference
+}
+
+// ParseMapping разбирает сопоставление вида VARIABLE=name#field
+func ParseMapping(s string) (Mapping, error) {
+	variable, ref, ok := strings.Cut(s, "=")
+	if !ok {
+		return Mapping{}, fmt.Errorf("%w %q: expected VARIABLE=name#field", ErrInvalidMapping, s)
+	}
+	variable = strings.TrimSpace(variable)
+	if !variableRegexp.MatchString(variable) {
+		return Mapping{}, fmt.Errorf("%w %q: invalid variable name", ErrInvalidMapping, s)
+	}
+	reference, err := Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return Mapping{}, err
+	}
+	return Mapping{Variable: variable, Reference: reference}, nil
+}
+
+// ReadMappings читает сопоставления, по одному в строке. Пустые строки и строки,
+// начинающиеся с #, пропускаются
+func ReadMappings(r io.Reader) ([]Mapping, error) {
+	var mappings []Mapping
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		mapping, err := ParseMapping(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// FieldValue возвращает значение поля секрета
+func FieldValue(secret models.Secret, name string) (models.Field, error) {
+	fields := secret.Fields()
+	if name == "" {
+		if len(fields) != 1 {
+			return models.Field{}, ErrFieldRequired
+		}
+		return fields[0], nil
+	}
+	for _, field := range fields {
+		if field.Name == name {
+			return field, nil
+		}
+	}
+	return models.Field{}, fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+}
+
+// FetchFunc получает и расшифровывает секрет по названию
+type FetchFunc func(name string) (models.Secret, error)
+
+// Resolver получает значения полей секретов, запрашивая каждый секрет один раз
+type Resolver struct {
+	fetch   FetchFunc
+	secrets map[string]models.Secret
+}
+
+// NewResolver создает Resolver
+func NewResolver(fetch FetchFunc) *Resolver {
+	return &Resolver{
+		fetch:   fetch,
+		secrets: make(map[string]models.Secret),
+	}
+}
+
+// Resolve возвращает значение поля секрета по ссылке.
+// Значения с нулевыми байтами отклоняются, так как не могут быть переданы в переменных окружения
+func (r *Resolver) Resolve(ref Reference) (string, error) {
+	secret, ok := r.secrets[ref.Name]
+	if !ok {
+		var err error
+		if secret, err = r.fetch(ref.Name); err != nil {
+			return "", fmt.Errorf("secret %s: %w", ref.Name, err)
+		}
+		r.secrets[ref.Name] = secret
+	}
+
+	field, err := FieldValue(secret, ref.Field)
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", ref, err)
+	}
+	if strings.IndexByte(field.Value, 0) >= 0 {
+		return "", fmt.Errorf("secret %s: %w", ref, ErrBinaryValue)
+	}
+	return field.Value, nil
+}
@@ -0,0 +1,98 @@
+package secretref
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    Reference
+		wantErr bool
+	}{
+		{ref: "prod/db#password", want: Reference{Name: "prod/db", Field: "password"}},
+		{ref: "notes", want: Reference{Name: "notes"}},
+		{ref: "a#b#login", want: Reference{Name: "a#b", Field: "login"}},
+		{ref: "#password", wantErr: true},
+		{ref: "prod/db#", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := Parse(tt.ref)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidReference)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ref, got.String())
+		})
+	}
+}
+
+func TestReadMappings(t *testing.T) {
+	mappings, err := ReadMappings(strings.NewReader(`
+# database
+DB_USER = prod/db#login
+DB_PASSWORD=prod/db#password
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Mapping{
+		{Variable: "DB_USER", Reference: Reference{Name: "prod/db", Field: "login"}},
+		{Variable: "DB_PASSWORD", Reference: Reference{Name: "prod/db", Field: "password"}},
+	}, mappings)
+
+	_, err = ReadMappings(strings.NewReader("DB_USER prod/db#login"))
+	assert.ErrorIs(t, err, ErrInvalidMapping)
+
+	_, err = ReadMappings(strings.NewReader("1DB=prod/db#login"))
+	assert.ErrorIs(t, err, ErrInvalidMapping)
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	calls := 0
+	resolver := NewResolver(func(name string) (models.Secret, error) {
+		calls++
+		switch name {
+		case "prod/db":
+			return models.Credentials{Login: "admin", Password: "qwerty"}, nil
+		case "motd":
+			return models.Text{Data: "hello"}, nil
+		case "key":
+			return models.Bin{Data: []byte{0, 1}}, nil
+		}
+		return nil, errors.New("not found")
+	})
+
+	value, err := resolver.Resolve(Reference{Name: "prod/db", Field: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, "qwerty", value)
+
+	value, err = resolver.Resolve(Reference{Name: "prod/db", Field: "login"})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", value)
+	assert.Equal(t, 1, calls)
+
+	value, err = resolver.Resolve(Reference{Name: "motd"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", value)
+
+	_, err = resolver.Resolve(Reference{Name: "prod/db"})
+	assert.ErrorIs(t, err, ErrFieldRequired)
+
+	_, err = resolver.Resolve(Reference{Name: "prod/db", Field: "token"})
+	assert.ErrorIs(t, err, ErrFieldNotFound)
+
+	_, err = resolver.Resolve(Reference{Name: "key"})
+	assert.ErrorIs(t, err, ErrBinaryValue)
+
+	_, err = resolver.Resolve(Reference{Name: "missing"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+// Package sshagent реализует сервер протокола ssh-agent, выдающий SSH-ключи из хранилища.
+// Ключи хранятся только в памяти процесса и не записываются на диск
+package sshagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	clientagent "github.com/go-developer-ya-practicum/gophkeeper/internal/client/agent"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// ErrReadOnly агент не принимает ключи от клиентов
+var ErrReadOnly = errors.New("keys are managed by gophkeeper")
+
+// DefaultSocketPath возвращает путь к сокету ssh-agent профиля profile рядом с сокетом агента клиента
+func DefaultSocketPath(profile string) string {
+	return filepath.Join(filepath.Dir(clientagent.DefaultSocketPath(profile)), "ssh-"+profile+".sock")
+}
+
+// Key SSH-ключ из хранилища
+type Key struct {
+	Name   string
+	SSHKey models.SSHKey
+}
+
+// keyring хранилище ключей, которое клиенты ssh-agent могут только использовать для подписи
+type keyring struct {
+	agent.Agent
+}
+
+// NewKeyring создает агент с ключами keys. Комментарием ключа служит его комментарий
+// или, если он пуст, название секрета. Добавление и удаление ключей клиентами запрещено
+func NewKeyring(keys []Key) (agent.Agent, error) {
+	ring := agent.NewKeyring()
+	for _, key := range keys {
+		raw, err := key.SSHKey.RawPrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("ssh key %s: %w", key.Name, err)
+		}
+		comment := key.SSHKey.Comment
+		if comment == "" {
+			comment = key.Name
+		}
+		if err = ring.Add(agent.AddedKey{PrivateKey: raw, Comment: comment}); err != nil {
+			return nil, fmt.Errorf("ssh key %s: %w", key.Name, err)
+		}
+	}
+	return keyring{ring}, nil
+}
+
+// Add запрещает добавление ключей
+func (k keyring) Add(agent.AddedKey) error {
+	return ErrReadOnly
+}
+
+// Remove запрещает удаление ключей
+func (k keyring) Remove(ssh.PublicKey) error {
+	return ErrReadOnly
+}
+
+// RemoveAll запрещает удаление ключей
+func (k keyring) RemoveAll() error {
+	return ErrReadOnly
+}
+
+// Serve обслуживает подключения клиентов ssh-agent до отмены контекста.
+// При отмене контекста открытые подключения закрываются
+func Serve(ctx context.Context, listener net.Listener, keys agent.Agent) error {
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	log.Info().Msgf("SSH agent listening on %s", listener.Addr())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(2)
+		connCtx, closeConn := context.WithCancel(ctx)
+		go func() {
+			defer wg.Done()
+			<-connCtx.Done()
+			_ = conn.Close()
+		}()
+		go func() {
+			defer wg.Done()
+			defer closeConn()
+			if err := agent.ServeAgent(keys, conn); err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				log.Debug().Err(err).Msg("SSH agent connection closed")
+			}
+		}()
+	}
+}
@@ -0,0 +1,85 @@
+package sshagent
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	clientagent "github.com/go-developer-ya-practicum/gophkeeper/internal/client/agent"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+func TestServe(t *testing.T) {
+	withComment, err := models.GenerateSSHKey(models.SSHKeyTypeEd25519, 0, "gopher@example.com")
+	require.NoError(t, err)
+	withoutComment, err := models.GenerateSSHKey(models.SSHKeyTypeEd25519, 0, "")
+	require.NoError(t, err)
+
+	keys, err := NewKeyring([]Key{
+		{Name: "github", SSHKey: withComment},
+		{Name: "deploy", SSHKey: withoutComment},
+	})
+	require.NoError(t, err)
+
+	dir, err := os.MkdirTemp("", "gophkeeper")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "ssh.sock")
+	listener, err := clientagent.Listen(socketPath)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, listener, keys)
+	}()
+
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	client := agent.NewClient(conn)
+
+	list, err := client.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	comments := []string{list[0].Comment, list[1].Comment}
+	assert.ElementsMatch(t, []string{"gopher@example.com", "deploy"}, comments)
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(withComment.PublicKey))
+	require.NoError(t, err)
+	data := make([]byte, 32)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	signature, err := client.Sign(publicKey, data)
+	require.NoError(t, err)
+	assert.NoError(t, publicKey.Verify(data, signature))
+
+	extra, err := models.GenerateSSHKey(models.SSHKeyTypeEd25519, 0, "")
+	require.NoError(t, err)
+	raw, err := extra.RawPrivateKey()
+	require.NoError(t, err)
+	assert.Error(t, client.Add(agent.AddedKey{PrivateKey: raw}))
+	assert.Error(t, client.Remove(publicKey))
+	assert.Error(t, client.RemoveAll())
+	list, err = client.List()
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	cancel()
+	require.NoError(t, <-done)
+	_, err = client.List()
+	assert.Error(t, err)
+}
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring([]Key{{Name: "broken", SSHKey: models.SSHKey{PrivateKey: "broken"}}})
+	assert.ErrorIs(t, err, models.ErrInvalidSSHKey)
+}
@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// errEmptyName не указано название секрета
+var errEmptyName = errors.New("secret name is required")
+
+// formField поле формы. Hidden означает, что значение маскируется при вводе и просмотре
+type formField struct {
+	name   string
+	label  string
+	hidden bool
+}
+
+// formSpec форма создания и редактирования секрета определенного типа.
+// build создает секрет по значениям полей, previous - редактируемый секрет или nil.
+// readOnly поля секрета, которые отображаются при просмотре, но не редактируются в форме
+type formSpec struct {
+	secretType models.SecretType
+	fields     []formField
+	readOnly   []formField
+	build      func(values map[string]string, previous models.Secret) (models.Secret, error)
+}
+
+// formSpecs формы секретов в порядке выбора типа нового секрета
+var formSpecs = []formSpec{
+	{
+		secretType: models.Credentials{}.Type(),
+		fields: []formField{
+			{name: "login", label: "Login"},
+			{name: "password", label: "Password", hidden: true},
+		},
+		build: func(values map[string]string, _ models.Secret) (models.Secret, error) {
+			return models.Credentials{Login: values["login"], Password: values["password"]}, nil
+		},
+	},
+	{
+		secretType: models.Card{}.Type(),
+		fields: []formField{
+			{name: "number", label: "Number", hidden: true},
+			{name: "expiry_date", label: "Expiry date"},
+			{name: "security_code", label: "Security code", hidden: true},
+			{name: "holder", label: "Holder"},
+		},
+		build: func(values map[string]string, _ models.Secret) (models.Secret, error) {
+			return models.ParseCard(values["number"], values["expiry_date"], values["security_code"], values["holder"])
+		},
+	},
+	{
+		secretType: models.Text{}.Type(),
+		fields: []formField{
+			{name: "data", label: "Text", hidden: true},
+		},
+		build: func(values map[string]string, _ models.Secret) (models.Secret, error) {
+			return models.Text{Data: values["data"]}, nil
+		},
+	},
+	{
+		secretType: models.TOTP{}.Type(),
+		fields: []formField{
+			{name: "issuer", label: "Issuer"},
+			{name: "account", label: "Account"},
+			{name: "secret", label: "Secret", hidden: true},
+			{name: "algorithm", label: "Algorithm"},
+			{name: "digits", label: "Digits"},
+			{name: "period", label: "Period"},
+		},
+		build: func(values map[string]string, _ models.Secret) (models.Secret, error) {
+			t := models.TOTP{
+				Issuer:    values["issuer"],
+				Account:   values["account"],
+				Secret:    values["secret"],
+				Algorithm: strings.ToUpper(values["algorithm"]),
+				Digits:    models.DefaultTOTPDigits,
+				Period:    models.DefaultTOTPPeriod,
+			}
+			if t.Algorithm == "" {
+				t.Algorithm = models.TOTPAlgorithmSHA1
+			}
+			var err error
+			if values["digits"] != "" {
+				if t.Digits, err = strconv.Atoi(values["digits"]); err != nil {
+					return nil, fmt.Errorf("invalid digits: %w", err)
+				}
+			}
+			if values["period"] != "" {
+				if t.Period, err = strconv.Atoi(values["period"]); err != nil {
+					return nil, fmt.Errorf("invalid period: %w", err)
+				}
+			}
+			return t, t.Validate()
+		},
+	},
+	{
+		secretType: models.SSHKey{}.Type(),
+		fields: []formField{
+			{name: "file", label: "Private key file, empty to generate"},
+			{name: "key_passphrase", label: "Key file passphrase", hidden: true},
+			{name: "comment", label: "Comment"},
+		},
+		readOnly: []formField{
+			{name: "public_key", label: "Public key"},
+			{name: "private_key", label: "Private key", hidden: true},
+			{name: "passphrase", label: "Passphrase", hidden: true},
+		},
+		build: func(values map[string]string, previous models.Secret) (models.Secret, error) {
+			if values["file"] != "" {
+				data, err := os.ReadFile(values["file"])
+				if err != nil {
+					return nil, err
+				}
+				return models.ParseSSHKey(data, values["key_passphrase"], values["comment"])
+			}
+			if key, ok := previous.(models.SSHKey); ok {
+				return key.WithComment(values["comment"])
+			}
+			return models.GenerateSSHKey(models.SSHKeyTypeEd25519, 0, values["comment"])
+		},
+	},
+	{
+		secretType: models.Bin{}.Type(),
+		fields: []formField{
+			{name: "file", label: "File path"},
+		},
+		build: func(values map[string]string, previous models.Secret) (models.Secret, error) {
+			if values["file"] == "" {
+				if bin, ok := previous.(models.Bin); ok {
+					return bin, nil
+				}
+				return nil, errors.New("file path is required")
+			}
+			data, err := os.ReadFile(values["file"])
+			if err != nil {
+				return nil, err
+			}
+			return models.Bin{Data: data}, nil
+		},
+	},
+}
+
+// findFormSpec возвращает форму секретов типа t
+func findFormSpec(t models.SecretType) (formSpec, bool) {
+	for _, spec := range formSpecs {
+		if spec.secretType == t {
+			return spec, true
+		}
+	}
+	return formSpec{}, false
+}
+
+// form состояние формы создания или редактирования секрета.
+// При создании первым полем вводится название секрета
+type form struct {
+	spec     formSpec
+	name     string
+	previous models.Secret
+	labels   []string
+	inputs   []textinput.Model
+	focus    int
+	revealed bool
+}
+
+// newForm создает форму нового секрета, если previous равен nil, иначе форму редактирования секрета name
+func newForm(spec formSpec, name string, previous models.Secret) *form {
+	f := &form{spec: spec, name: name, previous: previous}
+	if previous == nil {
+		f.addInput("Name", "", false)
+	}
+
+	values := make(map[string]string)
+	if previous != nil {
+		for _, field := range previous.Fields() {
+			if !field.Binary {
+				values[field.Name] = field.Value
+			}
+		}
+	}
+	for _, field := range spec.fields {
+		f.addInput(field.label, values[field.name], field.hidden)
+	}
+	f.setFocus(0)
+	return f
+}
+
+func (f *form) addInput(label, value string, hidden bool) {
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetValue(value)
+	if hidden {
+		input.EchoMode = textinput.EchoPassword
+	}
+	f.labels = append(f.labels, label)
+	f.inputs = append(f.inputs, input)
+}
+
+func (f *form) creating() bool {
+	return f.previous == nil
+}
+
+// setFocus переводит ввод на поле i
+func (f *form) setFocus(i int) tea.Cmd {
+	f.inputs[f.focus].Blur()
+	f.focus = (i + len(f.inputs)) % len(f.inputs)
+	return f.inputs[f.focus].Focus()
+}
+
+// toggleReveal переключает отображение скрытых полей
+func (f *form) toggleReveal() {
+	f.revealed = !f.revealed
+	offset := len(f.inputs) - len(f.spec.fields)
+	for i, field := range f.spec.fields {
+		if !field.hidden {
+			continue
+		}
+		if f.revealed {
+			f.inputs[offset+i].EchoMode = textinput.EchoNormal
+		} else {
+			f.inputs[offset+i].EchoMode = textinput.EchoPassword
+		}
+	}
+}
+
+// update передает сообщение полю ввода в фокусе
+func (f *form) update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return cmd
+}
+
+// result возвращает название и секрет, заданные в форме
+func (f *form) result() (string, models.Secret, error) {
+	name := f.name
+	inputs := f.inputs
+	if f.creating() {
+		name = strings.TrimSpace(inputs[0].Value())
+		inputs = inputs[1:]
+	}
+	if name == "" {
+		return "", nil, errEmptyName
+	}
+
+	values := make(map[string]string, len(f.spec.fields))
+	for i, field := range f.spec.fields {
+		values[field.name] = inputs[i].Value()
+	}
+	secret, err := f.spec.build(values, f.previous)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, secret, nil
+}
+
+func (f *form) view() string {
+	var b strings.Builder
+	if f.creating() {
+		fmt.Fprintf(&b, "New %s secret\n\n", f.spec.secretType)
+	} else {
+		fmt.Fprintf(&b, "Edit %s secret %s\n\n", f.spec.secretType, f.name)
+	}
+	for i, input := range f.inputs {
+		label := labelStyle.Render(f.labels[i] + ":")
+		if i == f.focus {
+			label = selectedStyle.Render(f.labels[i] + ":")
+		}
+		fmt.Fprintf(&b, "%s %s\n", label, input.View())
+	}
+	return b.String()
+}
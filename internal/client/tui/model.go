@@ -0,0 +1,562 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const maskedValue = "••••••••"
+
+// screen экран интерфейса
+type screen int
+
+const (
+	screenList screen = iota
+	screenDetail
+	screenSelectType
+	screenForm
+	screenConfirmDelete
+)
+
+// syncState состояние синхронизации с сервером
+type syncState int
+
+const (
+	stateSyncing syncState = iota
+	stateSynced
+	stateFailed
+)
+
+type syncedMsg struct {
+	snapshot *Snapshot
+	err      error
+}
+
+type savedMsg struct {
+	name string
+	err  error
+}
+
+type deletedMsg struct {
+	name string
+	err  error
+}
+
+// Model состояние терминального интерфейса
+type Model struct {
+	backend Backend
+	now     func() time.Time
+
+	items     []Item
+	filtered  []int
+	cursor    int
+	filter    textinput.Model
+	filtering bool
+
+	screen     screen
+	previous   screen
+	current    Item
+	revealed   bool
+	typeCursor int
+	form       *form
+
+	state         syncState
+	syncedAt      time.Time
+	syncErr       error
+	serverVersion string
+
+	message      string
+	messageError bool
+
+	width  int
+	height int
+}
+
+// New создает интерфейс, работающий с хранилищем через backend
+func New(backend Backend) Model {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter"
+	return Model{
+		backend: backend,
+		now:     time.Now,
+		filter:  filter,
+		state:   stateSyncing,
+	}
+}
+
+// Init запускает первую синхронизацию
+func (m Model) Init() tea.Cmd {
+	return m.sync()
+}
+
+func (m Model) sync() tea.Cmd {
+	backend := m.backend
+	return func() tea.Msg {
+		snapshot, err := backend.Sync()
+		return syncedMsg{snapshot: snapshot, err: err}
+	}
+}
+
+// Update обрабатывает события терминала и результаты операций с хранилищем
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case syncedMsg:
+		return m.handleSynced(msg), nil
+	case savedMsg:
+		if msg.err != nil {
+			m.setError(fmt.Errorf("failed to save secret: %w", msg.err))
+			return m, nil
+		}
+		m.setMessage(fmt.Sprintf("Secret %s saved", msg.name))
+		m.form = nil
+		m.current = Item{Name: msg.name}
+		m.screen = screenDetail
+		m.state = stateSyncing
+		return m, m.sync()
+	case deletedMsg:
+		if msg.err != nil {
+			m.setError(fmt.Errorf("failed to delete secret: %w", msg.err))
+			m.screen = m.previous
+			return m, nil
+		}
+		m.setMessage(fmt.Sprintf("Secret %s moved to trash", msg.name))
+		m.screen = screenList
+		m.state = stateSyncing
+		return m, m.sync()
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.screen {
+		case screenList:
+			return m.updateList(msg)
+		case screenDetail:
+			return m.updateDetail(msg)
+		case screenSelectType:
+			return m.updateSelectType(msg)
+		case screenForm:
+			return m.updateForm(msg)
+		case screenConfirmDelete:
+			return m.updateConfirmDelete(msg)
+		}
+	}
+
+	var cmd tea.Cmd
+	switch {
+	case m.screen == screenForm && m.form != nil:
+		cmd = m.form.update(msg)
+	case m.filtering:
+		m.filter, cmd = m.filter.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) handleSynced(msg syncedMsg) Model {
+	if msg.err != nil {
+		m.state = stateFailed
+		m.syncErr = msg.err
+		return m
+	}
+
+	m.state = stateSynced
+	m.syncErr = nil
+	m.syncedAt = m.now()
+	if msg.snapshot.ServerVersion != "" {
+		m.serverVersion = msg.snapshot.ServerVersion
+	}
+	m.items = msg.snapshot.Items
+	sort.Slice(m.items, func(i, j int) bool {
+		return m.items[i].Name < m.items[j].Name
+	})
+	m.applyFilter()
+
+	if m.screen == screenDetail {
+		if item, ok := m.findItem(m.current.Name); ok {
+			m.current = item
+		} else {
+			m.screen = screenList
+		}
+	}
+	return m
+}
+
+func (m Model) findItem(name string) (Item, bool) {
+	for _, item := range m.items {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// applyFilter отбирает секреты, названия которых содержат строку фильтра
+func (m *Model) applyFilter() {
+	query := strings.ToLower(m.filter.Value())
+	m.filtered = make([]int, 0, len(m.items))
+	for i, item := range m.items {
+		if strings.Contains(strings.ToLower(item.Name), query) {
+			m.filtered = append(m.filtered, i)
+		}
+	}
+	m.moveCursor(0)
+}
+
+func (m *Model) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// selected возвращает секрет под курсором
+func (m Model) selected() (Item, bool) {
+	if len(m.filtered) == 0 {
+		return Item{}, false
+	}
+	return m.items[m.filtered[m.cursor]], true
+}
+
+func (m *Model) setMessage(message string) {
+	m.message = message
+	m.messageError = false
+}
+
+func (m *Model) setError(err error) {
+	m.message = err.Error()
+	m.messageError = true
+}
+
+func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filtering {
+		switch msg.String() {
+		case "enter":
+			m.filtering = false
+			m.filter.Blur()
+		case "esc":
+			m.filtering = false
+			m.filter.Blur()
+			m.filter.SetValue("")
+			m.applyFilter()
+		case "up":
+			m.moveCursor(-1)
+		case "down":
+			m.moveCursor(1)
+		default:
+			var cmd tea.Cmd
+			m.filter, cmd = m.filter.Update(msg)
+			m.applyFilter()
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "/":
+		m.filtering = true
+		return m, m.filter.Focus()
+	case "esc":
+		m.filter.SetValue("")
+		m.applyFilter()
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "home", "g":
+		m.moveCursor(-len(m.filtered))
+	case "end", "G":
+		m.moveCursor(len(m.filtered))
+	case "enter":
+		if item, ok := m.selected(); ok {
+			m.current = item
+			m.revealed = false
+			m.screen = screenDetail
+		}
+	case "n":
+		m.typeCursor = 0
+		m.screen = screenSelectType
+	case "e":
+		if item, ok := m.selected(); ok {
+			m.current = item
+			return m.edit()
+		}
+	case "d":
+		if item, ok := m.selected(); ok {
+			m.current = item
+			m.previous = screenList
+			m.screen = screenConfirmDelete
+		}
+	case "r":
+		m.state = stateSyncing
+		return m, m.sync()
+	}
+	return m, nil
+}
+
+func (m Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "backspace":
+		m.screen = screenList
+	case "v", " ":
+		m.revealed = !m.revealed
+	case "e":
+		return m.edit()
+	case "d":
+		m.previous = screenDetail
+		m.screen = screenConfirmDelete
+	}
+	return m, nil
+}
+
+// edit открывает форму редактирования текущего секрета
+func (m Model) edit() (tea.Model, tea.Cmd) {
+	if m.current.Secret == nil {
+		return m, nil
+	}
+	spec, ok := findFormSpec(m.current.Secret.Type())
+	if !ok {
+		m.setError(fmt.Errorf("editing %s secrets is not supported", m.current.Secret.Type()))
+		return m, nil
+	}
+	m.form = newForm(spec, m.current.Name, m.current.Secret)
+	m.previous = m.screen
+	m.screen = screenForm
+	return m, textinput.Blink
+}
+
+func (m Model) updateSelectType(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.screen = screenList
+	case "up", "k":
+		if m.typeCursor > 0 {
+			m.typeCursor--
+		}
+	case "down", "j":
+		if m.typeCursor < len(formSpecs)-1 {
+			m.typeCursor++
+		}
+	case "enter":
+		m.form = newForm(formSpecs[m.typeCursor], "", nil)
+		m.previous = screenList
+		m.screen = screenForm
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.form = nil
+		m.screen = m.previous
+		return m, nil
+	case "tab", "down":
+		return m, m.form.setFocus(m.form.focus + 1)
+	case "shift+tab", "up":
+		return m, m.form.setFocus(m.form.focus - 1)
+	case "ctrl+r":
+		m.form.toggleReveal()
+		return m, nil
+	case "enter":
+		if m.form.focus < len(m.form.inputs)-1 {
+			return m, m.form.setFocus(m.form.focus + 1)
+		}
+		return m.save()
+	case "ctrl+s":
+		return m.save()
+	}
+	return m, m.form.update(msg)
+}
+
+// save сохраняет секрет из формы
+func (m Model) save() (tea.Model, tea.Cmd) {
+	name, secret, err := m.form.result()
+	if err != nil {
+		m.setError(err)
+		return m, nil
+	}
+
+	m.setMessage("Saving...")
+	backend := m.backend
+	if m.form.creating() {
+		return m, func() tea.Msg {
+			return savedMsg{name: name, err: backend.Create(name, secret)}
+		}
+	}
+	return m, func() tea.Msg {
+		return savedMsg{name: name, err: backend.Update(name, secret)}
+	}
+}
+
+func (m Model) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		name := m.current.Name
+		backend := m.backend
+		m.setMessage("Deleting...")
+		return m, func() tea.Msg {
+			return deletedMsg{name: name, err: backend.Delete(name)}
+		}
+	case "n", "N", "esc", "q":
+		m.screen = m.previous
+	}
+	return m, nil
+}
+
+// View отображает текущий экран, строку сообщений и строку состояния
+func (m Model) View() string {
+	var body, help string
+	switch m.screen {
+	case screenList:
+		body = m.listView()
+		help = "enter: open • /: filter • n: new • e: edit • d: delete • r: sync • q: quit"
+	case screenDetail:
+		body = m.detailView()
+		help = "v: reveal • e: edit • d: delete • esc: back"
+	case screenSelectType:
+		body = m.selectTypeView()
+		help = "enter: select • esc: cancel"
+	case screenForm:
+		body = m.form.view()
+		help = "tab: next field • ctrl+r: reveal • ctrl+s: save • esc: cancel"
+	case screenConfirmDelete:
+		body = fmt.Sprintf("Move secret %s to trash? (y/n)\n", m.current.Name)
+	}
+
+	footer := []string{helpStyle.Render(help)}
+	if m.message != "" {
+		if m.messageError {
+			footer = append(footer, errorStyle.Render(m.message))
+		} else {
+			footer = append(footer, m.message)
+		}
+	}
+	footer = append(footer, m.statusView())
+
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	for len(lines)+len(footer) < m.height {
+		lines = append(lines, "")
+	}
+	return strings.Join(append(lines, footer...), "\n")
+}
+
+func (m Model) listView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Secrets") + "\n")
+	if m.filtering || m.filter.Value() != "" {
+		b.WriteString(m.filter.View() + "\n")
+	}
+	b.WriteString("\n")
+
+	if len(m.filtered) == 0 {
+		if m.state == stateSyncing && len(m.items) == 0 {
+			b.WriteString(helpStyle.Render("Loading...") + "\n")
+		} else {
+			b.WriteString(helpStyle.Render("No secrets") + "\n")
+		}
+		return b.String()
+	}
+
+	// Строки списка, умещающиеся на экране вместе с заголовком и строками состояния
+	rows := len(m.filtered)
+	if m.height > 0 {
+		rows = m.height - 7
+		if rows < 1 {
+			rows = 1
+		}
+	}
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	for i := start; i < len(m.filtered) && i < start+rows; i++ {
+		item := m.items[m.filtered[i]]
+		line := fmt.Sprintf("%-40s %s", item.Name, secretType(item))
+		if i == m.cursor {
+			line = selectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+func (m Model) detailView() string {
+	var b strings.Builder
+	item := m.current
+	b.WriteString(titleStyle.Render(item.Name) + "\n\n")
+	fmt.Fprintf(&b, "%s %s\n", labelStyle.Render("Type:"), secretType(item))
+	fmt.Fprintf(&b, "%s %s\n", labelStyle.Render("Version:"), item.Version)
+	if item.Secret == nil {
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	for _, field := range item.Secret.Fields() {
+		value := field.Value
+		switch {
+		case field.Binary:
+			value = fmt.Sprintf("<%d bytes>", len(field.Value))
+		case !m.revealed && field.Hidden && field.Masked != "":
+			value = field.Masked
+		case !m.revealed && field.Hidden:
+			value = maskedValue
+		}
+		fmt.Fprintf(&b, "%s %s\n", labelStyle.Render(field.Name+":"), value)
+	}
+	return b.String()
+}
+
+func (m Model) selectTypeView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("New secret type") + "\n\n")
+	for i, spec := range formSpecs {
+		if i == m.typeCursor {
+			b.WriteString(selectedStyle.Render("> "+string(spec.secretType)) + "\n")
+		} else {
+			b.WriteString("  " + string(spec.secretType) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (m Model) statusView() string {
+	var state string
+	switch m.state {
+	case stateSyncing:
+		state = "syncing..."
+	case stateSynced:
+		state = "synced at " + m.syncedAt.Format("15:04:05")
+	case stateFailed:
+		state = "sync failed: " + m.syncErr.Error()
+	}
+
+	serverVersion := m.serverVersion
+	if serverVersion == "" {
+		serverVersion = "unknown"
+	}
+	status := fmt.Sprintf(" %d secrets │ %s │ server %s ", len(m.items), state, serverVersion)
+	return statusStyle.Width(m.width).Render(status)
+}
+
+func secretType(item Item) string {
+	if item.Secret == nil {
+		return ""
+	}
+	return string(item.Secret.Type())
+}
@@ -0,0 +1,326 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+type fakeBackend struct {
+	items   map[string]models.Secret
+	syncErr error
+	deleted []string
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{items: map[string]models.Secret{
+		"github": models.Credentials{Login: "gopher", Password: "qwerty"},
+		"visa":   models.Card{Number: "4111111111111111", ExpiryDate: "12/30", SecurityCode: "123", Holder: "GOPHER"},
+		"note":   models.Text{Data: "hello"},
+	}}
+}
+
+func (b *fakeBackend) Sync() (*Snapshot, error) {
+	if b.syncErr != nil {
+		return nil, b.syncErr
+	}
+	snapshot := &Snapshot{ServerVersion: "1.2.3"}
+	for name, secret := range b.items {
+		snapshot.Items = append(snapshot.Items, Item{Name: name, Version: "v1", Secret: secret})
+	}
+	return snapshot, nil
+}
+
+func (b *fakeBackend) Create(name string, s models.Secret) error {
+	if _, ok := b.items[name]; ok {
+		return errors.New("secret already exists")
+	}
+	b.items[name] = s
+	return nil
+}
+
+func (b *fakeBackend) Update(name string, s models.Secret) error {
+	b.items[name] = s
+	return nil
+}
+
+func (b *fakeBackend) Delete(name string) error {
+	delete(b.items, name)
+	b.deleted = append(b.deleted, name)
+	return nil
+}
+
+// send передает сообщение модели и выполняет возвращенные команды,
+// результаты которых относятся к операциям с хранилищем
+func send(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+	model, cmd := m.Update(msg)
+	m = model.(Model)
+	for cmd != nil {
+		switch result := cmd().(type) {
+		case syncedMsg, savedMsg, deletedMsg:
+			model, cmd = m.Update(result)
+			m = model.(Model)
+		default:
+			cmd = nil
+		}
+	}
+	return m
+}
+
+func keys(t *testing.T, m Model, input ...string) Model {
+	t.Helper()
+	for _, key := range input {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "ctrl+s":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlS}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m = send(t, m, msg)
+	}
+	return m
+}
+
+func start(t *testing.T, backend Backend) Model {
+	t.Helper()
+	m := New(backend)
+	m.now = func() time.Time {
+		return time.Date(2022, 8, 1, 12, 30, 0, 0, time.UTC)
+	}
+	return send(t, m, m.Init()())
+}
+
+func TestModelSync(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	require.Len(t, m.items, 3)
+	assert.Equal(t, "github", m.items[0].Name)
+	assert.Equal(t, "note", m.items[1].Name)
+	assert.Equal(t, "visa", m.items[2].Name)
+
+	view := m.View()
+	assert.Contains(t, view, "3 secrets")
+	assert.Contains(t, view, "synced at 12:30:00")
+	assert.Contains(t, view, "server 1.2.3")
+}
+
+func TestModelSyncFailed(t *testing.T) {
+	backend := newFakeBackend()
+	backend.syncErr = errors.New("connection refused")
+	m := start(t, backend)
+
+	assert.Equal(t, stateFailed, m.state)
+	assert.Contains(t, m.View(), "sync failed: connection refused")
+	assert.Contains(t, m.View(), "server unknown")
+}
+
+func TestModelFilter(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	m = keys(t, m, "/", "v", "I", "enter")
+	assert.False(t, m.filtering)
+	require.Len(t, m.filtered, 1)
+	item, ok := m.selected()
+	require.True(t, ok)
+	assert.Equal(t, "visa", item.Name)
+
+	m = keys(t, m, "esc")
+	assert.Len(t, m.filtered, 3)
+}
+
+func TestModelDetailReveal(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	m = keys(t, m, "enter")
+	require.Equal(t, screenDetail, m.screen)
+	view := m.View()
+	assert.Contains(t, view, "gopher")
+	assert.NotContains(t, view, "qwerty")
+	assert.Contains(t, view, maskedValue)
+
+	m = keys(t, m, "v")
+	assert.Contains(t, m.View(), "qwerty")
+
+	m = keys(t, m, "esc")
+	assert.Equal(t, screenList, m.screen)
+}
+
+func TestModelCreate(t *testing.T) {
+	backend := newFakeBackend()
+	m := start(t, backend)
+
+	m = keys(t, m, "n", "enter")
+	require.Equal(t, screenForm, m.screen)
+	m = keys(t, m, "gitlab", "tab", "root", "tab", "secret", "enter")
+
+	assert.Equal(t, models.Credentials{Login: "root", Password: "secret"}, backend.items["gitlab"])
+	assert.Equal(t, screenDetail, m.screen)
+	assert.Equal(t, "gitlab", m.current.Name)
+	assert.Len(t, m.items, 4)
+	assert.Equal(t, "Secret gitlab saved", m.message)
+}
+
+func TestModelCreateWithoutName(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	m = keys(t, m, "n", "down", "down", "enter", "ctrl+s")
+	assert.Equal(t, screenForm, m.screen)
+	assert.True(t, m.messageError)
+	assert.Equal(t, errEmptyName.Error(), m.message)
+}
+
+func TestModelEdit(t *testing.T) {
+	backend := newFakeBackend()
+	m := start(t, backend)
+
+	m = keys(t, m, "down", "e")
+	require.Equal(t, screenForm, m.screen)
+	require.Len(t, m.form.inputs, 1)
+	assert.Equal(t, "hello", m.form.inputs[0].Value())
+
+	m = keys(t, m, " world", "enter")
+	assert.Equal(t, models.Text{Data: "hello world"}, backend.items["note"])
+	assert.Equal(t, screenDetail, m.screen)
+	assert.Equal(t, models.Text{Data: "hello world"}, m.current.Secret)
+}
+
+func TestModelDelete(t *testing.T) {
+	backend := newFakeBackend()
+	m := start(t, backend)
+
+	m = keys(t, m, "d", "n")
+	assert.Equal(t, screenList, m.screen)
+	assert.Empty(t, backend.deleted)
+
+	m = keys(t, m, "enter", "d")
+	require.Equal(t, screenConfirmDelete, m.screen)
+	assert.Contains(t, m.View(), "Move secret github to trash?")
+
+	m = keys(t, m, "y")
+	assert.Equal(t, []string{"github"}, backend.deleted)
+	assert.Equal(t, screenList, m.screen)
+	assert.Len(t, m.items, 2)
+}
+
+func TestFormBin(t *testing.T) {
+	spec, ok := findFormSpec(models.Bin{}.Type())
+	require.True(t, ok)
+
+	f := newForm(spec, "", nil)
+	f.inputs[0].SetValue("file")
+	_, _, err := f.result()
+	assert.Error(t, err)
+
+	previous := models.Bin{Data: []byte{0, 1, 2}}
+	f = newForm(spec, "file", previous)
+	name, secret, err := f.result()
+	require.NoError(t, err)
+	assert.Equal(t, "file", name)
+	assert.Equal(t, previous, secret)
+}
+
+func TestDetailView_HiddenFields(t *testing.T) {
+	custom := models.Custom{Entries: []models.CustomField{
+		{Name: "host", Kind: models.FieldKindURL, Value: "https://db.example.com"},
+		{Name: "token", Kind: models.FieldKindHidden, Value: "qwerty"},
+	}}
+	card := models.Card{Number: "4111111111111111", ExpiryDate: "12/30", SecurityCode: "123"}
+	key := models.SSHKey{PublicKey: "ssh-ed25519 AAAA", PrivateKey: "private-key", Passphrase: "open-sesame"}
+
+	tests := []struct {
+		name    string
+		secret  models.Secret
+		visible []string
+		hidden  []string
+	}{
+		{name: "Credentials", secret: models.Credentials{Login: "user", Password: "s3cr3t"}, visible: []string{"user"}, hidden: []string{"s3cr3t"}},
+		{name: "Card", secret: card, visible: []string{"**** 1111", "12/30"}, hidden: []string{"4111111111111111", "123"}},
+		{name: "SSHKey", secret: key, visible: []string{"ssh-ed25519 AAAA"}, hidden: []string{"private-key", "open-sesame"}},
+		{name: "Custom", secret: custom, visible: []string{"https://db.example.com"}, hidden: []string{"qwerty"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{current: Item{Name: "name", Version: "v1", Secret: tt.secret}}
+			view := m.detailView()
+			for _, value := range tt.visible {
+				assert.Contains(t, view, value)
+			}
+			for _, value := range tt.hidden {
+				assert.NotContains(t, view, value)
+			}
+
+			m.revealed = true
+			view = m.detailView()
+			for _, value := range tt.hidden {
+				assert.Contains(t, view, value)
+			}
+		})
+	}
+}
+
+func TestFormTOTP(t *testing.T) {
+	spec, ok := findFormSpec(models.TOTP{}.Type())
+	require.True(t, ok)
+
+	f := newForm(spec, "", nil)
+	for i, value := range []string{"github", "GitHub", "gopher", "JBSWY3DPEHPK3PXP", "", "", ""} {
+		f.inputs[i].SetValue(value)
+	}
+	name, secret, err := f.result()
+	require.NoError(t, err)
+	assert.Equal(t, "github", name)
+	assert.Equal(t, models.TOTP{
+		Issuer:    "GitHub",
+		Account:   "gopher",
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: models.TOTPAlgorithmSHA1,
+		Digits:    models.DefaultTOTPDigits,
+		Period:    models.DefaultTOTPPeriod,
+	}, secret)
+
+	f.inputs[5].SetValue("4")
+	_, _, err = f.result()
+	assert.ErrorIs(t, err, models.ErrInvalidTOTP)
+}
+
+func TestFormSSHKey(t *testing.T) {
+	spec, ok := findFormSpec(models.SSHKey{}.Type())
+	require.True(t, ok)
+
+	f := newForm(spec, "", nil)
+	f.inputs[0].SetValue("github")
+	f.inputs[3].SetValue("gopher@example.com")
+	name, secret, err := f.result()
+	require.NoError(t, err)
+	assert.Equal(t, "github", name)
+	generated, ok := secret.(models.SSHKey)
+	require.True(t, ok)
+	assert.NoError(t, generated.Validate())
+	assert.Equal(t, "gopher@example.com", generated.Comment)
+
+	f = newForm(spec, "github", generated)
+	assert.Equal(t, "gopher@example.com", f.inputs[2].Value())
+	f.inputs[2].SetValue("laptop")
+	_, secret, err = f.result()
+	require.NoError(t, err)
+	edited := secret.(models.SSHKey)
+	assert.Equal(t, generated.PrivateKey, edited.PrivateKey)
+	assert.Equal(t, "laptop", edited.Comment)
+	assert.NoError(t, edited.Validate())
+}
@@ -0,0 +1,12 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	labelStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	statusStyle   = lipgloss.NewStyle().Reverse(true)
+)
@@ -0,0 +1,39 @@
+// Package tui реализует полноэкранный терминальный интерфейс для просмотра и редактирования хранилища
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/client/models"
+)
+
+// Item расшифрованный секрет хранилища
+type Item struct {
+	Name    string
+	Version string
+	Secret  models.Secret
+}
+
+// Snapshot содержимое хранилища на момент синхронизации
+type Snapshot struct {
+	Items         []Item
+	ServerVersion string
+}
+
+// Backend операции с хранилищем, которые выполняет интерфейс
+type Backend interface {
+	// Sync получает и расшифровывает секреты пользователя
+	Sync() (*Snapshot, error)
+	// Create шифрует и сохраняет новый секрет
+	Create(name string, s models.Secret) error
+	// Update обновляет содержимое секрета
+	Update(name string, s models.Secret) error
+	// Delete перемещает секрет в корзину
+	Delete(name string) error
+}
+
+// Run запускает интерфейс в альтернативном буфере терминала
+func Run(backend Backend) error {
+	_, err := tea.NewProgram(New(backend), tea.WithAltScreen()).Run()
+	return err
+}
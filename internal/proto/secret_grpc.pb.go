@@ -26,7 +26,26 @@ type SecretServiceClient interface {
 	CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*CreateSecretResponse, error)
 	UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*UpdateSecretResponse, error)
 	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
+	RenameSecret(ctx context.Context, in *RenameSecretRequest, opts ...grpc.CallOption) (*RenameSecretResponse, error)
+	CopySecret(ctx context.Context, in *CopySecretRequest, opts ...grpc.CallOption) (*CopySecretResponse, error)
 	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
+	ListDeletedSecrets(ctx context.Context, in *ListDeletedSecretsRequest, opts ...grpc.CallOption) (*ListDeletedSecretsResponse, error)
+	RestoreSecret(ctx context.Context, in *RestoreSecretRequest, opts ...grpc.CallOption) (*RestoreSecretResponse, error)
+	PurgeSecret(ctx context.Context, in *PurgeSecretRequest, opts ...grpc.CallOption) (*PurgeSecretResponse, error)
+	PutKeyPair(ctx context.Context, in *PutKeyPairRequest, opts ...grpc.CallOption) (*PutKeyPairResponse, error)
+	GetKeyPair(ctx context.Context, in *GetKeyPairRequest, opts ...grpc.CallOption) (*GetKeyPairResponse, error)
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	ShareSecret(ctx context.Context, in *ShareSecretRequest, opts ...grpc.CallOption) (*ShareSecretResponse, error)
+	UnshareSecret(ctx context.Context, in *UnshareSecretRequest, opts ...grpc.CallOption) (*UnshareSecretResponse, error)
+	ListSecretShares(ctx context.Context, in *ListSecretSharesRequest, opts ...grpc.CallOption) (*ListSecretSharesResponse, error)
+	ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error)
+	PutTemplate(ctx context.Context, in *PutTemplateRequest, opts ...grpc.CallOption) (*PutTemplateResponse, error)
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	PutBlob(ctx context.Context, in *PutBlobRequest, opts ...grpc.CallOption) (*PutBlobResponse, error)
+	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobResponse, error)
+	DeleteBlob(ctx context.Context, in *DeleteBlobRequest, opts ...grpc.CallOption) (*DeleteBlobResponse, error)
 }
 
 type secretServiceClient struct {
@@ -73,6 +92,24 @@ func (c *secretServiceClient) DeleteSecret(ctx context.Context, in *DeleteSecret
 	return out, nil
 }
 
+func (c *secretServiceClient) RenameSecret(ctx context.Context, in *RenameSecretRequest, opts ...grpc.CallOption) (*RenameSecretResponse, error) {
+	out := new(RenameSecretResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/RenameSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) CopySecret(ctx context.Context, in *CopySecretRequest, opts ...grpc.CallOption) (*CopySecretResponse, error) {
+	out := new(CopySecretResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/CopySecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *secretServiceClient) ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error) {
 	out := new(ListSecretsResponse)
 	err := c.cc.Invoke(ctx, "/proto.SecretService/ListSecrets", in, out, opts...)
@@ -82,6 +119,159 @@ func (c *secretServiceClient) ListSecrets(ctx context.Context, in *ListSecretsRe
 	return out, nil
 }
 
+func (c *secretServiceClient) ListDeletedSecrets(ctx context.Context, in *ListDeletedSecretsRequest, opts ...grpc.CallOption) (*ListDeletedSecretsResponse, error) {
+	out := new(ListDeletedSecretsResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/ListDeletedSecrets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) RestoreSecret(ctx context.Context, in *RestoreSecretRequest, opts ...grpc.CallOption) (*RestoreSecretResponse, error) {
+	out := new(RestoreSecretResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/RestoreSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) PurgeSecret(ctx context.Context, in *PurgeSecretRequest, opts ...grpc.CallOption) (*PurgeSecretResponse, error) {
+	out := new(PurgeSecretResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/PurgeSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) PutKeyPair(ctx context.Context, in *PutKeyPairRequest, opts ...grpc.CallOption) (*PutKeyPairResponse, error) {
+	out := new(PutKeyPairResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/PutKeyPair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetKeyPair(ctx context.Context, in *GetKeyPairRequest, opts ...grpc.CallOption) (*GetKeyPairResponse, error) {
+	out := new(GetKeyPairResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/GetKeyPair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ShareSecret(ctx context.Context, in *ShareSecretRequest, opts ...grpc.CallOption) (*ShareSecretResponse, error) {
+	out := new(ShareSecretResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/ShareSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) UnshareSecret(ctx context.Context, in *UnshareSecretRequest, opts ...grpc.CallOption) (*UnshareSecretResponse, error) {
+	out := new(UnshareSecretResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/UnshareSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSecretShares(ctx context.Context, in *ListSecretSharesRequest, opts ...grpc.CallOption) (*ListSecretSharesResponse, error) {
+	out := new(ListSecretSharesResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/ListSecretShares", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error) {
+	out := new(ListSharedWithMeResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/ListSharedWithMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) PutTemplate(ctx context.Context, in *PutTemplateRequest, opts ...grpc.CallOption) (*PutTemplateResponse, error) {
+	out := new(PutTemplateResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/PutTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error) {
+	out := new(GetTemplateResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/GetTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/ListTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/DeleteTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) PutBlob(ctx context.Context, in *PutBlobRequest, opts ...grpc.CallOption) (*PutBlobResponse, error) {
+	out := new(PutBlobResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/PutBlob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobResponse, error) {
+	out := new(GetBlobResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/GetBlob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) DeleteBlob(ctx context.Context, in *DeleteBlobRequest, opts ...grpc.CallOption) (*DeleteBlobResponse, error) {
+	out := new(DeleteBlobResponse)
+	err := c.cc.Invoke(ctx, "/proto.SecretService/DeleteBlob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SecretServiceServer is the server API for SecretService service.
 // All implementations must embed UnimplementedSecretServiceServer
 // for forward compatibility
@@ -90,7 +280,26 @@ type SecretServiceServer interface {
 	CreateSecret(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error)
 	UpdateSecret(context.Context, *UpdateSecretRequest) (*UpdateSecretResponse, error)
 	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
+	RenameSecret(context.Context, *RenameSecretRequest) (*RenameSecretResponse, error)
+	CopySecret(context.Context, *CopySecretRequest) (*CopySecretResponse, error)
 	ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
+	ListDeletedSecrets(context.Context, *ListDeletedSecretsRequest) (*ListDeletedSecretsResponse, error)
+	RestoreSecret(context.Context, *RestoreSecretRequest) (*RestoreSecretResponse, error)
+	PurgeSecret(context.Context, *PurgeSecretRequest) (*PurgeSecretResponse, error)
+	PutKeyPair(context.Context, *PutKeyPairRequest) (*PutKeyPairResponse, error)
+	GetKeyPair(context.Context, *GetKeyPairRequest) (*GetKeyPairResponse, error)
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	ShareSecret(context.Context, *ShareSecretRequest) (*ShareSecretResponse, error)
+	UnshareSecret(context.Context, *UnshareSecretRequest) (*UnshareSecretResponse, error)
+	ListSecretShares(context.Context, *ListSecretSharesRequest) (*ListSecretSharesResponse, error)
+	ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error)
+	PutTemplate(context.Context, *PutTemplateRequest) (*PutTemplateResponse, error)
+	GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	PutBlob(context.Context, *PutBlobRequest) (*PutBlobResponse, error)
+	GetBlob(context.Context, *GetBlobRequest) (*GetBlobResponse, error)
+	DeleteBlob(context.Context, *DeleteBlobRequest) (*DeleteBlobResponse, error)
 	mustEmbedUnimplementedSecretServiceServer()
 }
 
@@ -110,9 +319,66 @@ func (UnimplementedSecretServiceServer) UpdateSecret(context.Context, *UpdateSec
 func (UnimplementedSecretServiceServer) DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecret not implemented")
 }
+func (UnimplementedSecretServiceServer) RenameSecret(context.Context, *RenameSecretRequest) (*RenameSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) CopySecret(context.Context, *CopySecretRequest) (*CopySecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopySecret not implemented")
+}
 func (UnimplementedSecretServiceServer) ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
 }
+func (UnimplementedSecretServiceServer) ListDeletedSecrets(context.Context, *ListDeletedSecretsRequest) (*ListDeletedSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedSecrets not implemented")
+}
+func (UnimplementedSecretServiceServer) RestoreSecret(context.Context, *RestoreSecretRequest) (*RestoreSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) PurgeSecret(context.Context, *PurgeSecretRequest) (*PurgeSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) PutKeyPair(context.Context, *PutKeyPairRequest) (*PutKeyPairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutKeyPair not implemented")
+}
+func (UnimplementedSecretServiceServer) GetKeyPair(context.Context, *GetKeyPairRequest) (*GetKeyPairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyPair not implemented")
+}
+func (UnimplementedSecretServiceServer) GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedSecretServiceServer) ShareSecret(context.Context, *ShareSecretRequest) (*ShareSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) UnshareSecret(context.Context, *UnshareSecretRequest) (*UnshareSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) ListSecretShares(context.Context, *ListSecretSharesRequest) (*ListSecretSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecretShares not implemented")
+}
+func (UnimplementedSecretServiceServer) ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (UnimplementedSecretServiceServer) PutTemplate(context.Context, *PutTemplateRequest) (*PutTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutTemplate not implemented")
+}
+func (UnimplementedSecretServiceServer) GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedSecretServiceServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedSecretServiceServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedSecretServiceServer) PutBlob(context.Context, *PutBlobRequest) (*PutBlobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutBlob not implemented")
+}
+func (UnimplementedSecretServiceServer) GetBlob(context.Context, *GetBlobRequest) (*GetBlobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlob not implemented")
+}
+func (UnimplementedSecretServiceServer) DeleteBlob(context.Context, *DeleteBlobRequest) (*DeleteBlobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlob not implemented")
+}
 func (UnimplementedSecretServiceServer) mustEmbedUnimplementedSecretServiceServer() {}
 
 // UnsafeSecretServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -198,6 +464,42 @@ func _SecretService_DeleteSecret_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecretService_RenameSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).RenameSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/RenameSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).RenameSecret(ctx, req.(*RenameSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_CopySecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopySecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).CopySecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/CopySecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).CopySecret(ctx, req.(*CopySecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SecretService_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSecretsRequest)
 	if err := dec(in); err != nil {
@@ -216,6 +518,312 @@ func _SecretService_ListSecrets_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecretService_ListDeletedSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListDeletedSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/ListDeletedSecrets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListDeletedSecrets(ctx, req.(*ListDeletedSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_RestoreSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).RestoreSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/RestoreSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).RestoreSecret(ctx, req.(*RestoreSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_PurgeSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).PurgeSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/PurgeSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).PurgeSecret(ctx, req.(*PurgeSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_PutKeyPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutKeyPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).PutKeyPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/PutKeyPair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).PutKeyPair(ctx, req.(*PutKeyPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetKeyPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetKeyPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/GetKeyPair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetKeyPair(ctx, req.(*GetKeyPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ShareSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ShareSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/ShareSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ShareSecret(ctx, req.(*ShareSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_UnshareSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).UnshareSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/UnshareSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).UnshareSecret(ctx, req.(*UnshareSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSecretShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListSecretShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/ListSecretShares",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListSecretShares(ctx, req.(*ListSecretSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedWithMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/ListSharedWithMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListSharedWithMe(ctx, req.(*ListSharedWithMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_PutTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).PutTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/PutTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).PutTemplate(ctx, req.(*PutTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/GetTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetTemplate(ctx, req.(*GetTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/ListTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/DeleteTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_PutBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).PutBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/PutBlob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).PutBlob(ctx, req.(*PutBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/GetBlob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetBlob(ctx, req.(*GetBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_DeleteBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).DeleteBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SecretService/DeleteBlob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).DeleteBlob(ctx, req.(*DeleteBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SecretService_ServiceDesc is the grpc.ServiceDesc for SecretService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,10 +847,86 @@ var SecretService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSecret",
 			Handler:    _SecretService_DeleteSecret_Handler,
 		},
+		{
+			MethodName: "RenameSecret",
+			Handler:    _SecretService_RenameSecret_Handler,
+		},
+		{
+			MethodName: "CopySecret",
+			Handler:    _SecretService_CopySecret_Handler,
+		},
 		{
 			MethodName: "ListSecrets",
 			Handler:    _SecretService_ListSecrets_Handler,
 		},
+		{
+			MethodName: "ListDeletedSecrets",
+			Handler:    _SecretService_ListDeletedSecrets_Handler,
+		},
+		{
+			MethodName: "RestoreSecret",
+			Handler:    _SecretService_RestoreSecret_Handler,
+		},
+		{
+			MethodName: "PurgeSecret",
+			Handler:    _SecretService_PurgeSecret_Handler,
+		},
+		{
+			MethodName: "PutKeyPair",
+			Handler:    _SecretService_PutKeyPair_Handler,
+		},
+		{
+			MethodName: "GetKeyPair",
+			Handler:    _SecretService_GetKeyPair_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _SecretService_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "ShareSecret",
+			Handler:    _SecretService_ShareSecret_Handler,
+		},
+		{
+			MethodName: "UnshareSecret",
+			Handler:    _SecretService_UnshareSecret_Handler,
+		},
+		{
+			MethodName: "ListSecretShares",
+			Handler:    _SecretService_ListSecretShares_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _SecretService_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "PutTemplate",
+			Handler:    _SecretService_PutTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _SecretService_GetTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _SecretService_ListTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _SecretService_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "PutBlob",
+			Handler:    _SecretService_PutBlob_Handler,
+		},
+		{
+			MethodName: "GetBlob",
+			Handler:    _SecretService_GetBlob_Handler,
+		},
+		{
+			MethodName: "DeleteBlob",
+			Handler:    _SecretService_DeleteBlob_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "secret.proto",
@@ -4,10 +4,11 @@ import "time"
 
 // Config содержит настройки сервера
 type Config struct {
-	GRPC GRPCConfig    `mapstructure:"grpc"`
-	DB   StorageConfig `mapstructure:"db"`
-	Auth AuthConfig    `mapstructure:"auth"`
-	Hash HashConfig    `mapstructure:"hasher"`
+	GRPC  GRPCConfig    `mapstructure:"grpc"`
+	DB    StorageConfig `mapstructure:"db"`
+	Auth  AuthConfig    `mapstructure:"auth"`
+	Hash  HashConfig    `mapstructure:"hasher"`
+	Trash TrashConfig   `mapstructure:"trash"`
 }
 
 // GRPCConfig настройки GRPC
@@ -30,3 +31,9 @@ type AuthConfig struct {
 type HashConfig struct {
 	Key string `mapstructure:"key"`
 }
+
+// TrashConfig настройки корзины удаленных секретов
+type TrashConfig struct {
+	Retention       time.Duration `mapstructure:"retention"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+}
@@ -97,5 +97,6 @@ func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string
 		return nil, status.Error(codes.Internal, "failed to validate token")
 	}
 
-	return context.WithValue(ctx, ContextKeyUserID, payload.UserID), nil
+	ctx = context.WithValue(ctx, ContextKeyUserID, payload.UserID)
+	return context.WithValue(ctx, ContextKeySessionID, payload.Id), nil
 }
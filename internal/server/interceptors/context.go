@@ -5,4 +5,6 @@ type key int
 const (
 	// ContextKeyUserID ключ для добавления UserID в контекст при аутентификации
 	ContextKeyUserID key = iota
+	// ContextKeySessionID ключ для добавления идентификатора токена доступа в контекст при аутентификации
+	ContextKeySessionID
 )
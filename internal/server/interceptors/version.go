@@ -0,0 +1,51 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/version"
+)
+
+// VersionInterceptor серверный перехватчик, передающий клиенту версию сервера в заголовке ответа
+type VersionInterceptor struct {
+	header metadata.MD
+}
+
+// NewVersionInterceptor создает новый перехватчик VersionInterceptor
+func NewVersionInterceptor(serverVersion string) *VersionInterceptor {
+	return &VersionInterceptor{header: metadata.Pairs(version.MetadataKey, serverVersion)}
+}
+
+// Unary возвращает серверную функцию-перехватчик, добавляющую версию сервера к одиночным RPC запросам
+func (interceptor *VersionInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := grpc.SetHeader(ctx, interceptor.header); err != nil {
+			log.Warn().Err(err).Msg("Failed to set server version header")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream возвращает серверную функцию-перехватчик, добавляющую версию сервера к потоковым RPC запросам
+func (interceptor *VersionInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := stream.SetHeader(interceptor.header); err != nil {
+			log.Warn().Err(err).Msg("Failed to set server version header")
+		}
+		return handler(srv, stream)
+	}
+}
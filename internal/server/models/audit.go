@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Действия пользователей, фиксируемые в журнале аудита
+const (
+	AuditActionSignUp             = "sign_up"
+	AuditActionSignIn             = "sign_in"
+	AuditActionGetSecret          = "get_secret"
+	AuditActionCreateSecret       = "create_secret"
+	AuditActionUpdateSecret       = "update_secret"
+	AuditActionDeleteSecret       = "delete_secret"
+	AuditActionRenameSecret       = "rename_secret"
+	AuditActionCopySecret         = "copy_secret"
+	AuditActionListSecrets        = "list_secrets"
+	AuditActionListDeletedSecrets = "list_deleted_secrets"
+	AuditActionRestoreSecret      = "restore_secret"
+	AuditActionPurgeSecret        = "purge_secret"
+	AuditActionShareSecret        = "share_secret"
+	AuditActionUnshareSecret      = "unshare_secret"
+	AuditActionListSecretShares   = "list_secret_shares"
+	AuditActionListSharedSecrets  = "list_shared_secrets"
+	AuditActionPutTemplate        = "put_template"
+	AuditActionDeleteTemplate     = "delete_template"
+	AuditActionCreateOrganization = "create_organization"
+	AuditActionPutMember          = "put_member"
+	AuditActionRemoveMember       = "remove_member"
+	AuditActionCreateTeam         = "create_team"
+	AuditActionAddTeamMember      = "add_team_member"
+	AuditActionRemoveTeamMember   = "remove_team_member"
+	AuditActionCreateCollection   = "create_collection"
+	AuditActionGrantCollection    = "grant_collection"
+	AuditActionRevokeCollection   = "revoke_collection"
+	AuditActionPutCollectionKeys  = "put_collection_keys"
+	AuditActionPutBlob            = "put_blob"
+	AuditActionGetBlob            = "get_blob"
+	AuditActionDeleteBlob         = "delete_blob"
+)
+
+// AuditEvent запись журнала аудита о доступе к данным пользователя.
+// Target - объект действия, не являющийся секретом: шаблон, организация, участник или вложение
+type AuditEvent struct {
+	ID            int64
+	CreatedAt     time.Time
+	UserID        int
+	SessionID     string
+	Action        string
+	SecretName    string
+	SecretVersion string
+	Target        string
+	PeerAddress   string
+	ResultCode    uint32
+}
@@ -0,0 +1,12 @@
+package models
+
+import "github.com/google/uuid"
+
+// Blob вложение секрета пользователя. Содержимое шифруется на клиенте ключом,
+// который хранится в зашифрованном содержимом секрета
+type Blob struct {
+	ID         uuid.UUID
+	SecretName string
+	OwnerID    int
+	Content    []byte
+}
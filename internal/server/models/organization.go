@@ -0,0 +1,61 @@
+package models
+
+// Role роль пользователя в организации
+type Role string
+
+// Возможные роли пользователя в организации
+const (
+	RoleOwner    Role = "owner"
+	RoleAdmin    Role = "admin"
+	RoleMember   Role = "member"
+	RoleReadOnly Role = "read-only"
+)
+
+// CanManage возвращает true, если роль позволяет управлять участниками, командами и коллекциями
+func (r Role) CanManage() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// CanWrite возвращает true, если роль позволяет изменять секреты коллекций
+func (r Role) CanWrite() bool {
+	return r == RoleOwner || r == RoleAdmin || r == RoleMember
+}
+
+// Valid возвращает true для известных ролей
+func (r Role) Valid() bool {
+	return r == RoleOwner || r == RoleAdmin || r == RoleMember || r == RoleReadOnly
+}
+
+// Organization организация и роль в ней текущего пользователя
+type Organization struct {
+	ID   int
+	Name string
+	Role Role
+}
+
+// Member участник организации
+type Member struct {
+	Email     string
+	Role      Role
+	PublicKey []byte
+}
+
+// Team команда участников организации
+type Team struct {
+	Name    string
+	Members []string
+}
+
+// Collection коллекция секретов организации
+type Collection struct {
+	ID   int
+	Name string
+}
+
+// CollectionAccess права пользователя на коллекцию и ключ коллекции,
+// зашифрованный открытым ключом пользователя
+type CollectionAccess struct {
+	CollectionID int
+	Role         Role
+	WrappedKey   []byte
+}
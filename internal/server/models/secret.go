@@ -1,11 +1,18 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // Secret содержит секретные данные пользователя
 type Secret struct {
-	Name    string
-	Content []byte
-	Version uuid.UUID
-	OwnerID int
+	Name         string
+	Content      []byte
+	Version      uuid.UUID
+	DataKey      []byte
+	OwnerID      int
+	CollectionID int
+	DeletedAt    time.Time
 }
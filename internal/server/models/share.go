@@ -0,0 +1,38 @@
+package models
+
+import "github.com/google/uuid"
+
+// Permission права доступа получателя к чужому секрету
+type Permission string
+
+// Возможные права доступа к чужому секрету
+const (
+	PermissionRead      Permission = "read"
+	PermissionReadWrite Permission = "read-write"
+)
+
+// KeyPair пара ключей X25519 пользователя; закрытый ключ зашифрован мастер-ключом пользователя
+type KeyPair struct {
+	PublicKey           []byte
+	EncryptedPrivateKey []byte
+}
+
+// Share предоставление доступа к секрету владельца OwnerID пользователю RecipientEmail
+type Share struct {
+	SecretName     string
+	OwnerID        int
+	RecipientEmail string
+	WrappedKey     []byte
+	Permission     Permission
+}
+
+// SharedSecret секрет другого пользователя, доступный получателю RecipientID
+type SharedSecret struct {
+	Name        string
+	Content     []byte
+	Version     uuid.UUID
+	OwnerEmail  string
+	RecipientID int
+	WrappedKey  []byte
+	Permission  Permission
+}
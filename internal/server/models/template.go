@@ -0,0 +1,9 @@
+package models
+
+// Template шаблон секретов пользователя. Описание полей шифруется на клиенте
+type Template struct {
+	Name    string
+	Content []byte
+	DataKey []byte
+	OwnerID int
+}
@@ -2,38 +2,55 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/config"
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/services"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/version"
 )
 
-// Server сервер gRPC с сервисами аутентификации и хранения пользовательских данных
+// Server сервер gRPC с сервисами аутентификации, хранения пользовательских данных,
+// организаций и журнала аудита
 type Server struct {
 	AuthService   *services.AuthService
 	SecretService *services.SecretService
+	AuditService  *services.AuditService
+	OrgService    *services.OrganizationService
 	Address       string
+
+	TrashCleanupInterval time.Duration
 }
 
 // New создает новый Server с указанными настройками
 func New(cfg config.Config) *Server {
 	authService := services.NewAuthService(cfg)
 	secretService := services.NewSecretService(cfg)
+	auditService := services.NewAuditService(cfg)
+	orgService := services.NewOrganizationService(cfg)
 	return &Server{
 		AuthService:   authService,
 		SecretService: secretService,
+		AuditService:  auditService,
+		OrgService:    orgService,
 		Address:       cfg.GRPC.Address,
+
+		TrashCleanupInterval: cfg.Trash.CleanupInterval,
 	}
 }
 
-// Run функция запуска gRPC сервера с сервисами аутентификации и хранения пользовательских данных
+// Run функция запуска gRPC сервера с сервисами аутентификации, хранения пользовательских данных,
+// организаций и журнала аудита
 func (s *Server) Run(ctx context.Context) {
 	interceptor := interceptors.NewAuthInterceptor(s.AuthService.TokenManager)
+	versionInterceptor := interceptors.NewVersionInterceptor(version.Version())
+
+	go s.SecretService.RunTrashCleaner(ctx, s.TrashCleanupInterval)
 
 	services.NewServer(
 		s.Address,
-		services.WithServices(s.AuthService, s.SecretService),
-		services.WithUnaryInterceptors(interceptor.Unary()),
-		services.WithStreamInterceptors(interceptor.Stream()),
+		services.WithServices(s.AuthService, s.SecretService, s.AuditService, s.OrgService),
+		services.WithUnaryInterceptors(versionInterceptor.Unary(), interceptor.Unary()),
+		services.WithStreamInterceptors(versionInterceptor.Stream(), interceptor.Stream()),
 	).Run(ctx)
 }
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/config"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/pg"
+)
+
+// AuditService реализация proto.AuditServiceServer
+type AuditService struct {
+	AuditStorage storage.AuditStorage
+	pb.UnimplementedAuditServiceServer
+}
+
+var _ pb.AuditServiceServer = (*AuditService)(nil)
+var _ Service = (*AuditService)(nil)
+
+// NewAuditService создает новый сервис AuditService
+func NewAuditService(cfg config.Config) *AuditService {
+	auditStorage, err := pg.NewAuditStorage(cfg.DB.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create storage")
+	}
+
+	return &AuditService{AuditStorage: auditStorage}
+}
+
+// RegisterService функция регистрации сервиса AuditService на сервере gRPC
+func (srv *AuditService) RegisterService(s grpc.ServiceRegistrar) {
+	pb.RegisterAuditServiceServer(s, srv)
+}
+
+// ListAuditEvents возвращает записи журнала аудита пользователя за указанный промежуток времени
+func (srv *AuditService) ListAuditEvents(
+	ctx context.Context,
+	request *pb.ListAuditEventsRequest,
+) (*pb.ListAuditEventsResponse, error) {
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	from := time.Unix(0, 0)
+	if request.GetFrom() != nil {
+		from = request.GetFrom().AsTime()
+	}
+	to := time.Now()
+	if request.GetTo() != nil {
+		to = request.GetTo().AsTime()
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "empty time range")
+	}
+
+	events, err := srv.AuditStorage.ListEvents(ctx, userID, from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list audit events")
+	}
+
+	pbEvents := make([]*pb.AuditEvent, 0, len(events))
+	for _, event := range events {
+		pbEvents = append(pbEvents, &pb.AuditEvent{
+			CreatedAt:     timestamppb.New(event.CreatedAt),
+			SessionId:     event.SessionID,
+			Action:        event.Action,
+			SecretName:    event.SecretName,
+			SecretVersion: event.SecretVersion,
+			Target:        event.Target,
+			PeerAddress:   event.PeerAddress,
+			Code:          event.ResultCode,
+		})
+	}
+	return &pb.ListAuditEventsResponse{
+		Events: pbEvents,
+	}, nil
+}
+
+// auditTarget возвращает объект действия журнала аудита, составленный из непустых частей через "/"
+func auditTarget(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "/")
+}
+
+// writeAuditEvent дополняет событие данными о пользователе, сессии, адресе клиента
+// и результате выполнения запроса, после чего сохраняет его в журнал аудита
+func writeAuditEvent(ctx context.Context, auditStorage storage.AuditStorage, event *models.AuditEvent, err error) {
+	if auditStorage == nil {
+		return
+	}
+
+	if userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int); ok {
+		event.UserID = userID
+	}
+	if sessionID, ok := ctx.Value(interceptors.ContextKeySessionID).(string); ok {
+		event.SessionID = sessionID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event.PeerAddress = p.Addr.String()
+	}
+	event.ResultCode = uint32(status.Code(err))
+
+	if err = auditStorage.PutEvent(ctx, event); err != nil {
+		log.Error().Err(err).Msgf("Failed to write audit event %s", event.Action)
+	}
+}
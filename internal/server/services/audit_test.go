@@ -0,0 +1,382 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	clientInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/client/interceptors"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	serverInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	ms "github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/mock"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+	mt "github.com/go-developer-ya-practicum/gophkeeper/pkg/token/mock"
+)
+
+func newAuditClient(accessToken string) (pb.AuditServiceClient, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(clientInterceptors.NewAuthInterceptor(accessToken).Unary()),
+	}
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewAuditServiceClient(conn), nil
+}
+
+func TestAuditService_ListAuditEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditStorage := ms.NewMockAuditStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	auditService := &AuditService{
+		AuditStorage: auditStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(auditService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	from := time.Now().Add(-time.Hour).UTC()
+	to := time.Now().UTC()
+
+	t.Run("InvalidTimeRange", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newAuditClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+			From: timestamppb.New(to),
+			To:   timestamppb.New(from),
+		})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("StorageError", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		auditStorage.
+			EXPECT().
+			ListEvents(gomock.Any(), userID, from, to).
+			Return(nil, errors.New("some error"))
+
+		client, err := newAuditClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+			From: timestamppb.New(from),
+			To:   timestamppb.New(to),
+		})
+		checkErrorStatus(t, err, codes.Internal)
+	})
+	t.Run("SuccessfulListAuditEvents", func(t *testing.T) {
+		event := &models.AuditEvent{
+			ID:            1,
+			CreatedAt:     time.Now().UTC(),
+			UserID:        userID,
+			SessionID:     "SessionID",
+			Action:        models.AuditActionGetSecret,
+			SecretName:    "SecretName",
+			SecretVersion: uuid.NewString(),
+			Target:        "Target",
+			PeerAddress:   "127.0.0.1:5000",
+			ResultCode:    uint32(codes.NotFound),
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		auditStorage.
+			EXPECT().
+			ListEvents(gomock.Any(), userID, from, to).
+			Return([]*models.AuditEvent{event}, nil)
+
+		client, err := newAuditClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+			From: timestamppb.New(from),
+			To:   timestamppb.New(to),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Events, 1)
+		assert.Equal(t, event.CreatedAt, resp.Events[0].CreatedAt.AsTime())
+		assert.Equal(t, event.SessionID, resp.Events[0].SessionId)
+		assert.Equal(t, event.Action, resp.Events[0].Action)
+		assert.Equal(t, event.SecretName, resp.Events[0].SecretName)
+		assert.Equal(t, event.SecretVersion, resp.Events[0].SecretVersion)
+		assert.Equal(t, event.Target, resp.Events[0].Target)
+		assert.Equal(t, event.PeerAddress, resp.Events[0].PeerAddress)
+		assert.Equal(t, event.ResultCode, resp.Events[0].Code)
+	})
+}
+
+func TestSecretService_WritesAuditEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	auditStorage := ms.NewMockAuditStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+		AuditStorage:  auditStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	payload := &token.Payload{
+		StandardClaims: jwt.StandardClaims{Id: "SessionID"},
+		UserID:         1,
+	}
+
+	t.Run("SuccessfulGetSecret", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:    "SecretName",
+			Content: []byte("SecretContent"),
+			Version: uuid.New(),
+			OwnerID: payload.UserID,
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(payload, nil)
+
+		secretStorage.
+			EXPECT().
+			GetSecret(gomock.Any(), secret.Name, secret.OwnerID).
+			Return(secret, nil)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, payload.UserID, event.UserID)
+				assert.Equal(t, payload.Id, event.SessionID)
+				assert.Equal(t, models.AuditActionGetSecret, event.Action)
+				assert.Equal(t, secret.Name, event.SecretName)
+				assert.Equal(t, secret.Version.String(), event.SecretVersion)
+				assert.NotEmpty(t, event.PeerAddress)
+				assert.Equal(t, uint32(codes.OK), event.ResultCode)
+				return nil
+			})
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetSecret(context.Background(), &pb.GetSecretRequest{Name: secret.Name})
+		require.NoError(t, err)
+	})
+
+	t.Run("FailedDeleteSecret", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(payload, nil)
+
+		secretStorage.
+			EXPECT().
+			DeleteSecret(gomock.Any(), gomock.Any()).
+			Return(storage.ErrSecretNotFound)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, models.AuditActionDeleteSecret, event.Action)
+				assert.Equal(t, "SecretName", event.SecretName)
+				assert.Empty(t, event.SecretVersion)
+				assert.Equal(t, uint32(codes.NotFound), event.ResultCode)
+				return errors.New("audit storage error")
+			})
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.DeleteSecret(context.Background(), &pb.DeleteSecretRequest{Name: "SecretName"})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+
+	t.Run("SuccessfulPutTemplate", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(payload, nil)
+
+		secretStorage.
+			EXPECT().
+			PutTemplate(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, models.AuditActionPutTemplate, event.Action)
+				assert.Equal(t, "TemplateName", event.Target)
+				assert.Empty(t, event.SecretName)
+				assert.Equal(t, uint32(codes.OK), event.ResultCode)
+				return nil
+			})
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutTemplate(context.Background(), &pb.PutTemplateRequest{
+			Name:    "TemplateName",
+			Content: []byte("TemplateContent"),
+			DataKey: []byte("DataKey"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("FailedDeleteTemplate", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(payload, nil)
+
+		secretStorage.
+			EXPECT().
+			DeleteTemplate(gomock.Any(), "TemplateName", payload.UserID).
+			Return(storage.ErrTemplateNotFound)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, models.AuditActionDeleteTemplate, event.Action)
+				assert.Equal(t, "TemplateName", event.Target)
+				assert.Equal(t, uint32(codes.NotFound), event.ResultCode)
+				return nil
+			})
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.DeleteTemplate(context.Background(), &pb.DeleteTemplateRequest{Name: "TemplateName"})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+
+	t.Run("SuccessfulPutBlob", func(t *testing.T) {
+		blob := &models.Blob{
+			ID:         uuid.New(),
+			SecretName: "SecretName",
+			OwnerID:    payload.UserID,
+			Content:    []byte("BlobContent"),
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(payload, nil)
+
+		secretStorage.
+			EXPECT().
+			PutBlob(gomock.Any(), gomock.Any()).
+			Return(blob, nil)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, models.AuditActionPutBlob, event.Action)
+				assert.Equal(t, blob.SecretName, event.SecretName)
+				assert.Equal(t, blob.ID.String(), event.Target)
+				assert.Equal(t, uint32(codes.OK), event.ResultCode)
+				return nil
+			})
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutBlob(context.Background(), &pb.PutBlobRequest{
+			SecretName: blob.SecretName,
+			Content:    blob.Content,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("FailedDeleteBlob", func(t *testing.T) {
+		id := uuid.New()
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(payload, nil)
+
+		secretStorage.
+			EXPECT().
+			DeleteBlob(gomock.Any(), "SecretName", id, payload.UserID).
+			Return(storage.ErrBlobNotFound)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, models.AuditActionDeleteBlob, event.Action)
+				assert.Equal(t, "SecretName", event.SecretName)
+				assert.Equal(t, id.String(), event.Target)
+				assert.Equal(t, uint32(codes.NotFound), event.ResultCode)
+				return nil
+			})
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.DeleteBlob(context.Background(), &pb.DeleteBlobRequest{
+			SecretName: "SecretName",
+			Id:         id.String(),
+		})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+}
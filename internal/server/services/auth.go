@@ -25,6 +25,7 @@ type AuthService struct {
 	pb.UnimplementedAuthServiceServer
 
 	UserStorage  storage.UserStorage
+	AuditStorage storage.AuditStorage
 	TokenManager token.Manager
 	Hasher       hasher.Hasher
 }
@@ -49,8 +50,14 @@ func NewAuthService(cfg config.Config) *AuthService {
 		log.Fatal().Err(err).Msg("Failed to create hasher computer")
 	}
 
+	auditStorage, err := pg.NewAuditStorage(cfg.DB.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create audit storage")
+	}
+
 	return &AuthService{
 		UserStorage:  userStorage,
+		AuditStorage: auditStorage,
 		TokenManager: tokenManager,
 		Hasher:       hmacHasher,
 	}
@@ -62,7 +69,15 @@ func (srv *AuthService) RegisterService(s grpc.ServiceRegistrar) {
 }
 
 // SignUp функция регистрации
-func (srv *AuthService) SignUp(ctx context.Context, request *pb.SignUpRequest) (*pb.SignUpResponse, error) {
+func (srv *AuthService) SignUp(ctx context.Context, request *pb.SignUpRequest) (resp *pb.SignUpResponse, err error) {
+	var userID int
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionSignUp,
+			UserID: userID,
+		}, err)
+	}()
+
 	if request.GetEmail() == "" || request.GetPassword() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Email or password is empty")
 	}
@@ -84,6 +99,7 @@ func (srv *AuthService) SignUp(ctx context.Context, request *pb.SignUpRequest) (
 		log.Warn().Err(err).Msg("Failed to put user")
 		return nil, status.Error(codes.Internal, "Failed to put user")
 	}
+	userID = user.ID
 
 	accessToken, err := srv.TokenManager.Create(user.ID)
 	if err != nil {
@@ -97,7 +113,15 @@ func (srv *AuthService) SignUp(ctx context.Context, request *pb.SignUpRequest) (
 }
 
 // SignIn функция аутентификации
-func (srv *AuthService) SignIn(ctx context.Context, request *pb.SignInRequest) (*pb.SignInResponse, error) {
+func (srv *AuthService) SignIn(ctx context.Context, request *pb.SignInRequest) (resp *pb.SignInResponse, err error) {
+	var userID int
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionSignIn,
+			UserID: userID,
+		}, err)
+	}()
+
 	if request.GetEmail() == "" || request.GetPassword() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Email or password is empty")
 	}
@@ -118,6 +142,7 @@ func (srv *AuthService) SignIn(ctx context.Context, request *pb.SignInRequest) (
 		log.Warn().Err(err).Msg("Failed to get user")
 		return nil, status.Error(codes.Internal, "Failed to get user")
 	}
+	userID = user.ID
 
 	accessToken, err := srv.TokenManager.Create(user.ID)
 	if err != nil {
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+// PutBlob сохраняет вложение собственного секрета пользователя. Содержимое зашифровано на клиенте,
+// ключ вложения хранится в зашифрованном содержимом секрета
+func (srv *SecretService) PutBlob(
+	ctx context.Context,
+	request *pb.PutBlobRequest,
+) (resp *pb.PutBlobResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionPutBlob,
+			SecretName: request.GetSecretName(),
+			Target:     resp.GetId(),
+		}, err)
+	}()
+
+	if request.GetSecretName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "secret name is empty")
+	}
+	if len(request.GetContent()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty blob content")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	blob, err := srv.SecretStorage.PutBlob(ctx, &models.Blob{
+		SecretName: request.GetSecretName(),
+		OwnerID:    userID,
+		Content:    request.GetContent(),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to put blob")
+	}
+	return &pb.PutBlobResponse{
+		Id: blob.ID.String(),
+	}, nil
+}
+
+// GetBlob возвращает вложение собственного секрета пользователя
+func (srv *SecretService) GetBlob(
+	ctx context.Context,
+	request *pb.GetBlobRequest,
+) (resp *pb.GetBlobResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionGetBlob,
+			SecretName: request.GetSecretName(),
+			Target:     request.GetId(),
+		}, err)
+	}()
+
+	id, err := uuid.Parse(request.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid blob id")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	blob, err := srv.SecretStorage.GetBlob(ctx, request.GetSecretName(), id, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, status.Error(codes.NotFound, "blob not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get blob")
+	}
+	return &pb.GetBlobResponse{
+		Id:      blob.ID.String(),
+		Content: blob.Content,
+	}, nil
+}
+
+// DeleteBlob удаляет вложение собственного секрета пользователя
+func (srv *SecretService) DeleteBlob(
+	ctx context.Context,
+	request *pb.DeleteBlobRequest,
+) (resp *pb.DeleteBlobResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionDeleteBlob,
+			SecretName: request.GetSecretName(),
+			Target:     request.GetId(),
+		}, err)
+	}()
+
+	id, err := uuid.Parse(request.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid blob id")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	if err = srv.SecretStorage.DeleteBlob(ctx, request.GetSecretName(), id, userID); err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, status.Error(codes.NotFound, "blob not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete blob")
+	}
+	return &pb.DeleteBlobResponse{
+		Id: request.GetId(),
+	}, nil
+}
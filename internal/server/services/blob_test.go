@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	serverInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	ms "github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/mock"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+	mt "github.com/go-developer-ya-practicum/gophkeeper/pkg/token/mock"
+)
+
+func TestSecretService_Blobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	blob := &models.Blob{
+		ID:         uuid.New(),
+		SecretName: "note",
+		OwnerID:    userID,
+		Content:    []byte("Content"),
+	}
+
+	t.Run("PutEmptyContent", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutBlob(context.Background(), &pb.PutBlobRequest{SecretName: blob.SecretName})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("PutSecretNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			PutBlob(gomock.Any(), gomock.Any()).
+			Return(nil, storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutBlob(context.Background(), &pb.PutBlobRequest{
+			SecretName: "unknown",
+			Content:    blob.Content,
+		})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SuccessfulPut", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			PutBlob(gomock.Any(), &models.Blob{SecretName: blob.SecretName, OwnerID: userID, Content: blob.Content}).
+			Return(blob, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.PutBlob(context.Background(), &pb.PutBlobRequest{
+			SecretName: blob.SecretName,
+			Content:    blob.Content,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, blob.ID.String(), resp.GetId())
+	})
+	t.Run("GetInvalidID", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetBlob(context.Background(), &pb.GetBlobRequest{SecretName: blob.SecretName, Id: "blob"})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SuccessfulGet", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			GetBlob(gomock.Any(), blob.SecretName, blob.ID, userID).
+			Return(blob, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.GetBlob(context.Background(), &pb.GetBlobRequest{
+			SecretName: blob.SecretName,
+			Id:         blob.ID.String(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, blob.Content, resp.GetContent())
+	})
+	t.Run("DeleteNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			DeleteBlob(gomock.Any(), blob.SecretName, blob.ID, userID).
+			Return(storage.ErrBlobNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.DeleteBlob(context.Background(), &pb.DeleteBlobRequest{
+			SecretName: blob.SecretName,
+			Id:         blob.ID.String(),
+		})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+// collectionAccess возвращает права пользователя на коллекцию, указанную в запросе.
+// При write проверяет, что роль пользователя позволяет изменять секреты коллекции
+func (srv *SecretService) collectionAccess(
+	ctx context.Context,
+	ref *pb.CollectionRef,
+	userID int,
+	write bool,
+) (*models.CollectionAccess, error) {
+	if ref.GetOrganization() == "" || ref.GetCollection() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty organization or collection")
+	}
+
+	access, err := getCollectionAccess(ctx, srv.OrganizationStorage, ref.GetOrganization(), ref.GetCollection(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if write && !access.Role.CanWrite() {
+		return nil, status.Error(codes.PermissionDenied, "collection is read-only for user")
+	}
+	return access, nil
+}
+
+// collectionTrashAccess возвращает права пользователя на коллекцию, указанную в запросе,
+// и проверяет, что роль пользователя позволяет управлять корзиной коллекции
+func (srv *SecretService) collectionTrashAccess(
+	ctx context.Context,
+	ref *pb.CollectionRef,
+	userID int,
+) (*models.CollectionAccess, error) {
+	access, err := srv.collectionAccess(ctx, ref, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if !access.Role.CanManage() {
+		return nil, status.Error(codes.PermissionDenied, "collection trash requires owner or admin role")
+	}
+	return access, nil
+}
+
+func (srv *SecretService) getCollectionSecret(
+	ctx context.Context,
+	request *pb.GetSecretRequest,
+	userID int,
+) (*pb.GetSecretResponse, error) {
+	access, err := srv.collectionAccess(ctx, request.GetCollection(), userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := srv.SecretStorage.GetCollectionSecret(ctx, request.GetName(), access.CollectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get secret")
+	}
+
+	permission := pb.Permission_PERMISSION_READ
+	if access.Role.CanWrite() {
+		permission = pb.Permission_PERMISSION_READ_WRITE
+	}
+	return &pb.GetSecretResponse{
+		Name:       secret.Name,
+		Content:    secret.Content,
+		Version:    secret.Version.String(),
+		DataKey:    access.WrappedKey,
+		Permission: permission,
+	}, nil
+}
+
+func (srv *SecretService) createCollectionSecret(
+	ctx context.Context,
+	request *pb.CreateSecretRequest,
+	userID int,
+) (*pb.CreateSecretResponse, error) {
+	access, err := srv.collectionAccess(ctx, request.GetCollection(), userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := srv.SecretStorage.CreateCollectionSecret(ctx, &models.Secret{
+		Name:         request.GetName(),
+		Content:      request.GetContent(),
+		CollectionID: access.CollectionID,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretConflict) {
+			return nil, status.Error(codes.AlreadyExists, "secret already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to create secret")
+	}
+	return &pb.CreateSecretResponse{
+		Name:    request.GetName(),
+		Version: secret.Version.String(),
+	}, nil
+}
+
+func (srv *SecretService) updateCollectionSecret(
+	ctx context.Context,
+	request *pb.UpdateSecretRequest,
+	userID int,
+) (*pb.UpdateSecretResponse, error) {
+	access, err := srv.collectionAccess(ctx, request.GetCollection(), userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := srv.SecretStorage.UpdateCollectionSecret(ctx, &models.Secret{
+		Name:         request.GetName(),
+		Content:      request.GetContent(),
+		CollectionID: access.CollectionID,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to update secret")
+	}
+	return &pb.UpdateSecretResponse{
+		Name:    request.GetName(),
+		Version: secret.Version.String(),
+	}, nil
+}
+
+func (srv *SecretService) deleteCollectionSecret(
+	ctx context.Context,
+	request *pb.DeleteSecretRequest,
+	userID int,
+) (*pb.DeleteSecretResponse, error) {
+	access, err := srv.collectionAccess(ctx, request.GetCollection(), userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.SecretStorage.DeleteCollectionSecret(ctx, &models.Secret{
+		Name:         request.GetName(),
+		CollectionID: access.CollectionID,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete secret")
+	}
+	return &pb.DeleteSecretResponse{
+		Name: request.GetName(),
+	}, nil
+}
+
+func (srv *SecretService) listCollectionSecrets(
+	ctx context.Context,
+	request *pb.ListSecretsRequest,
+	userID int,
+) (*pb.ListSecretsResponse, error) {
+	access, err := srv.collectionAccess(ctx, request.GetCollection(), userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := srv.SecretStorage.ListCollectionSecrets(ctx, access.CollectionID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list secrets")
+	}
+
+	pbSecrets := make([]*pb.SecretInfo, 0, len(secrets))
+	for _, secret := range secrets {
+		pbSecrets = append(pbSecrets, &pb.SecretInfo{
+			Name:    secret.Name,
+			Content: secret.Content,
+			Version: secret.Version.String(),
+			DataKey: access.WrappedKey,
+		})
+	}
+	return &pb.ListSecretsResponse{
+		Secrets: pbSecrets,
+	}, nil
+}
+
+func (srv *SecretService) listDeletedCollectionSecrets(
+	ctx context.Context,
+	request *pb.ListDeletedSecretsRequest,
+	userID int,
+) (*pb.ListDeletedSecretsResponse, error) {
+	access, err := srv.collectionTrashAccess(ctx, request.GetCollection(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := srv.SecretStorage.ListDeletedCollectionSecrets(ctx, access.CollectionID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list deleted secrets")
+	}
+	return &pb.ListDeletedSecretsResponse{
+		Secrets: srv.deletedSecretsToProto(secrets),
+	}, nil
+}
+
+func (srv *SecretService) restoreCollectionSecret(
+	ctx context.Context,
+	request *pb.RestoreSecretRequest,
+	userID int,
+) (*pb.RestoreSecretResponse, error) {
+	access, err := srv.collectionTrashAccess(ctx, request.GetCollection(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := srv.SecretStorage.RestoreCollectionSecret(ctx, request.GetName(), access.CollectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found in trash")
+		}
+		if errors.Is(err, storage.ErrSecretConflict) {
+			return nil, status.Error(codes.AlreadyExists, "secret already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to restore secret")
+	}
+	return &pb.RestoreSecretResponse{
+		Name:    secret.Name,
+		Version: secret.Version.String(),
+	}, nil
+}
+
+func (srv *SecretService) purgeCollectionSecret(
+	ctx context.Context,
+	request *pb.PurgeSecretRequest,
+	userID int,
+) (*pb.PurgeSecretResponse, error) {
+	access, err := srv.collectionTrashAccess(ctx, request.GetCollection(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.SecretStorage.PurgeCollectionSecret(ctx, request.GetName(), access.CollectionID); err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found in trash")
+		}
+		return nil, status.Error(codes.Internal, "failed to purge secret")
+	}
+	return &pb.PurgeSecretResponse{
+		Name: request.GetName(),
+	}, nil
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	serverInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	ms "github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/mock"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+	mt "github.com/go-developer-ya-practicum/gophkeeper/pkg/token/mock"
+)
+
+func TestSecretService_CollectionSecretAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	organizationStorage := ms.NewMockOrganizationStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage:       secretStorage,
+		OrganizationStorage: organizationStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	ref := &pb.CollectionRef{Organization: "acme", Collection: "infra"}
+	access := func(role models.Role) *models.CollectionAccess {
+		return &models.CollectionAccess{
+			CollectionID: 7,
+			Role:         role,
+			WrappedKey:   []byte("WrappedKey"),
+		}
+	}
+
+	t.Run("NoCollectionAccess", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(nil, storage.ErrCollectionNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetSecret(context.Background(), &pb.GetSecretRequest{
+			Name:       "SecretName",
+			Collection: ref,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+	t.Run("OwnerAndCollection", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetSecret(context.Background(), &pb.GetSecretRequest{
+			Name:       "SecretName",
+			Owner:      "owner@mail.ru",
+			Collection: ref,
+		})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SuccessfulGetCollectionSecret", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:         "SecretName",
+			Content:      []byte("SecretContent"),
+			Version:      uuid.New(),
+			CollectionID: 7,
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(access(models.RoleReadOnly), nil)
+		secretStorage.
+			EXPECT().
+			GetCollectionSecret(gomock.Any(), secret.Name, secret.CollectionID).
+			Return(secret, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.GetSecret(context.Background(), &pb.GetSecretRequest{
+			Name:       secret.Name,
+			Collection: ref,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Content, resp.GetContent())
+		assert.Equal(t, []byte("WrappedKey"), resp.GetDataKey())
+		assert.Equal(t, pb.Permission_PERMISSION_READ, resp.GetPermission())
+	})
+	t.Run("UpdateReadOnlyCollection", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(access(models.RoleReadOnly), nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
+			Name:       "SecretName",
+			Content:    []byte("NewContent"),
+			Collection: ref,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+	t.Run("SuccessfulCreateCollectionSecret", func(t *testing.T) {
+		version := uuid.New()
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(access(models.RoleMember), nil)
+		secretStorage.
+			EXPECT().
+			CreateCollectionSecret(gomock.Any(), &models.Secret{
+				Name:         "SecretName",
+				Content:      []byte("SecretContent"),
+				CollectionID: 7,
+			}).
+			Return(&models.Secret{Version: version}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.CreateSecret(context.Background(), &pb.CreateSecretRequest{
+			Name:       "SecretName",
+			Content:    []byte("SecretContent"),
+			Collection: ref,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, version.String(), resp.GetVersion())
+	})
+	t.Run("RestoreCollectionSecretByMember", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(access(models.RoleMember), nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RestoreSecret(context.Background(), &pb.RestoreSecretRequest{
+			Name:       "SecretName",
+			Collection: ref,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+	t.Run("SuccessfulListDeletedCollectionSecrets", func(t *testing.T) {
+		deletedAt := time.Now().UTC()
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(access(models.RoleAdmin), nil)
+		secretStorage.
+			EXPECT().
+			ListDeletedCollectionSecrets(gomock.Any(), 7).
+			Return([]*models.Secret{{Name: "SecretName", Version: uuid.New(), DeletedAt: deletedAt}}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.ListDeletedSecrets(context.Background(), &pb.ListDeletedSecretsRequest{
+			Collection: ref,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetSecrets(), 1)
+		assert.Equal(t, "SecretName", resp.GetSecrets()[0].GetName())
+		assert.Equal(t, deletedAt, resp.GetSecrets()[0].GetDeletedAt().AsTime())
+	})
+	t.Run("SuccessfulRestoreCollectionSecret", func(t *testing.T) {
+		version := uuid.New()
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(access(models.RoleOwner), nil)
+		secretStorage.
+			EXPECT().
+			RestoreCollectionSecret(gomock.Any(), "SecretName", 7).
+			Return(&models.Secret{Name: "SecretName", Version: version}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.RestoreSecret(context.Background(), &pb.RestoreSecretRequest{
+			Name:       "SecretName",
+			Collection: ref,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, version.String(), resp.GetVersion())
+	})
+	t.Run("PurgeCollectionSecretNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), ref.Organization, ref.Collection, userID).
+			Return(access(models.RoleAdmin), nil)
+		secretStorage.
+			EXPECT().
+			PurgeCollectionSecret(gomock.Any(), "SecretName", 7).
+			Return(storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PurgeSecret(context.Background(), &pb.PurgeSecretRequest{
+			Name:       "SecretName",
+			Collection: ref,
+		})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+}
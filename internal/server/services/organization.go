@@ -0,0 +1,642 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/config"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/pg"
+)
+
+// OrganizationService реализация proto.OrganizationServiceServer
+type OrganizationService struct {
+	OrganizationStorage storage.OrganizationStorage
+	AuditStorage        storage.AuditStorage
+	pb.UnimplementedOrganizationServiceServer
+}
+
+var _ pb.OrganizationServiceServer = (*OrganizationService)(nil)
+var _ Service = (*OrganizationService)(nil)
+
+// NewOrganizationService создает новый сервис OrganizationService
+func NewOrganizationService(cfg config.Config) *OrganizationService {
+	organizationStorage, err := pg.NewOrganizationStorage(cfg.DB.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create storage")
+	}
+
+	auditStorage, err := pg.NewAuditStorage(cfg.DB.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create audit storage")
+	}
+
+	return &OrganizationService{
+		OrganizationStorage: organizationStorage,
+		AuditStorage:        auditStorage,
+	}
+}
+
+// RegisterService функция регистрации сервиса OrganizationService на сервере gRPC
+func (srv *OrganizationService) RegisterService(s grpc.ServiceRegistrar) {
+	pb.RegisterOrganizationServiceServer(s, srv)
+}
+
+// CreateOrganization создает организацию, владельцем которой становится пользователь
+func (srv *OrganizationService) CreateOrganization(
+	ctx context.Context,
+	request *pb.CreateOrganizationRequest,
+) (resp *pb.CreateOrganizationResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionCreateOrganization,
+			Target: auditTarget(request.GetName()),
+		}, err)
+	}()
+
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty organization name")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	if _, err := srv.OrganizationStorage.CreateOrganization(ctx, request.GetName(), userID); err != nil {
+		if errors.Is(err, storage.ErrOrganizationConflict) {
+			return nil, status.Error(codes.AlreadyExists, "organization already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to create organization")
+	}
+	return &pb.CreateOrganizationResponse{Name: request.GetName()}, nil
+}
+
+// ListOrganizations возвращает список организаций пользователя
+func (srv *OrganizationService) ListOrganizations(
+	ctx context.Context,
+	_ *pb.ListOrganizationsRequest,
+) (*pb.ListOrganizationsResponse, error) {
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	organizations, err := srv.OrganizationStorage.ListOrganizations(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list organizations")
+	}
+
+	pbOrganizations := make([]*pb.OrganizationInfo, 0, len(organizations))
+	for _, organization := range organizations {
+		pbOrganizations = append(pbOrganizations, &pb.OrganizationInfo{
+			Name: organization.Name,
+			Role: roleToProto(organization.Role),
+		})
+	}
+	return &pb.ListOrganizationsResponse{Organizations: pbOrganizations}, nil
+}
+
+// PutMember добавляет пользователя в организацию или изменяет его роль.
+// Назначать и изменять роли owner и admin может только владелец организации
+func (srv *OrganizationService) PutMember(
+	ctx context.Context,
+	request *pb.PutMemberRequest,
+) (resp *pb.PutMemberResponse, err error) {
+	role, ok := roleFromProto(request.GetRole())
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionPutMember,
+			Target: auditTarget(request.GetOrganization(), request.GetEmail(), string(role)),
+		}, err)
+	}()
+
+	if request.GetOrganization() == "" || request.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty organization or email")
+	}
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid role")
+	}
+
+	if err := srv.checkMemberManagement(ctx, request.GetOrganization(), request.GetEmail(), role); err != nil {
+		return nil, err
+	}
+
+	err = srv.OrganizationStorage.PutMember(ctx, request.GetOrganization(), request.GetEmail(), role)
+	if err != nil {
+		if errors.Is(err, storage.ErrMemberNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to put member")
+	}
+	return &pb.PutMemberResponse{}, nil
+}
+
+// RemoveMember исключает пользователя из организации
+func (srv *OrganizationService) RemoveMember(
+	ctx context.Context,
+	request *pb.RemoveMemberRequest,
+) (resp *pb.RemoveMemberResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionRemoveMember,
+			Target: auditTarget(request.GetOrganization(), request.GetEmail()),
+		}, err)
+	}()
+
+	if request.GetOrganization() == "" || request.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty organization or email")
+	}
+
+	if err := srv.checkMemberManagement(ctx, request.GetOrganization(), request.GetEmail(), ""); err != nil {
+		return nil, err
+	}
+
+	err = srv.OrganizationStorage.RemoveMember(ctx, request.GetOrganization(), request.GetEmail())
+	if err != nil {
+		if errors.Is(err, storage.ErrMemberNotFound) {
+			return nil, status.Error(codes.NotFound, "member not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to remove member")
+	}
+	return &pb.RemoveMemberResponse{}, nil
+}
+
+// ListMembers возвращает список участников организации
+func (srv *OrganizationService) ListMembers(
+	ctx context.Context,
+	request *pb.ListMembersRequest,
+) (*pb.ListMembersResponse, error) {
+	if _, err := srv.authorize(ctx, request.GetOrganization(), false); err != nil {
+		return nil, err
+	}
+
+	members, err := srv.OrganizationStorage.ListMembers(ctx, request.GetOrganization())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list members")
+	}
+	return &pb.ListMembersResponse{Members: membersToProto(members)}, nil
+}
+
+// CreateTeam создает команду в организации
+func (srv *OrganizationService) CreateTeam(
+	ctx context.Context,
+	request *pb.CreateTeamRequest,
+) (resp *pb.CreateTeamResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionCreateTeam,
+			Target: auditTarget(request.GetOrganization(), request.GetTeam()),
+		}, err)
+	}()
+
+	if request.GetTeam() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty team name")
+	}
+	if _, err := srv.authorize(ctx, request.GetOrganization(), true); err != nil {
+		return nil, err
+	}
+
+	if err := srv.OrganizationStorage.CreateTeam(ctx, request.GetOrganization(), request.GetTeam()); err != nil {
+		if errors.Is(err, storage.ErrTeamConflict) {
+			return nil, status.Error(codes.AlreadyExists, "team already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to create team")
+	}
+	return &pb.CreateTeamResponse{}, nil
+}
+
+// ListTeams возвращает список команд организации
+func (srv *OrganizationService) ListTeams(
+	ctx context.Context,
+	request *pb.ListTeamsRequest,
+) (*pb.ListTeamsResponse, error) {
+	if _, err := srv.authorize(ctx, request.GetOrganization(), false); err != nil {
+		return nil, err
+	}
+
+	teams, err := srv.OrganizationStorage.ListTeams(ctx, request.GetOrganization())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list teams")
+	}
+
+	pbTeams := make([]*pb.TeamInfo, 0, len(teams))
+	for _, team := range teams {
+		pbTeams = append(pbTeams, &pb.TeamInfo{
+			Name:    team.Name,
+			Members: team.Members,
+		})
+	}
+	return &pb.ListTeamsResponse{Teams: pbTeams}, nil
+}
+
+// AddTeamMember добавляет участника организации в команду
+func (srv *OrganizationService) AddTeamMember(
+	ctx context.Context,
+	request *pb.AddTeamMemberRequest,
+) (resp *pb.AddTeamMemberResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionAddTeamMember,
+			Target: auditTarget(request.GetOrganization(), request.GetTeam(), request.GetEmail()),
+		}, err)
+	}()
+
+	if request.GetTeam() == "" || request.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty team or email")
+	}
+	if _, err := srv.authorize(ctx, request.GetOrganization(), true); err != nil {
+		return nil, err
+	}
+
+	err = srv.OrganizationStorage.AddTeamMember(ctx, request.GetOrganization(), request.GetTeam(), request.GetEmail())
+	if err != nil {
+		if errors.Is(err, storage.ErrMemberNotFound) {
+			return nil, status.Error(codes.NotFound, "team or member not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to add team member")
+	}
+	return &pb.AddTeamMemberResponse{}, nil
+}
+
+// RemoveTeamMember исключает участника из команды
+func (srv *OrganizationService) RemoveTeamMember(
+	ctx context.Context,
+	request *pb.RemoveTeamMemberRequest,
+) (resp *pb.RemoveTeamMemberResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionRemoveTeamMember,
+			Target: auditTarget(request.GetOrganization(), request.GetTeam(), request.GetEmail()),
+		}, err)
+	}()
+
+	if request.GetTeam() == "" || request.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty team or email")
+	}
+	if _, err := srv.authorize(ctx, request.GetOrganization(), true); err != nil {
+		return nil, err
+	}
+
+	err = srv.OrganizationStorage.RemoveTeamMember(
+		ctx, request.GetOrganization(), request.GetTeam(), request.GetEmail())
+	if err != nil {
+		if errors.Is(err, storage.ErrMemberNotFound) {
+			return nil, status.Error(codes.NotFound, "team member not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to remove team member")
+	}
+	return &pb.RemoveTeamMemberResponse{}, nil
+}
+
+// CreateCollection создает коллекцию секретов организации.
+// Ключ коллекции передается зашифрованным открытым ключом создателя
+func (srv *OrganizationService) CreateCollection(
+	ctx context.Context,
+	request *pb.CreateCollectionRequest,
+) (resp *pb.CreateCollectionResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionCreateCollection,
+			Target: auditTarget(request.GetOrganization(), request.GetCollection()),
+		}, err)
+	}()
+
+	if request.GetCollection() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty collection name")
+	}
+	if len(request.GetWrappedKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty collection key")
+	}
+	userID, err := srv.authorize(ctx, request.GetOrganization(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.OrganizationStorage.CreateCollection(
+		ctx, request.GetOrganization(), request.GetCollection(), userID, request.GetWrappedKey())
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionConflict) {
+			return nil, status.Error(codes.AlreadyExists, "collection already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to create collection")
+	}
+	return &pb.CreateCollectionResponse{}, nil
+}
+
+// ListCollections возвращает список коллекций организации, доступных пользователю
+func (srv *OrganizationService) ListCollections(
+	ctx context.Context,
+	request *pb.ListCollectionsRequest,
+) (*pb.ListCollectionsResponse, error) {
+	userID, err := srv.authorize(ctx, request.GetOrganization(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := srv.OrganizationStorage.ListCollections(ctx, request.GetOrganization(), userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list collections")
+	}
+
+	names := make([]string, 0, len(collections))
+	for _, collection := range collections {
+		names = append(names, collection.Name)
+	}
+	return &pb.ListCollectionsResponse{Collections: names}, nil
+}
+
+// GrantCollection предоставляет команде доступ к коллекции
+func (srv *OrganizationService) GrantCollection(
+	ctx context.Context,
+	request *pb.GrantCollectionRequest,
+) (resp *pb.GrantCollectionResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionGrantCollection,
+			Target: auditTarget(request.GetOrganization(), request.GetCollection(), request.GetTeam()),
+		}, err)
+	}()
+
+	if request.GetCollection() == "" || request.GetTeam() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty collection or team")
+	}
+	if _, err := srv.authorize(ctx, request.GetOrganization(), true); err != nil {
+		return nil, err
+	}
+
+	err = srv.OrganizationStorage.GrantCollection(
+		ctx, request.GetOrganization(), request.GetCollection(), request.GetTeam())
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			return nil, status.Error(codes.NotFound, "collection or team not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to grant collection")
+	}
+	return &pb.GrantCollectionResponse{}, nil
+}
+
+// RevokeCollection отзывает у команды доступ к коллекции
+func (srv *OrganizationService) RevokeCollection(
+	ctx context.Context,
+	request *pb.RevokeCollectionRequest,
+) (resp *pb.RevokeCollectionResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionRevokeCollection,
+			Target: auditTarget(request.GetOrganization(), request.GetCollection(), request.GetTeam()),
+		}, err)
+	}()
+
+	if request.GetCollection() == "" || request.GetTeam() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty collection or team")
+	}
+	if _, err := srv.authorize(ctx, request.GetOrganization(), true); err != nil {
+		return nil, err
+	}
+
+	err = srv.OrganizationStorage.RevokeCollection(
+		ctx, request.GetOrganization(), request.GetCollection(), request.GetTeam())
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			return nil, status.Error(codes.NotFound, "collection access not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to revoke collection")
+	}
+	return &pb.RevokeCollectionResponse{}, nil
+}
+
+// GetCollectionKey возвращает ключ коллекции, зашифрованный открытым ключом пользователя
+func (srv *OrganizationService) GetCollectionKey(
+	ctx context.Context,
+	request *pb.GetCollectionKeyRequest,
+) (*pb.GetCollectionKeyResponse, error) {
+	access, err := srv.collectionAccess(ctx, request.GetOrganization(), request.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	if len(access.WrappedKey) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "collection key has not been shared with user yet")
+	}
+	return &pb.GetCollectionKeyResponse{
+		WrappedKey: access.WrappedKey,
+		Role:       roleToProto(access.Role),
+	}, nil
+}
+
+// ListPendingCollectionKeys возвращает участников с доступом к коллекции, еще не получивших ее ключ
+func (srv *OrganizationService) ListPendingCollectionKeys(
+	ctx context.Context,
+	request *pb.ListPendingCollectionKeysRequest,
+) (*pb.ListPendingCollectionKeysResponse, error) {
+	access, err := srv.collectionAccess(ctx, request.GetOrganization(), request.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := srv.OrganizationStorage.ListPendingCollectionKeys(ctx, access.CollectionID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list pending collection keys")
+	}
+	return &pb.ListPendingCollectionKeysResponse{Members: membersToProto(members)}, nil
+}
+
+// PutCollectionKeys сохраняет ключ коллекции, зашифрованный открытыми ключами участников.
+// Ключи распределяет владелец или администратор организации, уже получивший ключ коллекции
+func (srv *OrganizationService) PutCollectionKeys(
+	ctx context.Context,
+	request *pb.PutCollectionKeysRequest,
+) (resp *pb.PutCollectionKeysResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionPutCollectionKeys,
+			Target: auditTarget(request.GetOrganization(), request.GetCollection()),
+		}, err)
+	}()
+
+	access, err := srv.collectionAccess(ctx, request.GetOrganization(), request.GetCollection())
+	if err != nil {
+		return nil, err
+	}
+	if !access.Role.CanManage() {
+		return nil, status.Error(codes.PermissionDenied, "organization management requires owner or admin role")
+	}
+	if len(access.WrappedKey) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "collection key has not been shared with user yet")
+	}
+
+	for _, key := range request.GetKeys() {
+		if key.GetEmail() == "" || len(key.GetWrappedKey()) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "empty email or collection key")
+		}
+		err = srv.OrganizationStorage.PutCollectionKey(ctx, access.CollectionID, key.GetEmail(), key.GetWrappedKey())
+		if err != nil {
+			if errors.Is(err, storage.ErrMemberNotFound) {
+				return nil, status.Errorf(codes.FailedPrecondition, "member %s has no pending collection key", key.GetEmail())
+			}
+			return nil, status.Error(codes.Internal, "failed to put collection key")
+		}
+	}
+	return &pb.PutCollectionKeysResponse{}, nil
+}
+
+// authorize проверяет, что пользователь состоит в организации, а при manage - что он может ей управлять
+func (srv *OrganizationService) authorize(ctx context.Context, organization string, manage bool) (int, error) {
+	userID, role, err := srv.memberRole(ctx, organization)
+	if err != nil {
+		return 0, err
+	}
+	if manage && !role.CanManage() {
+		return 0, status.Error(codes.PermissionDenied, "organization management requires owner or admin role")
+	}
+	return userID, nil
+}
+
+// memberRole возвращает идентификатор пользователя и его роль в организации
+func (srv *OrganizationService) memberRole(ctx context.Context, organization string) (int, models.Role, error) {
+	if organization == "" {
+		return 0, "", status.Error(codes.InvalidArgument, "empty organization name")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return 0, "", status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	role, err := srv.OrganizationStorage.GetRole(ctx, organization, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrganizationNotFound) {
+			return 0, "", status.Error(codes.NotFound, "organization not found")
+		}
+		return 0, "", status.Error(codes.Internal, "failed to get organization role")
+	}
+	return userID, role, nil
+}
+
+// checkMemberManagement проверяет право пользователя изменить членство участника email.
+// Пустая роль role соответствует исключению участника из организации
+func (srv *OrganizationService) checkMemberManagement(
+	ctx context.Context,
+	organization, email string,
+	role models.Role,
+) error {
+	_, callerRole, err := srv.memberRole(ctx, organization)
+	if err != nil {
+		return err
+	}
+	if !callerRole.CanManage() {
+		return status.Error(codes.PermissionDenied, "organization management requires owner or admin role")
+	}
+
+	members, err := srv.OrganizationStorage.ListMembers(ctx, organization)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to list members")
+	}
+	var targetRole models.Role
+	for _, member := range members {
+		if member.Email == email {
+			targetRole = member.Role
+		}
+	}
+
+	if callerRole != models.RoleOwner && (role.CanManage() || targetRole.CanManage()) {
+		return status.Error(codes.PermissionDenied, "only owner can manage owners and admins")
+	}
+	if targetRole == models.RoleOwner && role != models.RoleOwner && countOwners(members) == 1 {
+		return status.Error(codes.FailedPrecondition, "organization must have at least one owner")
+	}
+	return nil
+}
+
+// collectionAccess возвращает права пользователя на коллекцию организации
+func (srv *OrganizationService) collectionAccess(
+	ctx context.Context,
+	organization, collection string,
+) (*models.CollectionAccess, error) {
+	if organization == "" || collection == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty organization or collection")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	return getCollectionAccess(ctx, srv.OrganizationStorage, organization, collection, userID)
+}
+
+func getCollectionAccess(
+	ctx context.Context,
+	organizationStorage storage.OrganizationStorage,
+	organization, collection string,
+	userID int,
+) (*models.CollectionAccess, error) {
+	access, err := organizationStorage.GetCollectionAccess(ctx, organization, collection, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			return nil, status.Error(codes.PermissionDenied, "no access to collection")
+		}
+		return nil, status.Error(codes.Internal, "failed to get collection access")
+	}
+	return access, nil
+}
+
+func countOwners(members []*models.Member) int {
+	owners := 0
+	for _, member := range members {
+		if member.Role == models.RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
+
+func membersToProto(members []*models.Member) []*pb.MemberInfo {
+	pbMembers := make([]*pb.MemberInfo, 0, len(members))
+	for _, member := range members {
+		pbMembers = append(pbMembers, &pb.MemberInfo{
+			Email:     member.Email,
+			Role:      roleToProto(member.Role),
+			PublicKey: member.PublicKey,
+		})
+	}
+	return pbMembers
+}
+
+func roleFromProto(role pb.Role) (models.Role, bool) {
+	switch role {
+	case pb.Role_ROLE_OWNER:
+		return models.RoleOwner, true
+	case pb.Role_ROLE_ADMIN:
+		return models.RoleAdmin, true
+	case pb.Role_ROLE_MEMBER:
+		return models.RoleMember, true
+	case pb.Role_ROLE_READ_ONLY:
+		return models.RoleReadOnly, true
+	default:
+		return "", false
+	}
+}
+
+func roleToProto(role models.Role) pb.Role {
+	switch role {
+	case models.RoleOwner:
+		return pb.Role_ROLE_OWNER
+	case models.RoleAdmin:
+		return pb.Role_ROLE_ADMIN
+	case models.RoleMember:
+		return pb.Role_ROLE_MEMBER
+	case models.RoleReadOnly:
+		return pb.Role_ROLE_READ_ONLY
+	default:
+		return pb.Role_ROLE_UNSPECIFIED
+	}
+}
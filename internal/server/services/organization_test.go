@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+
+	clientInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/client/interceptors"
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	serverInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	ms "github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/mock"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+	mt "github.com/go-developer-ya-practicum/gophkeeper/pkg/token/mock"
+)
+
+func newOrganizationClient(accessToken string) (pb.OrganizationServiceClient, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(clientInterceptors.NewAuthInterceptor(accessToken).Unary()),
+	}
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewOrganizationServiceClient(conn), nil
+}
+
+func TestOrganizationService_Members(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organizationStorage := ms.NewMockOrganizationStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	organizationService := &OrganizationService{
+		OrganizationStorage: organizationStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(organizationService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	organization := "acme"
+	email := "member@mail.ru"
+
+	t.Run("OrganizationNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetRole(gomock.Any(), organization, userID).
+			Return(models.Role(""), storage.ErrOrganizationNotFound)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.ListMembers(context.Background(), &pb.ListMembersRequest{
+			Organization: organization,
+		})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("MemberCannotManage", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetRole(gomock.Any(), organization, userID).
+			Return(models.RoleMember, nil)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutMember(context.Background(), &pb.PutMemberRequest{
+			Organization: organization,
+			Email:        email,
+			Role:         pb.Role_ROLE_READ_ONLY,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+	t.Run("AdminCannotAssignAdmin", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetRole(gomock.Any(), organization, userID).
+			Return(models.RoleAdmin, nil)
+		organizationStorage.
+			EXPECT().
+			ListMembers(gomock.Any(), organization).
+			Return([]*models.Member{}, nil)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutMember(context.Background(), &pb.PutMemberRequest{
+			Organization: organization,
+			Email:        email,
+			Role:         pb.Role_ROLE_ADMIN,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+	t.Run("LastOwnerCannotBeRemoved", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetRole(gomock.Any(), organization, userID).
+			Return(models.RoleOwner, nil)
+		organizationStorage.
+			EXPECT().
+			ListMembers(gomock.Any(), organization).
+			Return([]*models.Member{{Email: email, Role: models.RoleOwner}}, nil)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RemoveMember(context.Background(), &pb.RemoveMemberRequest{
+			Organization: organization,
+			Email:        email,
+		})
+		checkErrorStatus(t, err, codes.FailedPrecondition)
+	})
+	t.Run("SuccessfulPutMember", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetRole(gomock.Any(), organization, userID).
+			Return(models.RoleAdmin, nil)
+		organizationStorage.
+			EXPECT().
+			ListMembers(gomock.Any(), organization).
+			Return([]*models.Member{}, nil)
+		organizationStorage.
+			EXPECT().
+			PutMember(gomock.Any(), organization, email, models.RoleMember).
+			Return(nil)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutMember(context.Background(), &pb.PutMemberRequest{
+			Organization: organization,
+			Email:        email,
+			Role:         pb.Role_ROLE_MEMBER,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestOrganizationService_CollectionKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organizationStorage := ms.NewMockOrganizationStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	organizationService := &OrganizationService{
+		OrganizationStorage: organizationStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(organizationService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	organization := "acme"
+	collection := "infra"
+
+	t.Run("NoAccess", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), organization, collection, userID).
+			Return(nil, storage.ErrCollectionNotFound)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetCollectionKey(context.Background(), &pb.GetCollectionKeyRequest{
+			Organization: organization,
+			Collection:   collection,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+	t.Run("KeyNotSharedYet", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), organization, collection, userID).
+			Return(&models.CollectionAccess{CollectionID: 1, Role: models.RoleMember}, nil)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetCollectionKey(context.Background(), &pb.GetCollectionKeyRequest{
+			Organization: organization,
+			Collection:   collection,
+		})
+		checkErrorStatus(t, err, codes.FailedPrecondition)
+	})
+	t.Run("SuccessfulPutCollectionKeys", func(t *testing.T) {
+		email := "member@mail.ru"
+		wrappedKey := []byte("WrappedKey")
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), organization, collection, userID).
+			Return(&models.CollectionAccess{CollectionID: 1, Role: models.RoleAdmin, WrappedKey: []byte("Key")}, nil)
+		organizationStorage.
+			EXPECT().
+			PutCollectionKey(gomock.Any(), 1, email, wrappedKey).
+			Return(nil)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutCollectionKeys(context.Background(), &pb.PutCollectionKeysRequest{
+			Organization: organization,
+			Collection:   collection,
+			Keys:         []*pb.CollectionKey{{Email: email, WrappedKey: wrappedKey}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("PutCollectionKeysByReadOnlyMember", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetCollectionAccess(gomock.Any(), organization, collection, userID).
+			Return(&models.CollectionAccess{CollectionID: 1, Role: models.RoleReadOnly, WrappedKey: []byte("Key")}, nil)
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutCollectionKeys(context.Background(), &pb.PutCollectionKeysRequest{
+			Organization: organization,
+			Collection:   collection,
+			Keys:         []*pb.CollectionKey{{Email: "member@mail.ru", WrappedKey: []byte("AttackerKey")}},
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+}
+
+func TestOrganizationService_WritesAuditEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organizationStorage := ms.NewMockOrganizationStorage(ctrl)
+	auditStorage := ms.NewMockAuditStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	organizationService := &OrganizationService{
+		OrganizationStorage: organizationStorage,
+		AuditStorage:        auditStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(organizationService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	organization := "acme"
+	email := "member@mail.ru"
+
+	t.Run("SuccessfulCreateOrganization", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			CreateOrganization(gomock.Any(), organization, userID).
+			Return(&models.Organization{ID: 1, Name: organization}, nil)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, userID, event.UserID)
+				assert.Equal(t, models.AuditActionCreateOrganization, event.Action)
+				assert.Equal(t, organization, event.Target)
+				assert.Equal(t, uint32(codes.OK), event.ResultCode)
+				return nil
+			})
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.CreateOrganization(context.Background(), &pb.CreateOrganizationRequest{Name: organization})
+		require.NoError(t, err)
+	})
+	t.Run("DeniedPutMember", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		organizationStorage.
+			EXPECT().
+			GetRole(gomock.Any(), organization, userID).
+			Return(models.RoleMember, nil)
+
+		auditStorage.
+			EXPECT().
+			PutEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, models.AuditActionPutMember, event.Action)
+				assert.Equal(t, "acme/member@mail.ru/admin", event.Target)
+				assert.Equal(t, uint32(codes.PermissionDenied), event.ResultCode)
+				return nil
+			})
+
+		client, err := newOrganizationClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutMember(context.Background(), &pb.PutMemberRequest{
+			Organization: organization,
+			Email:        email,
+			Role:         pb.Role_ROLE_ADMIN,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+}
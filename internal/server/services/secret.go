@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/config"
@@ -20,7 +22,11 @@ import (
 
 // SecretService реализация proto.SecretServiceServer
 type SecretService struct {
-	SecretStorage storage.SecretStorage
+	SecretStorage       storage.SecretStorage
+	UserStorage         storage.UserStorage
+	AuditStorage        storage.AuditStorage
+	OrganizationStorage storage.OrganizationStorage
+	TrashRetention      time.Duration
 	pb.UnimplementedSecretServiceServer
 }
 
@@ -34,7 +40,28 @@ func NewSecretService(cfg config.Config) *SecretService {
 		log.Fatal().Err(err).Msg("Failed to create storage")
 	}
 
-	return &SecretService{SecretStorage: secretStorage}
+	userStorage, err := pg.NewUserStorage(cfg.DB.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create user storage")
+	}
+
+	auditStorage, err := pg.NewAuditStorage(cfg.DB.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create audit storage")
+	}
+
+	organizationStorage, err := pg.NewOrganizationStorage(cfg.DB.URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create organization storage")
+	}
+
+	return &SecretService{
+		SecretStorage:       secretStorage,
+		UserStorage:         userStorage,
+		AuditStorage:        auditStorage,
+		OrganizationStorage: organizationStorage,
+		TrashRetention:      cfg.Trash.Retention,
+	}
 }
 
 // RegisterService функция регистрации сервиса SecretService на сервере gRPC
@@ -42,11 +69,21 @@ func (srv *SecretService) RegisterService(s grpc.ServiceRegistrar) {
 	pb.RegisterSecretServiceServer(s, srv)
 }
 
-// GetSecret возвращает приватные данные пользователя по указанному в запросе названию
+// GetSecret возвращает приватные данные пользователя по указанному в запросе названию.
+// Если в запросе указан владелец, возвращает секрет, к которому владелец предоставил доступ,
+// если указана коллекция - секрет коллекции организации
 func (srv *SecretService) GetSecret(
 	ctx context.Context,
 	request *pb.GetSecretRequest,
-) (*pb.GetSecretResponse, error) {
+) (resp *pb.GetSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:        models.AuditActionGetSecret,
+			SecretName:    request.GetName(),
+			SecretVersion: resp.GetVersion(),
+		}, err)
+	}()
+
 	if request.GetName() == "" {
 		return nil, status.Error(codes.InvalidArgument, "secret name is empty")
 	}
@@ -56,6 +93,16 @@ func (srv *SecretService) GetSecret(
 		return nil, status.Error(codes.Unauthenticated, "empty user id")
 	}
 
+	if request.GetOwner() != "" && request.GetCollection() != nil {
+		return nil, status.Error(codes.InvalidArgument, "both owner and collection are specified")
+	}
+	if request.GetOwner() != "" {
+		return srv.getSharedSecret(ctx, request.GetName(), request.GetOwner(), userID)
+	}
+	if request.GetCollection() != nil {
+		return srv.getCollectionSecret(ctx, request, userID)
+	}
+
 	secret, err := srv.SecretStorage.GetSecret(ctx, request.GetName(), userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrSecretNotFound) {
@@ -68,14 +115,23 @@ func (srv *SecretService) GetSecret(
 		Name:    secret.Name,
 		Content: secret.Content,
 		Version: secret.Version.String(),
+		DataKey: secret.DataKey,
 	}, nil
 }
 
-// CreateSecret сохраняет на сервере новые приватные данные пользователя
+// CreateSecret сохраняет на сервере новые приватные данные пользователя или коллекции
 func (srv *SecretService) CreateSecret(
 	ctx context.Context,
 	request *pb.CreateSecretRequest,
-) (*pb.CreateSecretResponse, error) {
+) (resp *pb.CreateSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:        models.AuditActionCreateSecret,
+			SecretName:    request.GetName(),
+			SecretVersion: resp.GetVersion(),
+		}, err)
+	}()
+
 	if request.GetName() == "" {
 		return nil, status.Error(codes.InvalidArgument, "empty secret name")
 	}
@@ -88,10 +144,15 @@ func (srv *SecretService) CreateSecret(
 		return nil, status.Error(codes.Unauthenticated, "empty user id")
 	}
 
+	if request.GetCollection() != nil {
+		return srv.createCollectionSecret(ctx, request, userID)
+	}
+
 	secret, err := srv.SecretStorage.CreateSecret(ctx, &models.Secret{
 		Name:    request.GetName(),
 		Content: request.GetContent(),
 		Version: uuid.UUID{},
+		DataKey: request.GetDataKey(),
 		OwnerID: userID,
 	})
 	if err != nil {
@@ -106,11 +167,21 @@ func (srv *SecretService) CreateSecret(
 	}, nil
 }
 
-// UpdateSecret обновляет приватные данные пользователя
+// UpdateSecret обновляет приватные данные пользователя.
+// Если в запросе указан владелец, обновляет секрет, доступный пользователю на запись,
+// если указана коллекция - секрет коллекции организации
 func (srv *SecretService) UpdateSecret(
 	ctx context.Context,
 	request *pb.UpdateSecretRequest,
-) (*pb.UpdateSecretResponse, error) {
+) (resp *pb.UpdateSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:        models.AuditActionUpdateSecret,
+			SecretName:    request.GetName(),
+			SecretVersion: resp.GetVersion(),
+		}, err)
+	}()
+
 	if request.GetName() == "" {
 		return nil, status.Error(codes.InvalidArgument, "empty secret name")
 	}
@@ -123,14 +194,41 @@ func (srv *SecretService) UpdateSecret(
 		return nil, status.Error(codes.Unauthenticated, "empty user id")
 	}
 
+	if request.GetOwner() != "" && request.GetCollection() != nil {
+		return nil, status.Error(codes.InvalidArgument, "both owner and collection are specified")
+	}
+
+	// Ожидаемая версия позволяет не перезаписать изменения, сделанные после получения секрета
+	var expectedVersion uuid.UUID
+	if request.GetExpectedVersion() != "" {
+		if request.GetOwner() != "" || request.GetCollection() != nil {
+			return nil, status.Error(codes.InvalidArgument, "expected version is supported only for own secrets")
+		}
+		if expectedVersion, err = uuid.Parse(request.GetExpectedVersion()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid expected version")
+		}
+	}
+
+	if request.GetOwner() != "" {
+		return srv.updateSharedSecret(ctx, request, userID)
+	}
+	if request.GetCollection() != nil {
+		return srv.updateCollectionSecret(ctx, request, userID)
+	}
+
 	secret, err := srv.SecretStorage.UpdateSecret(ctx, &models.Secret{
 		Name:    request.GetName(),
 		Content: request.GetContent(),
+		Version: expectedVersion,
+		DataKey: request.GetDataKey(),
 		OwnerID: userID,
 	})
 	if err != nil {
-		if errors.Is(err, storage.ErrSecretNotFound) {
+		switch {
+		case errors.Is(err, storage.ErrSecretNotFound):
 			return nil, status.Error(codes.NotFound, "secret not found")
+		case errors.Is(err, storage.ErrVersionConflict):
+			return nil, status.Error(codes.Aborted, "secret was modified, expected version is outdated")
 		}
 		return nil, status.Error(codes.Internal, "failed to create secret")
 	}
@@ -140,11 +238,18 @@ func (srv *SecretService) UpdateSecret(
 	}, nil
 }
 
-// DeleteSecret удаляет приватные данные пользователя
+// DeleteSecret перемещает приватные данные пользователя или коллекции в корзину
 func (srv *SecretService) DeleteSecret(
 	ctx context.Context,
 	request *pb.DeleteSecretRequest,
-) (*pb.DeleteSecretResponse, error) {
+) (resp *pb.DeleteSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionDeleteSecret,
+			SecretName: request.GetName(),
+		}, err)
+	}()
+
 	if request.GetName() == "" {
 		return nil, status.Error(codes.InvalidArgument, "empty secret name")
 	}
@@ -154,6 +259,10 @@ func (srv *SecretService) DeleteSecret(
 		return nil, status.Error(codes.Unauthenticated, "empty user id")
 	}
 
+	if request.GetCollection() != nil {
+		return srv.deleteCollectionSecret(ctx, request, userID)
+	}
+
 	secret := &models.Secret{
 		Name:    request.GetName(),
 		OwnerID: userID,
@@ -163,23 +272,115 @@ func (srv *SecretService) DeleteSecret(
 		if errors.Is(err, storage.ErrSecretNotFound) {
 			return nil, status.Error(codes.NotFound, "secret not found")
 		}
-		return nil, status.Error(codes.Internal, "failed to create secret")
+		return nil, status.Error(codes.Internal, "failed to delete secret")
 	}
 	return &pb.DeleteSecretResponse{
 		Name: request.GetName(),
 	}, nil
 }
 
-// ListSecrets возвращает список секретов пользователя
+// RenameSecret переименовывает секрет пользователя без изменения его содержимого
+func (srv *SecretService) RenameSecret(
+	ctx context.Context,
+	request *pb.RenameSecretRequest,
+) (resp *pb.RenameSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:        models.AuditActionRenameSecret,
+			SecretName:    request.GetName(),
+			SecretVersion: resp.GetVersion(),
+		}, err)
+	}()
+
+	if request.GetName() == "" || request.GetNewName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty secret name")
+	}
+	if request.GetName() == request.GetNewName() {
+		return nil, status.Error(codes.InvalidArgument, "new secret name matches the current one")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	secret, err := srv.SecretStorage.RenameSecret(ctx, request.GetName(), request.GetNewName(), userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		if errors.Is(err, storage.ErrSecretConflict) {
+			return nil, status.Error(codes.AlreadyExists, "secret already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to rename secret")
+	}
+	return &pb.RenameSecretResponse{
+		Name:    secret.Name,
+		Version: secret.Version.String(),
+	}, nil
+}
+
+// CopySecret создает копию секрета пользователя под новым названием
+func (srv *SecretService) CopySecret(
+	ctx context.Context,
+	request *pb.CopySecretRequest,
+) (resp *pb.CopySecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:        models.AuditActionCopySecret,
+			SecretName:    request.GetName(),
+			SecretVersion: resp.GetVersion(),
+		}, err)
+	}()
+
+	if request.GetName() == "" || request.GetNewName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty secret name")
+	}
+	if request.GetName() == request.GetNewName() {
+		return nil, status.Error(codes.InvalidArgument, "new secret name matches the current one")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	secret, err := srv.SecretStorage.CopySecret(ctx, request.GetName(), request.GetNewName(), userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		if errors.Is(err, storage.ErrSecretConflict) {
+			return nil, status.Error(codes.AlreadyExists, "secret already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to copy secret")
+	}
+	return &pb.CopySecretResponse{
+		Name:    secret.Name,
+		Version: secret.Version.String(),
+	}, nil
+}
+
+// ListSecrets возвращает список секретов пользователя или коллекции
 func (srv *SecretService) ListSecrets(
 	ctx context.Context,
-	_ *pb.ListSecretsRequest,
-) (*pb.ListSecretsResponse, error) {
+	request *pb.ListSecretsRequest,
+) (resp *pb.ListSecretsResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionListSecrets,
+		}, err)
+	}()
+
 	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "empty user id")
 	}
 
+	if request.GetCollection() != nil {
+		return srv.listCollectionSecrets(ctx, request, userID)
+	}
+
 	secrets, err := srv.SecretStorage.ListSecrets(ctx, userID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to list secrets")
@@ -191,9 +392,163 @@ func (srv *SecretService) ListSecrets(
 			Name:    secret.Name,
 			Content: secret.Content,
 			Version: secret.Version.String(),
+			DataKey: secret.DataKey,
 		})
 	}
 	return &pb.ListSecretsResponse{
 		Secrets: pbSecrets,
 	}, nil
 }
+
+// ListDeletedSecrets возвращает список секретов пользователя или коллекции, находящихся в корзине
+func (srv *SecretService) ListDeletedSecrets(
+	ctx context.Context,
+	request *pb.ListDeletedSecretsRequest,
+) (resp *pb.ListDeletedSecretsResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionListDeletedSecrets,
+		}, err)
+	}()
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	if request.GetCollection() != nil {
+		return srv.listDeletedCollectionSecrets(ctx, request, userID)
+	}
+
+	secrets, err := srv.SecretStorage.ListDeletedSecrets(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list deleted secrets")
+	}
+	return &pb.ListDeletedSecretsResponse{
+		Secrets: srv.deletedSecretsToProto(secrets),
+	}, nil
+}
+
+// deletedSecretsToProto возвращает описания секретов в корзине со сроком их окончательного удаления
+func (srv *SecretService) deletedSecretsToProto(secrets []*models.Secret) []*pb.DeletedSecretInfo {
+	pbSecrets := make([]*pb.DeletedSecretInfo, 0, len(secrets))
+	for _, secret := range secrets {
+		info := &pb.DeletedSecretInfo{
+			Name:      secret.Name,
+			Version:   secret.Version.String(),
+			DeletedAt: timestamppb.New(secret.DeletedAt),
+		}
+		if srv.TrashRetention > 0 {
+			info.PurgeAt = timestamppb.New(secret.DeletedAt.Add(srv.TrashRetention))
+		}
+		pbSecrets = append(pbSecrets, info)
+	}
+	return pbSecrets
+}
+
+// RestoreSecret восстанавливает секрет пользователя или коллекции из корзины
+func (srv *SecretService) RestoreSecret(
+	ctx context.Context,
+	request *pb.RestoreSecretRequest,
+) (resp *pb.RestoreSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:        models.AuditActionRestoreSecret,
+			SecretName:    request.GetName(),
+			SecretVersion: resp.GetVersion(),
+		}, err)
+	}()
+
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty secret name")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	if request.GetCollection() != nil {
+		return srv.restoreCollectionSecret(ctx, request, userID)
+	}
+
+	secret, err := srv.SecretStorage.RestoreSecret(ctx, request.GetName(), userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found in trash")
+		}
+		if errors.Is(err, storage.ErrSecretConflict) {
+			return nil, status.Error(codes.AlreadyExists, "secret already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to restore secret")
+	}
+	return &pb.RestoreSecretResponse{
+		Name:    secret.Name,
+		Version: secret.Version.String(),
+	}, nil
+}
+
+// PurgeSecret окончательно удаляет секрет пользователя или коллекции из корзины
+func (srv *SecretService) PurgeSecret(
+	ctx context.Context,
+	request *pb.PurgeSecretRequest,
+) (resp *pb.PurgeSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionPurgeSecret,
+			SecretName: request.GetName(),
+		}, err)
+	}()
+
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty secret name")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	if request.GetCollection() != nil {
+		return srv.purgeCollectionSecret(ctx, request, userID)
+	}
+
+	if err := srv.SecretStorage.PurgeSecret(ctx, request.GetName(), userID); err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found in trash")
+		}
+		return nil, status.Error(codes.Internal, "failed to purge secret")
+	}
+	return &pb.PurgeSecretResponse{
+		Name: request.GetName(),
+	}, nil
+}
+
+// RunTrashCleaner с периодом interval окончательно удаляет секреты,
+// находящиеся в корзине дольше срока хранения TrashRetention
+func (srv *SecretService) RunTrashCleaner(ctx context.Context, interval time.Duration) {
+	if srv.TrashRetention <= 0 || interval <= 0 {
+		log.Info().Msg("Trash cleaner is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deletedBefore := time.Now().Add(-srv.TrashRetention)
+			purged, err := srv.SecretStorage.PurgeExpiredSecrets(ctx, deletedBefore)
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to purge expired secrets")
+				continue
+			}
+			if purged > 0 {
+				log.Info().Msgf("Purged %d expired secrets from trash", purged)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -401,6 +402,56 @@ func TestSecretService_UpdateSecret(t *testing.T) {
 		)
 		checkErrorStatus(t, err, codes.Internal)
 	})
+	t.Run("InvalidExpectedVersion", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.UpdateSecret(
+			context.Background(),
+			&pb.UpdateSecretRequest{
+				Name:            "SecretName",
+				Content:         []byte("SecretContent"),
+				ExpectedVersion: "version",
+			},
+		)
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("VersionConflict", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:    "SecretName",
+			Content: []byte("SecretContent"),
+			Version: uuid.New(),
+			OwnerID: userID,
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: secret.OwnerID}, nil)
+
+		secretStorage.
+			EXPECT().
+			UpdateSecret(gomock.Any(), secret).
+			Return(nil, storage.ErrVersionConflict)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.UpdateSecret(
+			context.Background(),
+			&pb.UpdateSecretRequest{
+				Name:            secret.Name,
+				Content:         secret.Content,
+				ExpectedVersion: secret.Version.String(),
+			},
+		)
+		checkErrorStatus(t, err, codes.Aborted)
+	})
 
 	t.Run("SuccessfulUpdateSecret", func(t *testing.T) {
 		secret := &models.Secret{
@@ -512,6 +563,33 @@ func TestSecretService_DeleteSecret(t *testing.T) {
 		)
 		checkErrorStatus(t, err, codes.Internal)
 	})
+	t.Run("SecretNotFound", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:    "SecretName",
+			OwnerID: userID,
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: secret.OwnerID}, nil)
+
+		secretStorage.
+			EXPECT().
+			DeleteSecret(gomock.Any(), secret).
+			Return(storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.DeleteSecret(
+			context.Background(),
+			&pb.DeleteSecretRequest{
+				Name: secret.Name,
+			},
+		)
+		checkErrorStatus(t, err, codes.NotFound)
+	})
 	t.Run("SuccessfulDeleteSecret", func(t *testing.T) {
 		secret := &models.Secret{
 			Name:    "SecretName",
@@ -630,3 +708,573 @@ func TestSecretService_ListSecrets(t *testing.T) {
 		}
 	})
 }
+
+func TestSecretService_RenameSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 0
+
+	t.Run("EmptySecretName", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RenameSecret(
+			context.Background(),
+			&pb.RenameSecretRequest{Name: "", NewName: "NewName"},
+		)
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SameSecretName", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RenameSecret(
+			context.Background(),
+			&pb.RenameSecretRequest{Name: "Name", NewName: "Name"},
+		)
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SecretNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			RenameSecret(gomock.Any(), "Name", "NewName", userID).
+			Return(nil, storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RenameSecret(
+			context.Background(),
+			&pb.RenameSecretRequest{Name: "Name", NewName: "NewName"},
+		)
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SecretAlreadyExists", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			RenameSecret(gomock.Any(), "Name", "NewName", userID).
+			Return(nil, storage.ErrSecretConflict)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RenameSecret(
+			context.Background(),
+			&pb.RenameSecretRequest{Name: "Name", NewName: "NewName"},
+		)
+		checkErrorStatus(t, err, codes.AlreadyExists)
+	})
+	t.Run("StorageError", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			RenameSecret(gomock.Any(), "Name", "NewName", userID).
+			Return(nil, errors.New("some error"))
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RenameSecret(
+			context.Background(),
+			&pb.RenameSecretRequest{Name: "Name", NewName: "NewName"},
+		)
+		checkErrorStatus(t, err, codes.Internal)
+	})
+	t.Run("SuccessfulRenameSecret", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:    "NewName",
+			Content: []byte("SecretContent"),
+			Version: uuid.New(),
+			OwnerID: userID,
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			RenameSecret(gomock.Any(), "Name", secret.Name, userID).
+			Return(secret, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.RenameSecret(
+			context.Background(),
+			&pb.RenameSecretRequest{Name: "Name", NewName: secret.Name},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Name, resp.Name)
+		assert.Equal(t, secret.Version.String(), resp.Version)
+	})
+}
+
+func TestSecretService_CopySecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 0
+
+	t.Run("EmptyNewSecretName", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.CopySecret(
+			context.Background(),
+			&pb.CopySecretRequest{Name: "Name", NewName: ""},
+		)
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SecretNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			CopySecret(gomock.Any(), "Name", "NewName", userID).
+			Return(nil, storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.CopySecret(
+			context.Background(),
+			&pb.CopySecretRequest{Name: "Name", NewName: "NewName"},
+		)
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SecretAlreadyExists", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			CopySecret(gomock.Any(), "Name", "NewName", userID).
+			Return(nil, storage.ErrSecretConflict)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.CopySecret(
+			context.Background(),
+			&pb.CopySecretRequest{Name: "Name", NewName: "NewName"},
+		)
+		checkErrorStatus(t, err, codes.AlreadyExists)
+	})
+	t.Run("StorageError", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			CopySecret(gomock.Any(), "Name", "NewName", userID).
+			Return(nil, errors.New("some error"))
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.CopySecret(
+			context.Background(),
+			&pb.CopySecretRequest{Name: "Name", NewName: "NewName"},
+		)
+		checkErrorStatus(t, err, codes.Internal)
+	})
+	t.Run("SuccessfulCopySecret", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:    "NewName",
+			Content: []byte("SecretContent"),
+			Version: uuid.New(),
+			OwnerID: userID,
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			CopySecret(gomock.Any(), "Name", secret.Name, userID).
+			Return(secret, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.CopySecret(
+			context.Background(),
+			&pb.CopySecretRequest{Name: "Name", NewName: secret.Name},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Name, resp.Name)
+		assert.Equal(t, secret.Version.String(), resp.Version)
+	})
+}
+
+func TestSecretService_ListDeletedSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage:  secretStorage,
+		TrashRetention: time.Hour,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 0
+
+	t.Run("StorageError", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			ListDeletedSecrets(gomock.Any(), userID).
+			Return(nil, errors.New("some error"))
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.ListDeletedSecrets(context.Background(), &pb.ListDeletedSecretsRequest{})
+		checkErrorStatus(t, err, codes.Internal)
+	})
+
+	t.Run("SuccessfulListDeletedSecrets", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:      "Name",
+			Version:   uuid.New(),
+			DeletedAt: time.Now().UTC(),
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			ListDeletedSecrets(gomock.Any(), userID).
+			Return([]*models.Secret{secret}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.ListDeletedSecrets(context.Background(), &pb.ListDeletedSecretsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Secrets, 1)
+		assert.Equal(t, secret.Name, resp.Secrets[0].Name)
+		assert.Equal(t, secret.Version.String(), resp.Secrets[0].Version)
+		assert.Equal(t, secret.DeletedAt, resp.Secrets[0].DeletedAt.AsTime())
+		assert.Equal(t, secret.DeletedAt.Add(time.Hour), resp.Secrets[0].PurgeAt.AsTime())
+	})
+}
+
+func TestSecretService_RestoreSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 0
+	secretName := "SecretName"
+
+	t.Run("EmptySecretName", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RestoreSecret(context.Background(), &pb.RestoreSecretRequest{Name: ""})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SecretNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			RestoreSecret(gomock.Any(), secretName, userID).
+			Return(nil, storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RestoreSecret(context.Background(), &pb.RestoreSecretRequest{Name: secretName})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SecretAlreadyExists", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			RestoreSecret(gomock.Any(), secretName, userID).
+			Return(nil, storage.ErrSecretConflict)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.RestoreSecret(context.Background(), &pb.RestoreSecretRequest{Name: secretName})
+		checkErrorStatus(t, err, codes.AlreadyExists)
+	})
+	t.Run("SuccessfulRestoreSecret", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:    secretName,
+			Version: uuid.New(),
+			OwnerID: userID,
+		}
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			RestoreSecret(gomock.Any(), secretName, userID).
+			Return(secret, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.RestoreSecret(context.Background(), &pb.RestoreSecretRequest{Name: secretName})
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Name, resp.Name)
+		assert.Equal(t, secret.Version.String(), resp.Version)
+	})
+}
+
+func TestSecretService_PurgeSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 0
+	secretName := "SecretName"
+
+	t.Run("EmptySecretName", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PurgeSecret(context.Background(), &pb.PurgeSecretRequest{Name: ""})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SecretNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			PurgeSecret(gomock.Any(), secretName, userID).
+			Return(storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PurgeSecret(context.Background(), &pb.PurgeSecretRequest{Name: secretName})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SuccessfulPurgeSecret", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			PurgeSecret(gomock.Any(), secretName, userID).
+			Return(nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.PurgeSecret(context.Background(), &pb.PurgeSecretRequest{Name: secretName})
+		assert.NoError(t, err)
+		assert.Equal(t, secretName, resp.Name)
+	})
+}
+
+func TestSecretService_RunTrashCleaner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	secretService := &SecretService{
+		SecretStorage:  secretStorage,
+		TrashRetention: time.Hour,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	secretStorage.
+		EXPECT().
+		PurgeExpiredSecrets(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, deletedBefore time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), deletedBefore, time.Second)
+			cancel()
+			return 1, nil
+		}).
+		MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		secretService.RunTrashCleaner(ctx, time.Millisecond)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("trash cleaner did not stop")
+	}
+}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
+)
+
+// PutKeyPair сохраняет пару ключей пользователя, используемую для обмена секретами
+func (srv *SecretService) PutKeyPair(
+	ctx context.Context,
+	request *pb.PutKeyPairRequest,
+) (*pb.PutKeyPairResponse, error) {
+	if len(request.GetPublicKey()) != x25519.KeySize {
+		return nil, status.Error(codes.InvalidArgument, "invalid public key")
+	}
+	if len(request.GetEncryptedPrivateKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty private key")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	err := srv.UserStorage.PutKeyPair(ctx, userID, &models.KeyPair{
+		PublicKey:           request.GetPublicKey(),
+		EncryptedPrivateKey: request.GetEncryptedPrivateKey(),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyPairConflict) {
+			return nil, status.Error(codes.AlreadyExists, "key pair already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to put key pair")
+	}
+	return &pb.PutKeyPairResponse{}, nil
+}
+
+// GetKeyPair возвращает пару ключей пользователя
+func (srv *SecretService) GetKeyPair(
+	ctx context.Context,
+	_ *pb.GetKeyPairRequest,
+) (*pb.GetKeyPairResponse, error) {
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	keyPair, err := srv.UserStorage.GetKeyPair(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyPairNotFound) {
+			return nil, status.Error(codes.NotFound, "key pair not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get key pair")
+	}
+	return &pb.GetKeyPairResponse{
+		PublicKey:           keyPair.PublicKey,
+		EncryptedPrivateKey: keyPair.EncryptedPrivateKey,
+	}, nil
+}
+
+// GetPublicKey возвращает открытый ключ пользователя с указанным адресом электронной почты
+func (srv *SecretService) GetPublicKey(
+	ctx context.Context,
+	request *pb.GetPublicKeyRequest,
+) (*pb.GetPublicKeyResponse, error) {
+	if request.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty email")
+	}
+
+	publicKey, err := srv.UserStorage.GetPublicKey(ctx, request.GetEmail())
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyPairNotFound) {
+			return nil, status.Error(codes.NotFound, "public key not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get public key")
+	}
+	return &pb.GetPublicKeyResponse{
+		Email:     request.GetEmail(),
+		PublicKey: publicKey,
+	}, nil
+}
+
+// ShareSecret предоставляет другому пользователю доступ к секрету.
+// Ключ данных секрета передается зашифрованным открытым ключом получателя
+func (srv *SecretService) ShareSecret(
+	ctx context.Context,
+	request *pb.ShareSecretRequest,
+) (resp *pb.ShareSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionShareSecret,
+			SecretName: request.GetName(),
+		}, err)
+	}()
+
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty secret name")
+	}
+	if request.GetRecipient() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty recipient")
+	}
+	if len(request.GetWrappedKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty wrapped key")
+	}
+	permission, ok := permissionFromProto(request.GetPermission())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid permission")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	err = srv.SecretStorage.ShareSecret(ctx, &models.Share{
+		SecretName:     request.GetName(),
+		OwnerID:        userID,
+		RecipientEmail: request.GetRecipient(),
+		WrappedKey:     request.GetWrappedKey(),
+		Permission:     permission,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret or recipient not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to share secret")
+	}
+	return &pb.ShareSecretResponse{
+		Name:      request.GetName(),
+		Recipient: request.GetRecipient(),
+	}, nil
+}
+
+// UnshareSecret отзывает у пользователя доступ к секрету
+func (srv *SecretService) UnshareSecret(
+	ctx context.Context,
+	request *pb.UnshareSecretRequest,
+) (resp *pb.UnshareSecretResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionUnshareSecret,
+			SecretName: request.GetName(),
+		}, err)
+	}()
+
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty secret name")
+	}
+	if request.GetRecipient() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty recipient")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	err = srv.SecretStorage.UnshareSecret(ctx, request.GetName(), userID, request.GetRecipient())
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "share not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to unshare secret")
+	}
+	return &pb.UnshareSecretResponse{
+		Name:      request.GetName(),
+		Recipient: request.GetRecipient(),
+	}, nil
+}
+
+// ListSecretShares возвращает список пользователей, которым доступен секрет
+func (srv *SecretService) ListSecretShares(
+	ctx context.Context,
+	request *pb.ListSecretSharesRequest,
+) (resp *pb.ListSecretSharesResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action:     models.AuditActionListSecretShares,
+			SecretName: request.GetName(),
+		}, err)
+	}()
+
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty secret name")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	shares, err := srv.SecretStorage.ListSecretShares(ctx, request.GetName(), userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list secret shares")
+	}
+
+	pbShares := make([]*pb.SecretShareInfo, 0, len(shares))
+	for _, share := range shares {
+		pbShares = append(pbShares, &pb.SecretShareInfo{
+			Recipient:  share.RecipientEmail,
+			Permission: permissionToProto(share.Permission),
+		})
+	}
+	return &pb.ListSecretSharesResponse{
+		Shares: pbShares,
+	}, nil
+}
+
+// ListSharedWithMe возвращает список секретов других пользователей, доступных пользователю
+func (srv *SecretService) ListSharedWithMe(
+	ctx context.Context,
+	_ *pb.ListSharedWithMeRequest,
+) (resp *pb.ListSharedWithMeResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionListSharedSecrets,
+		}, err)
+	}()
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	secrets, err := srv.SecretStorage.ListSharedSecrets(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list shared secrets")
+	}
+
+	pbSecrets := make([]*pb.SharedSecretInfo, 0, len(secrets))
+	for _, secret := range secrets {
+		pbSecrets = append(pbSecrets, &pb.SharedSecretInfo{
+			Owner:      secret.OwnerEmail,
+			Name:       secret.Name,
+			Content:    secret.Content,
+			Version:    secret.Version.String(),
+			WrappedKey: secret.WrappedKey,
+			Permission: permissionToProto(secret.Permission),
+		})
+	}
+	return &pb.ListSharedWithMeResponse{
+		Secrets: pbSecrets,
+	}, nil
+}
+
+func (srv *SecretService) getSharedSecret(
+	ctx context.Context,
+	name, owner string,
+	userID int,
+) (*pb.GetSecretResponse, error) {
+	secret, err := srv.SecretStorage.GetSharedSecret(ctx, name, owner, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get secret")
+	}
+
+	return &pb.GetSecretResponse{
+		Name:       secret.Name,
+		Content:    secret.Content,
+		Version:    secret.Version.String(),
+		DataKey:    secret.WrappedKey,
+		Permission: permissionToProto(secret.Permission),
+	}, nil
+}
+
+func (srv *SecretService) updateSharedSecret(
+	ctx context.Context,
+	request *pb.UpdateSecretRequest,
+	userID int,
+) (*pb.UpdateSecretResponse, error) {
+	secret, err := srv.SecretStorage.GetSharedSecret(ctx, request.GetName(), request.GetOwner(), userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to update secret")
+	}
+	if secret.Permission != models.PermissionReadWrite {
+		return nil, status.Error(codes.PermissionDenied, "secret is shared read-only")
+	}
+
+	secret.Content = request.GetContent()
+	secret, err = srv.SecretStorage.UpdateSharedSecret(ctx, secret)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to update secret")
+	}
+	return &pb.UpdateSecretResponse{
+		Name:    request.GetName(),
+		Version: secret.Version.String(),
+	}, nil
+}
+
+func permissionFromProto(permission pb.Permission) (models.Permission, bool) {
+	switch permission {
+	case pb.Permission_PERMISSION_READ:
+		return models.PermissionRead, true
+	case pb.Permission_PERMISSION_READ_WRITE:
+		return models.PermissionReadWrite, true
+	default:
+		return "", false
+	}
+}
+
+func permissionToProto(permission models.Permission) pb.Permission {
+	switch permission {
+	case models.PermissionRead:
+		return pb.Permission_PERMISSION_READ
+	case models.PermissionReadWrite:
+		return pb.Permission_PERMISSION_READ_WRITE
+	default:
+		return pb.Permission_PERMISSION_UNSPECIFIED
+	}
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	serverInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	ms "github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/mock"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/x25519"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+	mt "github.com/go-developer-ya-practicum/gophkeeper/pkg/token/mock"
+)
+
+func TestSecretService_KeyPair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := ms.NewMockUserStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		UserStorage: userStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	publicKey, _, err := x25519.GenerateKeyPair()
+	require.NoError(t, err)
+	keyPair := &models.KeyPair{
+		PublicKey:           publicKey,
+		EncryptedPrivateKey: []byte("EncryptedPrivateKey"),
+	}
+
+	t.Run("InvalidPublicKey", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutKeyPair(context.Background(), &pb.PutKeyPairRequest{
+			PublicKey:           []byte("short"),
+			EncryptedPrivateKey: keyPair.EncryptedPrivateKey,
+		})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("KeyPairExists", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		userStorage.
+			EXPECT().
+			PutKeyPair(gomock.Any(), userID, keyPair).
+			Return(storage.ErrKeyPairConflict)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutKeyPair(context.Background(), &pb.PutKeyPairRequest{
+			PublicKey:           keyPair.PublicKey,
+			EncryptedPrivateKey: keyPair.EncryptedPrivateKey,
+		})
+		checkErrorStatus(t, err, codes.AlreadyExists)
+	})
+	t.Run("SuccessfulGetKeyPair", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		userStorage.
+			EXPECT().
+			GetKeyPair(gomock.Any(), userID).
+			Return(keyPair, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.GetKeyPair(context.Background(), &pb.GetKeyPairRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, keyPair.PublicKey, resp.GetPublicKey())
+		assert.Equal(t, keyPair.EncryptedPrivateKey, resp.GetEncryptedPrivateKey())
+	})
+	t.Run("PublicKeyNotFound", func(t *testing.T) {
+		email := "recipient@mail.ru"
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		userStorage.
+			EXPECT().
+			GetPublicKey(gomock.Any(), email).
+			Return(nil, storage.ErrKeyPairNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetPublicKey(context.Background(), &pb.GetPublicKeyRequest{Email: email})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+}
+
+func TestSecretService_ShareSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	request := &pb.ShareSecretRequest{
+		Name:       "SecretName",
+		Recipient:  "recipient@mail.ru",
+		WrappedKey: []byte("WrappedKey"),
+		Permission: pb.Permission_PERMISSION_READ_WRITE,
+	}
+	share := &models.Share{
+		SecretName:     request.GetName(),
+		OwnerID:        userID,
+		RecipientEmail: request.GetRecipient(),
+		WrappedKey:     request.GetWrappedKey(),
+		Permission:     models.PermissionReadWrite,
+	}
+
+	t.Run("InvalidPermission", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.ShareSecret(context.Background(), &pb.ShareSecretRequest{
+			Name:       request.GetName(),
+			Recipient:  request.GetRecipient(),
+			WrappedKey: request.GetWrappedKey(),
+		})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SecretOrRecipientNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			ShareSecret(gomock.Any(), share).
+			Return(storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.ShareSecret(context.Background(), request)
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SuccessfulShareSecret", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			ShareSecret(gomock.Any(), share).
+			Return(nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.ShareSecret(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, request.GetName(), resp.GetName())
+		assert.Equal(t, request.GetRecipient(), resp.GetRecipient())
+	})
+	t.Run("ShareNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			UnshareSecret(gomock.Any(), request.GetName(), userID, request.GetRecipient()).
+			Return(storage.ErrSecretNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.UnshareSecret(context.Background(), &pb.UnshareSecretRequest{
+			Name:      request.GetName(),
+			Recipient: request.GetRecipient(),
+		})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SuccessfulListSecretShares", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			ListSecretShares(gomock.Any(), request.GetName(), userID).
+			Return([]*models.Share{share}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.ListSecretShares(context.Background(), &pb.ListSecretSharesRequest{
+			Name: request.GetName(),
+		})
+		assert.NoError(t, err)
+		require.Len(t, resp.GetShares(), 1)
+		assert.Equal(t, request.GetRecipient(), resp.GetShares()[0].GetRecipient())
+		assert.Equal(t, pb.Permission_PERMISSION_READ_WRITE, resp.GetShares()[0].GetPermission())
+	})
+}
+
+func TestSecretService_SharedSecretAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 2
+	shared := func(permission models.Permission) *models.SharedSecret {
+		return &models.SharedSecret{
+			Name:        "SecretName",
+			Content:     []byte("SecretContent"),
+			Version:     uuid.New(),
+			OwnerEmail:  "owner@mail.ru",
+			RecipientID: userID,
+			WrappedKey:  []byte("WrappedKey"),
+			Permission:  permission,
+		}
+	}
+
+	t.Run("SuccessfulGetSharedSecret", func(t *testing.T) {
+		secret := shared(models.PermissionRead)
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			GetSharedSecret(gomock.Any(), secret.Name, secret.OwnerEmail, userID).
+			Return(secret, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.GetSecret(context.Background(), &pb.GetSecretRequest{
+			Name:  secret.Name,
+			Owner: secret.OwnerEmail,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Content, resp.GetContent())
+		assert.Equal(t, secret.WrappedKey, resp.GetDataKey())
+		assert.Equal(t, pb.Permission_PERMISSION_READ, resp.GetPermission())
+	})
+	t.Run("UpdateReadOnlySecret", func(t *testing.T) {
+		secret := shared(models.PermissionRead)
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			GetSharedSecret(gomock.Any(), secret.Name, secret.OwnerEmail, userID).
+			Return(secret, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
+			Name:    secret.Name,
+			Content: []byte("NewContent"),
+			Owner:   secret.OwnerEmail,
+		})
+		checkErrorStatus(t, err, codes.PermissionDenied)
+	})
+	t.Run("SuccessfulUpdateSharedSecret", func(t *testing.T) {
+		secret := shared(models.PermissionReadWrite)
+		newVersion := uuid.New()
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			GetSharedSecret(gomock.Any(), secret.Name, secret.OwnerEmail, userID).
+			Return(secret, nil)
+		secretStorage.
+			EXPECT().
+			UpdateSharedSecret(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, s *models.SharedSecret) (*models.SharedSecret, error) {
+				assert.Equal(t, []byte("NewContent"), s.Content)
+				s.Version = newVersion
+				return s, nil
+			})
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.UpdateSecret(context.Background(), &pb.UpdateSecretRequest{
+			Name:    secret.Name,
+			Content: []byte("NewContent"),
+			Owner:   secret.OwnerEmail,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, newVersion.String(), resp.GetVersion())
+	})
+	t.Run("SuccessfulListSharedWithMe", func(t *testing.T) {
+		secret := shared(models.PermissionReadWrite)
+
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			ListSharedSecrets(gomock.Any(), userID).
+			Return([]*models.SharedSecret{secret}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{})
+		assert.NoError(t, err)
+		require.Len(t, resp.GetSecrets(), 1)
+		assert.Equal(t, secret.OwnerEmail, resp.GetSecrets()[0].GetOwner())
+		assert.Equal(t, secret.Name, resp.GetSecrets()[0].GetName())
+		assert.Equal(t, secret.WrappedKey, resp.GetSecrets()[0].GetWrappedKey())
+	})
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+// PutTemplate создает или заменяет шаблон секретов пользователя.
+// Описание полей шаблона зашифровано на клиенте ключом данных, зашифрованным мастер-ключом
+func (srv *SecretService) PutTemplate(
+	ctx context.Context,
+	request *pb.PutTemplateRequest,
+) (resp *pb.PutTemplateResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionPutTemplate,
+			Target: request.GetName(),
+		}, err)
+	}()
+
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty template name")
+	}
+	if len(request.GetContent()) == 0 || len(request.GetDataKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty template content")
+	}
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	err = srv.SecretStorage.PutTemplate(ctx, &models.Template{
+		Name:    request.GetName(),
+		Content: request.GetContent(),
+		DataKey: request.GetDataKey(),
+		OwnerID: userID,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to put template")
+	}
+	return &pb.PutTemplateResponse{
+		Name: request.GetName(),
+	}, nil
+}
+
+// GetTemplate возвращает шаблон секретов пользователя по названию
+func (srv *SecretService) GetTemplate(
+	ctx context.Context,
+	request *pb.GetTemplateRequest,
+) (*pb.GetTemplateResponse, error) {
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	template, err := srv.SecretStorage.GetTemplate(ctx, request.GetName(), userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTemplateNotFound) {
+			return nil, status.Error(codes.NotFound, "template not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get template")
+	}
+	return &pb.GetTemplateResponse{
+		Template: templateToProto(template),
+	}, nil
+}
+
+// ListTemplates возвращает список шаблонов секретов пользователя
+func (srv *SecretService) ListTemplates(
+	ctx context.Context,
+	_ *pb.ListTemplatesRequest,
+) (*pb.ListTemplatesResponse, error) {
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	templates, err := srv.SecretStorage.ListTemplates(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list templates")
+	}
+
+	pbTemplates := make([]*pb.TemplateInfo, 0, len(templates))
+	for _, template := range templates {
+		pbTemplates = append(pbTemplates, templateToProto(template))
+	}
+	return &pb.ListTemplatesResponse{
+		Templates: pbTemplates,
+	}, nil
+}
+
+// DeleteTemplate удаляет шаблон секретов пользователя. Созданные по шаблону секреты не изменяются
+func (srv *SecretService) DeleteTemplate(
+	ctx context.Context,
+	request *pb.DeleteTemplateRequest,
+) (resp *pb.DeleteTemplateResponse, err error) {
+	defer func() {
+		writeAuditEvent(ctx, srv.AuditStorage, &models.AuditEvent{
+			Action: models.AuditActionDeleteTemplate,
+			Target: request.GetName(),
+		}, err)
+	}()
+
+	userID, ok := ctx.Value(interceptors.ContextKeyUserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	if err = srv.SecretStorage.DeleteTemplate(ctx, request.GetName(), userID); err != nil {
+		if errors.Is(err, storage.ErrTemplateNotFound) {
+			return nil, status.Error(codes.NotFound, "template not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete template")
+	}
+	return &pb.DeleteTemplateResponse{
+		Name: request.GetName(),
+	}, nil
+}
+
+func templateToProto(template *models.Template) *pb.TemplateInfo {
+	return &pb.TemplateInfo{
+		Name:    template.Name,
+		Content: template.Content,
+		DataKey: template.DataKey,
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/go-developer-ya-practicum/gophkeeper/internal/proto"
+	serverInterceptors "github.com/go-developer-ya-practicum/gophkeeper/internal/server/interceptors"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+	ms "github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage/mock"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/token"
+	mt "github.com/go-developer-ya-practicum/gophkeeper/pkg/token/mock"
+)
+
+func TestSecretService_Templates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secretStorage := ms.NewMockSecretStorage(ctrl)
+	tokenManager := mt.NewMockManager(ctrl)
+
+	secretService := &SecretService{
+		SecretStorage: secretStorage,
+	}
+
+	interceptor := serverInterceptors.NewAuthInterceptor(tokenManager)
+
+	server := NewServer(
+		address,
+		WithServices(secretService),
+		WithUnaryInterceptors(interceptor.Unary()),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go server.Run(ctx)
+
+	accessToken := "Token"
+	userID := 1
+	template := &models.Template{
+		Name:    "database",
+		Content: []byte("Content"),
+		DataKey: []byte("DataKey"),
+		OwnerID: userID,
+	}
+
+	t.Run("PutEmptyName", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.PutTemplate(context.Background(), &pb.PutTemplateRequest{
+			Content: template.Content,
+			DataKey: template.DataKey,
+		})
+		checkErrorStatus(t, err, codes.InvalidArgument)
+	})
+	t.Run("SuccessfulPut", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			PutTemplate(gomock.Any(), template).
+			Return(nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.PutTemplate(context.Background(), &pb.PutTemplateRequest{
+			Name:    template.Name,
+			Content: template.Content,
+			DataKey: template.DataKey,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, template.Name, resp.GetName())
+	})
+	t.Run("GetNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			GetTemplate(gomock.Any(), "unknown", userID).
+			Return(nil, storage.ErrTemplateNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.GetTemplate(context.Background(), &pb.GetTemplateRequest{Name: "unknown"})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+	t.Run("SuccessfulList", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			ListTemplates(gomock.Any(), userID).
+			Return([]*models.Template{template}, nil)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		resp, err := client.ListTemplates(context.Background(), &pb.ListTemplatesRequest{})
+		assert.NoError(t, err)
+		require.Len(t, resp.GetTemplates(), 1)
+		assert.Equal(t, template.Name, resp.GetTemplates()[0].GetName())
+		assert.Equal(t, template.Content, resp.GetTemplates()[0].GetContent())
+		assert.Equal(t, template.DataKey, resp.GetTemplates()[0].GetDataKey())
+	})
+	t.Run("DeleteNotFound", func(t *testing.T) {
+		tokenManager.
+			EXPECT().
+			Validate(accessToken).
+			Return(&token.Payload{UserID: userID}, nil)
+
+		secretStorage.
+			EXPECT().
+			DeleteTemplate(gomock.Any(), template.Name, userID).
+			Return(storage.ErrTemplateNotFound)
+
+		client, err := newSecretClient(accessToken)
+		require.NoError(t, err)
+
+		_, err = client.DeleteTemplate(context.Background(), &pb.DeleteTemplateRequest{Name: template.Name})
+		checkErrorStatus(t, err, codes.NotFound)
+	})
+}
@@ -7,9 +7,11 @@ package mock_storage
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockUserStorage is a mock of UserStorage interface.
@@ -35,6 +37,36 @@ func (m *MockUserStorage) EXPECT() *MockUserStorageMockRecorder {
 	return m.recorder
 }
 
+// GetKeyPair mocks base method.
+func (m *MockUserStorage) GetKeyPair(ctx context.Context, userID int) (*models.KeyPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyPair", ctx, userID)
+	ret0, _ := ret[0].(*models.KeyPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyPair indicates an expected call of GetKeyPair.
+func (mr *MockUserStorageMockRecorder) GetKeyPair(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyPair", reflect.TypeOf((*MockUserStorage)(nil).GetKeyPair), ctx, userID)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockUserStorage) GetPublicKey(ctx context.Context, email string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", ctx, email)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockUserStorageMockRecorder) GetPublicKey(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockUserStorage)(nil).GetPublicKey), ctx, email)
+}
+
 // GetUser mocks base method.
 func (m *MockUserStorage) GetUser(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +82,20 @@ func (mr *MockUserStorageMockRecorder) GetUser(ctx, user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserStorage)(nil).GetUser), ctx, user)
 }
 
+// PutKeyPair mocks base method.
+func (m *MockUserStorage) PutKeyPair(ctx context.Context, userID int, keyPair *models.KeyPair) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutKeyPair", ctx, userID, keyPair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutKeyPair indicates an expected call of PutKeyPair.
+func (mr *MockUserStorageMockRecorder) PutKeyPair(ctx, userID, keyPair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutKeyPair", reflect.TypeOf((*MockUserStorage)(nil).PutKeyPair), ctx, userID, keyPair)
+}
+
 // PutUser mocks base method.
 func (m *MockUserStorage) PutUser(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +134,36 @@ func (m *MockSecretStorage) EXPECT() *MockSecretStorageMockRecorder {
 	return m.recorder
 }
 
+// CopySecret mocks base method.
+func (m *MockSecretStorage) CopySecret(ctx context.Context, name, newName string, userID int) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopySecret", ctx, name, newName, userID)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopySecret indicates an expected call of CopySecret.
+func (mr *MockSecretStorageMockRecorder) CopySecret(ctx, name, newName, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopySecret", reflect.TypeOf((*MockSecretStorage)(nil).CopySecret), ctx, name, newName, userID)
+}
+
+// CreateCollectionSecret mocks base method.
+func (m *MockSecretStorage) CreateCollectionSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollectionSecret", ctx, secret)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollectionSecret indicates an expected call of CreateCollectionSecret.
+func (mr *MockSecretStorageMockRecorder) CreateCollectionSecret(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollectionSecret", reflect.TypeOf((*MockSecretStorage)(nil).CreateCollectionSecret), ctx, secret)
+}
+
 // CreateSecret mocks base method.
 func (m *MockSecretStorage) CreateSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +179,34 @@ func (mr *MockSecretStorageMockRecorder) CreateSecret(ctx, secret interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretStorage)(nil).CreateSecret), ctx, secret)
 }
 
+// DeleteBlob mocks base method.
+func (m *MockSecretStorage) DeleteBlob(ctx context.Context, secretName string, id uuid.UUID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlob", ctx, secretName, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlob indicates an expected call of DeleteBlob.
+func (mr *MockSecretStorageMockRecorder) DeleteBlob(ctx, secretName, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlob", reflect.TypeOf((*MockSecretStorage)(nil).DeleteBlob), ctx, secretName, id, userID)
+}
+
+// DeleteCollectionSecret mocks base method.
+func (m *MockSecretStorage) DeleteCollectionSecret(ctx context.Context, secret *models.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionSecret", ctx, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionSecret indicates an expected call of DeleteCollectionSecret.
+func (mr *MockSecretStorageMockRecorder) DeleteCollectionSecret(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionSecret", reflect.TypeOf((*MockSecretStorage)(nil).DeleteCollectionSecret), ctx, secret)
+}
+
 // DeleteSecret mocks base method.
 func (m *MockSecretStorage) DeleteSecret(ctx context.Context, secret *models.Secret) error {
 	m.ctrl.T.Helper()
@@ -117,6 +221,50 @@ func (mr *MockSecretStorageMockRecorder) DeleteSecret(ctx, secret interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretStorage)(nil).DeleteSecret), ctx, secret)
 }
 
+// DeleteTemplate mocks base method.
+func (m *MockSecretStorage) DeleteTemplate(ctx context.Context, name string, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, name, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockSecretStorageMockRecorder) DeleteTemplate(ctx, name, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockSecretStorage)(nil).DeleteTemplate), ctx, name, userID)
+}
+
+// GetBlob mocks base method.
+func (m *MockSecretStorage) GetBlob(ctx context.Context, secretName string, id uuid.UUID, userID int) (*models.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlob", ctx, secretName, id, userID)
+	ret0, _ := ret[0].(*models.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlob indicates an expected call of GetBlob.
+func (mr *MockSecretStorageMockRecorder) GetBlob(ctx, secretName, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlob", reflect.TypeOf((*MockSecretStorage)(nil).GetBlob), ctx, secretName, id, userID)
+}
+
+// GetCollectionSecret mocks base method.
+func (m *MockSecretStorage) GetCollectionSecret(ctx context.Context, name string, collectionID int) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionSecret", ctx, name, collectionID)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionSecret indicates an expected call of GetCollectionSecret.
+func (mr *MockSecretStorageMockRecorder) GetCollectionSecret(ctx, name, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionSecret", reflect.TypeOf((*MockSecretStorage)(nil).GetCollectionSecret), ctx, name, collectionID)
+}
+
 // GetSecret mocks base method.
 func (m *MockSecretStorage) GetSecret(ctx context.Context, name string, userID int) (*models.Secret, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +280,96 @@ func (mr *MockSecretStorageMockRecorder) GetSecret(ctx, name, userID interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretStorage)(nil).GetSecret), ctx, name, userID)
 }
 
+// GetSharedSecret mocks base method.
+func (m *MockSecretStorage) GetSharedSecret(ctx context.Context, name, ownerEmail string, recipientID int) (*models.SharedSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedSecret", ctx, name, ownerEmail, recipientID)
+	ret0, _ := ret[0].(*models.SharedSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedSecret indicates an expected call of GetSharedSecret.
+func (mr *MockSecretStorageMockRecorder) GetSharedSecret(ctx, name, ownerEmail, recipientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedSecret", reflect.TypeOf((*MockSecretStorage)(nil).GetSharedSecret), ctx, name, ownerEmail, recipientID)
+}
+
+// GetTemplate mocks base method.
+func (m *MockSecretStorage) GetTemplate(ctx context.Context, name string, userID int) (*models.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, name, userID)
+	ret0, _ := ret[0].(*models.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockSecretStorageMockRecorder) GetTemplate(ctx, name, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockSecretStorage)(nil).GetTemplate), ctx, name, userID)
+}
+
+// ListCollectionSecrets mocks base method.
+func (m *MockSecretStorage) ListCollectionSecrets(ctx context.Context, collectionID int) ([]*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionSecrets", ctx, collectionID)
+	ret0, _ := ret[0].([]*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionSecrets indicates an expected call of ListCollectionSecrets.
+func (mr *MockSecretStorageMockRecorder) ListCollectionSecrets(ctx, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionSecrets", reflect.TypeOf((*MockSecretStorage)(nil).ListCollectionSecrets), ctx, collectionID)
+}
+
+// ListDeletedCollectionSecrets mocks base method.
+func (m *MockSecretStorage) ListDeletedCollectionSecrets(ctx context.Context, collectionID int) ([]*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedCollectionSecrets", ctx, collectionID)
+	ret0, _ := ret[0].([]*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedCollectionSecrets indicates an expected call of ListDeletedCollectionSecrets.
+func (mr *MockSecretStorageMockRecorder) ListDeletedCollectionSecrets(ctx, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedCollectionSecrets", reflect.TypeOf((*MockSecretStorage)(nil).ListDeletedCollectionSecrets), ctx, collectionID)
+}
+
+// ListDeletedSecrets mocks base method.
+func (m *MockSecretStorage) ListDeletedSecrets(ctx context.Context, userID int) ([]*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedSecrets", ctx, userID)
+	ret0, _ := ret[0].([]*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedSecrets indicates an expected call of ListDeletedSecrets.
+func (mr *MockSecretStorageMockRecorder) ListDeletedSecrets(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedSecrets", reflect.TypeOf((*MockSecretStorage)(nil).ListDeletedSecrets), ctx, userID)
+}
+
+// ListSecretShares mocks base method.
+func (m *MockSecretStorage) ListSecretShares(ctx context.Context, name string, ownerID int) ([]*models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretShares", ctx, name, ownerID)
+	ret0, _ := ret[0].([]*models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretShares indicates an expected call of ListSecretShares.
+func (mr *MockSecretStorageMockRecorder) ListSecretShares(ctx, name, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretShares", reflect.TypeOf((*MockSecretStorage)(nil).ListSecretShares), ctx, name, ownerID)
+}
+
 // ListSecrets mocks base method.
 func (m *MockSecretStorage) ListSecrets(ctx context.Context, userID int) ([]*models.Secret, error) {
 	m.ctrl.T.Helper()
@@ -147,6 +385,196 @@ func (mr *MockSecretStorageMockRecorder) ListSecrets(ctx, userID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockSecretStorage)(nil).ListSecrets), ctx, userID)
 }
 
+// ListSharedSecrets mocks base method.
+func (m *MockSecretStorage) ListSharedSecrets(ctx context.Context, recipientID int) ([]*models.SharedSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSharedSecrets", ctx, recipientID)
+	ret0, _ := ret[0].([]*models.SharedSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedSecrets indicates an expected call of ListSharedSecrets.
+func (mr *MockSecretStorageMockRecorder) ListSharedSecrets(ctx, recipientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedSecrets", reflect.TypeOf((*MockSecretStorage)(nil).ListSharedSecrets), ctx, recipientID)
+}
+
+// ListTemplates mocks base method.
+func (m *MockSecretStorage) ListTemplates(ctx context.Context, userID int) ([]*models.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, userID)
+	ret0, _ := ret[0].([]*models.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockSecretStorageMockRecorder) ListTemplates(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockSecretStorage)(nil).ListTemplates), ctx, userID)
+}
+
+// PurgeCollectionSecret mocks base method.
+func (m *MockSecretStorage) PurgeCollectionSecret(ctx context.Context, name string, collectionID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCollectionSecret", ctx, name, collectionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeCollectionSecret indicates an expected call of PurgeCollectionSecret.
+func (mr *MockSecretStorageMockRecorder) PurgeCollectionSecret(ctx, name, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCollectionSecret", reflect.TypeOf((*MockSecretStorage)(nil).PurgeCollectionSecret), ctx, name, collectionID)
+}
+
+// PurgeExpiredSecrets mocks base method.
+func (m *MockSecretStorage) PurgeExpiredSecrets(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredSecrets", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredSecrets indicates an expected call of PurgeExpiredSecrets.
+func (mr *MockSecretStorageMockRecorder) PurgeExpiredSecrets(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredSecrets", reflect.TypeOf((*MockSecretStorage)(nil).PurgeExpiredSecrets), ctx, deletedBefore)
+}
+
+// PurgeSecret mocks base method.
+func (m *MockSecretStorage) PurgeSecret(ctx context.Context, name string, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeSecret", ctx, name, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeSecret indicates an expected call of PurgeSecret.
+func (mr *MockSecretStorageMockRecorder) PurgeSecret(ctx, name, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeSecret", reflect.TypeOf((*MockSecretStorage)(nil).PurgeSecret), ctx, name, userID)
+}
+
+// PutBlob mocks base method.
+func (m *MockSecretStorage) PutBlob(ctx context.Context, blob *models.Blob) (*models.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBlob", ctx, blob)
+	ret0, _ := ret[0].(*models.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutBlob indicates an expected call of PutBlob.
+func (mr *MockSecretStorageMockRecorder) PutBlob(ctx, blob interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBlob", reflect.TypeOf((*MockSecretStorage)(nil).PutBlob), ctx, blob)
+}
+
+// PutTemplate mocks base method.
+func (m *MockSecretStorage) PutTemplate(ctx context.Context, template *models.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutTemplate indicates an expected call of PutTemplate.
+func (mr *MockSecretStorageMockRecorder) PutTemplate(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTemplate", reflect.TypeOf((*MockSecretStorage)(nil).PutTemplate), ctx, template)
+}
+
+// RenameSecret mocks base method.
+func (m *MockSecretStorage) RenameSecret(ctx context.Context, name, newName string, userID int) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameSecret", ctx, name, newName, userID)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameSecret indicates an expected call of RenameSecret.
+func (mr *MockSecretStorageMockRecorder) RenameSecret(ctx, name, newName, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameSecret", reflect.TypeOf((*MockSecretStorage)(nil).RenameSecret), ctx, name, newName, userID)
+}
+
+// RestoreCollectionSecret mocks base method.
+func (m *MockSecretStorage) RestoreCollectionSecret(ctx context.Context, name string, collectionID int) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCollectionSecret", ctx, name, collectionID)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCollectionSecret indicates an expected call of RestoreCollectionSecret.
+func (mr *MockSecretStorageMockRecorder) RestoreCollectionSecret(ctx, name, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCollectionSecret", reflect.TypeOf((*MockSecretStorage)(nil).RestoreCollectionSecret), ctx, name, collectionID)
+}
+
+// RestoreSecret mocks base method.
+func (m *MockSecretStorage) RestoreSecret(ctx context.Context, name string, userID int) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSecret", ctx, name, userID)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSecret indicates an expected call of RestoreSecret.
+func (mr *MockSecretStorageMockRecorder) RestoreSecret(ctx, name, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSecret", reflect.TypeOf((*MockSecretStorage)(nil).RestoreSecret), ctx, name, userID)
+}
+
+// ShareSecret mocks base method.
+func (m *MockSecretStorage) ShareSecret(ctx context.Context, share *models.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSecret", ctx, share)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockSecretStorageMockRecorder) ShareSecret(ctx, share interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockSecretStorage)(nil).ShareSecret), ctx, share)
+}
+
+// UnshareSecret mocks base method.
+func (m *MockSecretStorage) UnshareSecret(ctx context.Context, name string, ownerID int, recipientEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareSecret", ctx, name, ownerID, recipientEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareSecret indicates an expected call of UnshareSecret.
+func (mr *MockSecretStorageMockRecorder) UnshareSecret(ctx, name, ownerID, recipientEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareSecret", reflect.TypeOf((*MockSecretStorage)(nil).UnshareSecret), ctx, name, ownerID, recipientEmail)
+}
+
+// UpdateCollectionSecret mocks base method.
+func (m *MockSecretStorage) UpdateCollectionSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollectionSecret", ctx, secret)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollectionSecret indicates an expected call of UpdateCollectionSecret.
+func (mr *MockSecretStorageMockRecorder) UpdateCollectionSecret(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollectionSecret", reflect.TypeOf((*MockSecretStorage)(nil).UpdateCollectionSecret), ctx, secret)
+}
+
 // UpdateSecret mocks base method.
 func (m *MockSecretStorage) UpdateSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
 	m.ctrl.T.Helper()
@@ -161,3 +589,339 @@ func (mr *MockSecretStorageMockRecorder) UpdateSecret(ctx, secret interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretStorage)(nil).UpdateSecret), ctx, secret)
 }
+
+// UpdateSharedSecret mocks base method.
+func (m *MockSecretStorage) UpdateSharedSecret(ctx context.Context, secret *models.SharedSecret) (*models.SharedSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSharedSecret", ctx, secret)
+	ret0, _ := ret[0].(*models.SharedSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSharedSecret indicates an expected call of UpdateSharedSecret.
+func (mr *MockSecretStorageMockRecorder) UpdateSharedSecret(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSharedSecret", reflect.TypeOf((*MockSecretStorage)(nil).UpdateSharedSecret), ctx, secret)
+}
+
+// MockOrganizationStorage is a mock of OrganizationStorage interface.
+type MockOrganizationStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationStorageMockRecorder
+}
+
+// MockOrganizationStorageMockRecorder is the mock recorder for MockOrganizationStorage.
+type MockOrganizationStorageMockRecorder struct {
+	mock *MockOrganizationStorage
+}
+
+// NewMockOrganizationStorage creates a new mock instance.
+func NewMockOrganizationStorage(ctrl *gomock.Controller) *MockOrganizationStorage {
+	mock := &MockOrganizationStorage{ctrl: ctrl}
+	mock.recorder = &MockOrganizationStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationStorage) EXPECT() *MockOrganizationStorageMockRecorder {
+	return m.recorder
+}
+
+// AddTeamMember mocks base method.
+func (m *MockOrganizationStorage) AddTeamMember(ctx context.Context, organization, team, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeamMember", ctx, organization, team, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTeamMember indicates an expected call of AddTeamMember.
+func (mr *MockOrganizationStorageMockRecorder) AddTeamMember(ctx, organization, team, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockOrganizationStorage)(nil).AddTeamMember), ctx, organization, team, email)
+}
+
+// CreateCollection mocks base method.
+func (m *MockOrganizationStorage) CreateCollection(ctx context.Context, organization, collection string, creatorID int, wrappedKey []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, organization, collection, creatorID, wrappedKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockOrganizationStorageMockRecorder) CreateCollection(ctx, organization, collection, creatorID, wrappedKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockOrganizationStorage)(nil).CreateCollection), ctx, organization, collection, creatorID, wrappedKey)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizationStorage) CreateOrganization(ctx context.Context, name string, ownerID int) (*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, name, ownerID)
+	ret0, _ := ret[0].(*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationStorageMockRecorder) CreateOrganization(ctx, name, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizationStorage)(nil).CreateOrganization), ctx, name, ownerID)
+}
+
+// CreateTeam mocks base method.
+func (m *MockOrganizationStorage) CreateTeam(ctx context.Context, organization, team string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", ctx, organization, team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockOrganizationStorageMockRecorder) CreateTeam(ctx, organization, team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockOrganizationStorage)(nil).CreateTeam), ctx, organization, team)
+}
+
+// GetCollectionAccess mocks base method.
+func (m *MockOrganizationStorage) GetCollectionAccess(ctx context.Context, organization, collection string, userID int) (*models.CollectionAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionAccess", ctx, organization, collection, userID)
+	ret0, _ := ret[0].(*models.CollectionAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionAccess indicates an expected call of GetCollectionAccess.
+func (mr *MockOrganizationStorageMockRecorder) GetCollectionAccess(ctx, organization, collection, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionAccess", reflect.TypeOf((*MockOrganizationStorage)(nil).GetCollectionAccess), ctx, organization, collection, userID)
+}
+
+// GetRole mocks base method.
+func (m *MockOrganizationStorage) GetRole(ctx context.Context, organization string, userID int) (models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, organization, userID)
+	ret0, _ := ret[0].(models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockOrganizationStorageMockRecorder) GetRole(ctx, organization, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockOrganizationStorage)(nil).GetRole), ctx, organization, userID)
+}
+
+// GrantCollection mocks base method.
+func (m *MockOrganizationStorage) GrantCollection(ctx context.Context, organization, collection, team string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantCollection", ctx, organization, collection, team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantCollection indicates an expected call of GrantCollection.
+func (mr *MockOrganizationStorageMockRecorder) GrantCollection(ctx, organization, collection, team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantCollection", reflect.TypeOf((*MockOrganizationStorage)(nil).GrantCollection), ctx, organization, collection, team)
+}
+
+// ListCollections mocks base method.
+func (m *MockOrganizationStorage) ListCollections(ctx context.Context, organization string, userID int) ([]*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", ctx, organization, userID)
+	ret0, _ := ret[0].([]*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockOrganizationStorageMockRecorder) ListCollections(ctx, organization, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockOrganizationStorage)(nil).ListCollections), ctx, organization, userID)
+}
+
+// ListMembers mocks base method.
+func (m *MockOrganizationStorage) ListMembers(ctx context.Context, organization string) ([]*models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, organization)
+	ret0, _ := ret[0].([]*models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockOrganizationStorageMockRecorder) ListMembers(ctx, organization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockOrganizationStorage)(nil).ListMembers), ctx, organization)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockOrganizationStorage) ListOrganizations(ctx context.Context, userID int) ([]*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", ctx, userID)
+	ret0, _ := ret[0].([]*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockOrganizationStorageMockRecorder) ListOrganizations(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockOrganizationStorage)(nil).ListOrganizations), ctx, userID)
+}
+
+// ListPendingCollectionKeys mocks base method.
+func (m *MockOrganizationStorage) ListPendingCollectionKeys(ctx context.Context, collectionID int) ([]*models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingCollectionKeys", ctx, collectionID)
+	ret0, _ := ret[0].([]*models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingCollectionKeys indicates an expected call of ListPendingCollectionKeys.
+func (mr *MockOrganizationStorageMockRecorder) ListPendingCollectionKeys(ctx, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingCollectionKeys", reflect.TypeOf((*MockOrganizationStorage)(nil).ListPendingCollectionKeys), ctx, collectionID)
+}
+
+// ListTeams mocks base method.
+func (m *MockOrganizationStorage) ListTeams(ctx context.Context, organization string) ([]*models.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", ctx, organization)
+	ret0, _ := ret[0].([]*models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockOrganizationStorageMockRecorder) ListTeams(ctx, organization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockOrganizationStorage)(nil).ListTeams), ctx, organization)
+}
+
+// PutCollectionKey mocks base method.
+func (m *MockOrganizationStorage) PutCollectionKey(ctx context.Context, collectionID int, email string, wrappedKey []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutCollectionKey", ctx, collectionID, email, wrappedKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutCollectionKey indicates an expected call of PutCollectionKey.
+func (mr *MockOrganizationStorageMockRecorder) PutCollectionKey(ctx, collectionID, email, wrappedKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCollectionKey", reflect.TypeOf((*MockOrganizationStorage)(nil).PutCollectionKey), ctx, collectionID, email, wrappedKey)
+}
+
+// PutMember mocks base method.
+func (m *MockOrganizationStorage) PutMember(ctx context.Context, organization, email string, role models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMember", ctx, organization, email, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutMember indicates an expected call of PutMember.
+func (mr *MockOrganizationStorageMockRecorder) PutMember(ctx, organization, email, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMember", reflect.TypeOf((*MockOrganizationStorage)(nil).PutMember), ctx, organization, email, role)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationStorage) RemoveMember(ctx context.Context, organization, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, organization, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationStorageMockRecorder) RemoveMember(ctx, organization, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationStorage)(nil).RemoveMember), ctx, organization, email)
+}
+
+// RemoveTeamMember mocks base method.
+func (m *MockOrganizationStorage) RemoveTeamMember(ctx context.Context, organization, team, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeamMember", ctx, organization, team, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTeamMember indicates an expected call of RemoveTeamMember.
+func (mr *MockOrganizationStorageMockRecorder) RemoveTeamMember(ctx, organization, team, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamMember", reflect.TypeOf((*MockOrganizationStorage)(nil).RemoveTeamMember), ctx, organization, team, email)
+}
+
+// RevokeCollection mocks base method.
+func (m *MockOrganizationStorage) RevokeCollection(ctx context.Context, organization, collection, team string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCollection", ctx, organization, collection, team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCollection indicates an expected call of RevokeCollection.
+func (mr *MockOrganizationStorageMockRecorder) RevokeCollection(ctx, organization, collection, team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCollection", reflect.TypeOf((*MockOrganizationStorage)(nil).RevokeCollection), ctx, organization, collection, team)
+}
+
+// MockAuditStorage is a mock of AuditStorage interface.
+type MockAuditStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditStorageMockRecorder
+}
+
+// MockAuditStorageMockRecorder is the mock recorder for MockAuditStorage.
+type MockAuditStorageMockRecorder struct {
+	mock *MockAuditStorage
+}
+
+// NewMockAuditStorage creates a new mock instance.
+func NewMockAuditStorage(ctrl *gomock.Controller) *MockAuditStorage {
+	mock := &MockAuditStorage{ctrl: ctrl}
+	mock.recorder = &MockAuditStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditStorage) EXPECT() *MockAuditStorageMockRecorder {
+	return m.recorder
+}
+
+// ListEvents mocks base method.
+func (m *MockAuditStorage) ListEvents(ctx context.Context, userID int, from, to time.Time) ([]*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, userID, from, to)
+	ret0, _ := ret[0].([]*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockAuditStorageMockRecorder) ListEvents(ctx, userID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockAuditStorage)(nil).ListEvents), ctx, userID, from, to)
+}
+
+// PutEvent mocks base method.
+func (m *MockAuditStorage) PutEvent(ctx context.Context, event *models.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutEvent indicates an expected call of PutEvent.
+func (mr *MockAuditStorageMockRecorder) PutEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutEvent", reflect.TypeOf((*MockAuditStorage)(nil).PutEvent), ctx, event)
+}
@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+type auditStorage struct {
+	db *sql.DB
+}
+
+var _ storage.AuditStorage = (*auditStorage)(nil)
+
+// NewAuditStorage возвращает объект, реализующий интерфейс storage.AuditStorage
+func NewAuditStorage(databaseURL string) (storage.AuditStorage, error) {
+	if err := migrate(databaseURL); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("pgx", databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditStorage{db: db}, nil
+}
+
+// PutEvent добавляет запись в журнал аудита
+func (s *auditStorage) PutEvent(ctx context.Context, event *models.AuditEvent) error {
+	row := s.db.QueryRowContext(
+		ctx,
+		`INSERT INTO audit_events
+                   (user_id, session_id, action, secret_name, secret_version, target, peer_address, result_code)
+                   VALUES(NULLIF($1, 0), NULLIF($2, ''), $3, NULLIF($4, ''), NULLIF($5, '')::uuid, NULLIF($6, ''),
+                          NULLIF($7, ''), $8)
+                   RETURNING id, created_at`,
+		event.UserID,
+		event.SessionID,
+		event.Action,
+		event.SecretName,
+		event.SecretVersion,
+		event.Target,
+		event.PeerAddress,
+		event.ResultCode,
+	)
+	return row.Scan(&event.ID, &event.CreatedAt)
+}
+
+// ListEvents возвращает записи журнала пользователя userID, созданные в промежутке [from, to)
+func (s *auditStorage) ListEvents(ctx context.Context, userID int, from, to time.Time) ([]*models.AuditEvent, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT id, created_at, COALESCE(session_id, ''), action, COALESCE(secret_name, ''),
+                   COALESCE(secret_version::text, ''), COALESCE(target, ''), COALESCE(peer_address, ''), result_code
+                   FROM audit_events
+                   WHERE user_id = ($1) AND created_at >= ($2) AND created_at < ($3)
+                   ORDER BY created_at, id`,
+		userID, from, to,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.AuditEvent, 0)
+	for rows.Next() {
+		event := &models.AuditEvent{
+			UserID: userID,
+		}
+		err = rows.Scan(
+			&event.ID,
+			&event.CreatedAt,
+			&event.SessionID,
+			&event.Action,
+			&event.SecretName,
+			&event.SecretVersion,
+			&event.Target,
+			&event.PeerAddress,
+			&event.ResultCode,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
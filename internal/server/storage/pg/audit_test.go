@@ -0,0 +1,106 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+func newAuditMock() (storage.AuditStorage, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Failed to create sql mock db")
+	}
+	return &auditStorage{db: db}, mock
+}
+
+func TestAuditStorage_PutEvent(t *testing.T) {
+	s, mock := newAuditMock()
+
+	event := &models.AuditEvent{
+		UserID:        1,
+		SessionID:     "SessionID",
+		Action:        models.AuditActionGetSecret,
+		SecretName:    "SecretName",
+		SecretVersion: "8c3c5b0e-4a3f-4b1c-9d3a-2f3e4b5c6d7e",
+		Target:        "Target",
+		PeerAddress:   "127.0.0.1:5000",
+		ResultCode:    0,
+	}
+
+	t.Run("ErrorOnInsert", func(t *testing.T) {
+		insertError := errors.New("some error")
+		mock.ExpectQuery("INSERT INTO audit_events").
+			WithArgs(event.UserID, event.SessionID, event.Action, event.SecretName,
+				event.SecretVersion, event.Target, event.PeerAddress, event.ResultCode).
+			WillReturnError(insertError)
+
+		err := s.PutEvent(context.Background(), event)
+		assert.ErrorIs(t, err, insertError)
+	})
+
+	t.Run("SuccessfulInsert", func(t *testing.T) {
+		createdAt := time.Now()
+		mock.ExpectQuery("INSERT INTO audit_events").
+			WithArgs(event.UserID, event.SessionID, event.Action, event.SecretName,
+				event.SecretVersion, event.Target, event.PeerAddress, event.ResultCode).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(42, createdAt))
+
+		err := s.PutEvent(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), event.ID)
+		assert.Equal(t, createdAt, event.CreatedAt)
+	})
+}
+
+func TestAuditStorage_ListEvents(t *testing.T) {
+	s, mock := newAuditMock()
+	userID := 1
+	from, to := time.Now().Add(-time.Hour), time.Now()
+
+	t.Run("SelectError", func(t *testing.T) {
+		errExpected := errors.New("some error")
+		mock.ExpectQuery("SELECT (.+) FROM audit_events").
+			WithArgs(userID, from, to).
+			WillReturnError(errExpected)
+
+		_, err := s.ListEvents(context.Background(), userID, from, to)
+		assert.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("SuccessfulList", func(t *testing.T) {
+		event := &models.AuditEvent{
+			ID:          1,
+			CreatedAt:   time.Now(),
+			UserID:      userID,
+			SessionID:   "SessionID",
+			Action:      models.AuditActionDeleteSecret,
+			SecretName:  "SecretName",
+			PeerAddress: "127.0.0.1:5000",
+			ResultCode:  5,
+		}
+
+		mock.ExpectQuery("SELECT (.+) FROM audit_events").
+			WithArgs(userID, from, to).
+			WillReturnRows(
+				sqlmock.NewRows([]string{
+					"id", "created_at", "session_id", "action", "secret_name",
+					"secret_version", "target", "peer_address", "result_code",
+				}).AddRow(
+					event.ID, event.CreatedAt, event.SessionID, event.Action, event.SecretName,
+					event.SecretVersion, event.Target, event.PeerAddress, event.ResultCode,
+				))
+
+		events, err := s.ListEvents(context.Background(), userID, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.AuditEvent{event}, events)
+	})
+}
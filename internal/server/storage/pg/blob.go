@@ -0,0 +1,64 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+// PutBlob сохраняет вложение секрета blob.SecretName пользователя blob.OwnerID и возвращает его идентификатор
+func (s *secretStorage) PutBlob(ctx context.Context, blob *models.Blob) (*models.Blob, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`INSERT INTO blobs (secret_id, content)
+                   SELECT id, $1 FROM secrets WHERE name = ($2) AND owner_id = ($3) AND deleted_at IS NULL
+                   RETURNING id`,
+		blob.Content, blob.SecretName, blob.OwnerID,
+	)
+	err := row.Scan(&blob.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrSecretNotFound
+	}
+	return blob, err
+}
+
+// GetBlob возвращает вложение id секрета secretName пользователя userID
+func (s *secretStorage) GetBlob(ctx context.Context, secretName string, id uuid.UUID, userID int) (*models.Blob, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT blobs.content FROM blobs JOIN secrets ON secrets.id = blobs.secret_id
+                   WHERE secrets.name = ($1) AND secrets.owner_id = ($2) AND secrets.deleted_at IS NULL
+                   AND blobs.id = ($3)`,
+		secretName, userID, id,
+	)
+	blob := &models.Blob{
+		ID:         id,
+		SecretName: secretName,
+		OwnerID:    userID,
+	}
+	err := row.Scan(&blob.Content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrBlobNotFound
+	}
+	return blob, err
+}
+
+// DeleteBlob удаляет вложение id секрета secretName пользователя userID
+func (s *secretStorage) DeleteBlob(ctx context.Context, secretName string, id uuid.UUID, userID int) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM blobs USING secrets
+                   WHERE secrets.id = blobs.secret_id AND secrets.name = ($1) AND secrets.owner_id = ($2)
+                   AND secrets.deleted_at IS NULL AND blobs.id = ($3)`,
+		secretName, userID, id,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrBlobNotFound)
+}
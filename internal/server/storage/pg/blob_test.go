@@ -0,0 +1,112 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+func TestSecretStorage_PutBlob(t *testing.T) {
+	s, mock := newSecretMock()
+	blob := &models.Blob{
+		SecretName: "note",
+		OwnerID:    1,
+		Content:    []byte("content"),
+	}
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectQuery("INSERT INTO blobs").
+			WithArgs(blob.Content, blob.SecretName, blob.OwnerID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.PutBlob(context.Background(), blob)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("InsertError", func(t *testing.T) {
+		errExpected := errors.New("some error")
+		mock.ExpectQuery("INSERT INTO blobs").
+			WithArgs(blob.Content, blob.SecretName, blob.OwnerID).
+			WillReturnError(errExpected)
+
+		_, err := s.PutBlob(context.Background(), blob)
+		assert.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		id := uuid.New()
+		mock.ExpectQuery("INSERT INTO blobs").
+			WithArgs(blob.Content, blob.SecretName, blob.OwnerID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+		result, err := s.PutBlob(context.Background(), blob)
+		assert.NoError(t, err)
+		assert.Equal(t, id, result.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_GetBlob(t *testing.T) {
+	s, mock := newSecretMock()
+	secretName, id, userID := "note", uuid.New(), 1
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery("SELECT blobs.content FROM blobs").
+			WithArgs(secretName, userID, id).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetBlob(context.Background(), secretName, id, userID)
+		assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT blobs.content FROM blobs").
+			WithArgs(secretName, userID, id).
+			WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow([]byte("content")))
+
+		blob, err := s.GetBlob(context.Background(), secretName, id, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Blob{
+			ID:         id,
+			SecretName: secretName,
+			OwnerID:    userID,
+			Content:    []byte("content"),
+		}, blob)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_DeleteBlob(t *testing.T) {
+	s, mock := newSecretMock()
+	secretName, id, userID := "note", uuid.New(), 1
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM blobs").
+			WithArgs(secretName, userID, id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.DeleteBlob(context.Background(), secretName, id, userID)
+		assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM blobs").
+			WithArgs(secretName, userID, id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.DeleteBlob(context.Background(), secretName, id, userID)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,172 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+// GetCollectionSecret возвращает секрет с указанным именем из коллекции collectionID
+func (s *secretStorage) GetCollectionSecret(ctx context.Context, name string, collectionID int) (*models.Secret, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT content, version FROM secrets WHERE name = ($1) AND collection_id = ($2) AND deleted_at IS NULL`,
+		name, collectionID,
+	)
+	secret := &models.Secret{
+		Name:         name,
+		CollectionID: collectionID,
+	}
+	err := row.Scan(&secret.Content, &secret.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrSecretNotFound
+	}
+	return secret, err
+}
+
+// CreateCollectionSecret создает новый секрет в коллекции
+func (s *secretStorage) CreateCollectionSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`INSERT INTO secrets (name, content, collection_id)
+                   VALUES($1, $2, $3)
+                   ON CONFLICT DO NOTHING RETURNING version`,
+		secret.Name, secret.Content, secret.CollectionID,
+	)
+	err := row.Scan(&secret.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return secret, storage.ErrSecretConflict
+	}
+	return secret, err
+}
+
+// UpdateCollectionSecret обновляет содержимое секрета коллекции
+func (s *secretStorage) UpdateCollectionSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`UPDATE secrets SET version = uuid_generate_v4(), content = ($1)
+                   WHERE collection_id = ($2) AND name = ($3) AND deleted_at IS NULL
+                   RETURNING version`,
+		secret.Content, secret.CollectionID, secret.Name,
+	)
+	if err := row.Scan(&secret.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSecretNotFound
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// DeleteCollectionSecret перемещает секрет коллекции в корзину
+func (s *secretStorage) DeleteCollectionSecret(ctx context.Context, secret *models.Secret) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`UPDATE secrets SET deleted_at = now() WHERE name = ($1) AND collection_id = ($2) AND deleted_at IS NULL`,
+		secret.Name, secret.CollectionID,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrSecretNotFound)
+}
+
+// ListCollectionSecrets возвращает список всех секретов коллекции collectionID
+func (s *secretStorage) ListCollectionSecrets(ctx context.Context, collectionID int) ([]*models.Secret, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT name, version, content FROM secrets WHERE collection_id = ($1) AND deleted_at IS NULL`,
+		collectionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*models.Secret, 0)
+	for rows.Next() {
+		secret := &models.Secret{
+			CollectionID: collectionID,
+		}
+		if err = rows.Scan(&secret.Name, &secret.Version, &secret.Content); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
+
+// ListDeletedCollectionSecrets возвращает список секретов коллекции collectionID, находящихся в корзине
+func (s *secretStorage) ListDeletedCollectionSecrets(ctx context.Context, collectionID int) ([]*models.Secret, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT name, version, deleted_at FROM secrets
+                   WHERE collection_id = ($1) AND deleted_at IS NOT NULL
+                   ORDER BY deleted_at DESC`,
+		collectionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*models.Secret, 0)
+	for rows.Next() {
+		secret := &models.Secret{
+			CollectionID: collectionID,
+		}
+		if err = rows.Scan(&secret.Name, &secret.Version, &secret.DeletedAt); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
+
+// RestoreCollectionSecret восстанавливает из корзины последний удаленный секрет коллекции с указанным именем.
+// Если название занято действующим секретом коллекции, возвращает storage.ErrSecretConflict
+func (s *secretStorage) RestoreCollectionSecret(
+	ctx context.Context,
+	name string,
+	collectionID int,
+) (*models.Secret, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`UPDATE secrets SET deleted_at = NULL
+                   WHERE id = (
+                       SELECT id FROM secrets
+                       WHERE name = ($1) AND collection_id = ($2) AND deleted_at IS NOT NULL
+                       ORDER BY deleted_at DESC LIMIT 1
+                   )
+                   RETURNING content, version`,
+		name, collectionID,
+	)
+	secret := &models.Secret{
+		Name:         name,
+		CollectionID: collectionID,
+	}
+	if err := row.Scan(&secret.Content, &secret.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSecretNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, storage.ErrSecretConflict
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// PurgeCollectionSecret окончательно удаляет из корзины секреты коллекции с указанным именем
+func (s *secretStorage) PurgeCollectionSecret(ctx context.Context, name string, collectionID int) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM secrets WHERE name = ($1) AND collection_id = ($2) AND deleted_at IS NOT NULL`,
+		name, collectionID,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrSecretNotFound)
+}
@@ -0,0 +1,171 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+func TestSecretStorage_GetCollectionSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, collectionID := "TestName", 3
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectQuery("SELECT content, version FROM secrets WHERE").
+			WithArgs(name, collectionID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetCollectionSecret(context.Background(), name, collectionID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		version := uuid.New()
+		mock.ExpectQuery("SELECT content, version FROM secrets WHERE").
+			WithArgs(name, collectionID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version"}).AddRow([]byte("content"), version))
+
+		secret, err := s.GetCollectionSecret(context.Background(), name, collectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, version, secret.Version)
+		assert.Equal(t, collectionID, secret.CollectionID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_CreateCollectionSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	secret := &models.Secret{
+		Name:         "TestName",
+		Content:      []byte("content"),
+		CollectionID: 3,
+	}
+
+	t.Run("NameConflict", func(t *testing.T) {
+		mock.ExpectQuery("INSERT INTO secrets").
+			WithArgs(secret.Name, secret.Content, secret.CollectionID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.CreateCollectionSecret(context.Background(), secret)
+		assert.ErrorIs(t, err, storage.ErrSecretConflict)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		version := uuid.New()
+		mock.ExpectQuery("INSERT INTO secrets").
+			WithArgs(secret.Name, secret.Content, secret.CollectionID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+
+		created, err := s.CreateCollectionSecret(context.Background(), secret)
+		assert.NoError(t, err)
+		assert.Equal(t, version, created.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_ListCollectionSecrets(t *testing.T) {
+	s, mock := newSecretMock()
+	collectionID := 3
+	version := uuid.New()
+
+	mock.ExpectQuery("SELECT name, version, content FROM secrets").
+		WithArgs(collectionID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "version", "content"}).
+			AddRow("TestName", version, []byte("content")))
+
+	secrets, err := s.ListCollectionSecrets(context.Background(), collectionID)
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 1)
+	assert.Equal(t, "TestName", secrets[0].Name)
+	assert.Equal(t, collectionID, secrets[0].CollectionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecretStorage_ListDeletedCollectionSecrets(t *testing.T) {
+	s, mock := newSecretMock()
+	secret := &models.Secret{
+		Name:         "TestName",
+		Version:      uuid.New(),
+		CollectionID: 3,
+		DeletedAt:    time.Now().UTC(),
+	}
+
+	mock.ExpectQuery("SELECT name, version, deleted_at FROM secrets").
+		WithArgs(secret.CollectionID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "version", "deleted_at"}).
+			AddRow(secret.Name, secret.Version, secret.DeletedAt))
+
+	secrets, err := s.ListDeletedCollectionSecrets(context.Background(), secret.CollectionID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Secret{secret}, secrets)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecretStorage_RestoreCollectionSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, collectionID := "TestName", 3
+
+	t.Run("NameConflict", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE secrets SET deleted_at = NULL").
+			WithArgs(name, collectionID).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationCode})
+
+		_, err := s.RestoreCollectionSecret(context.Background(), name, collectionID)
+		assert.ErrorIs(t, err, storage.ErrSecretConflict)
+	})
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE secrets SET deleted_at = NULL").
+			WithArgs(name, collectionID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version"}))
+
+		_, err := s.RestoreCollectionSecret(context.Background(), name, collectionID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		version := uuid.New()
+		mock.ExpectQuery("UPDATE secrets SET deleted_at = NULL").
+			WithArgs(name, collectionID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version"}).AddRow([]byte("content"), version))
+
+		secret, err := s.RestoreCollectionSecret(context.Background(), name, collectionID)
+		assert.NoError(t, err)
+		assert.Equal(t, version, secret.Version)
+		assert.Equal(t, collectionID, secret.CollectionID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_PurgeCollectionSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, collectionID := "TestName", 3
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM secrets WHERE").
+			WithArgs(name, collectionID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.PurgeCollectionSecret(context.Background(), name, collectionID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM secrets WHERE").
+			WithArgs(name, collectionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.PurgeCollectionSecret(context.Background(), name, collectionID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
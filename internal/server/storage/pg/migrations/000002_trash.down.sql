@@ -0,0 +1,5 @@
+DELETE FROM secrets WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS secrets_deleted_at_idx;
+DROP INDEX IF EXISTS secrets_name_owner_id_active_idx;
+ALTER TABLE secrets ADD CONSTRAINT secrets_name_owner_id_key UNIQUE (name, owner_id);
+ALTER TABLE secrets DROP COLUMN IF EXISTS deleted_at;
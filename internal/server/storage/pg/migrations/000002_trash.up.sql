@@ -0,0 +1,6 @@
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE secrets DROP CONSTRAINT IF EXISTS secrets_name_owner_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS secrets_name_owner_id_active_idx
+    ON secrets (name, owner_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS secrets_deleted_at_idx
+    ON secrets (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS audit_events(
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL,
+    user_id INTEGER,
+    session_id VARCHAR (64),
+    action VARCHAR (64) NOT NULL,
+    secret_name VARCHAR (255),
+    secret_version UUID,
+    peer_address VARCHAR (255),
+    result_code INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_events_user_id_created_at_idx ON audit_events (user_id, created_at);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE PROCEDURE audit_events_append_only();
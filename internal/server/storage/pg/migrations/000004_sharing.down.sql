@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS secret_shares_recipient_id_idx;
+DROP TABLE IF EXISTS secret_shares;
+ALTER TABLE secrets DROP COLUMN IF EXISTS data_key;
+ALTER TABLE users DROP COLUMN IF EXISTS encrypted_private_key;
+ALTER TABLE users DROP COLUMN IF EXISTS public_key;
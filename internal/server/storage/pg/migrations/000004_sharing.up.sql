@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS public_key BYTEA;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS encrypted_private_key BYTEA;
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS data_key BYTEA;
+CREATE TABLE IF NOT EXISTS secret_shares(
+    secret_id INTEGER REFERENCES secrets (id) ON DELETE CASCADE,
+    recipient_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    permission VARCHAR (16) NOT NULL,
+    PRIMARY KEY (secret_id, recipient_id)
+);
+CREATE INDEX IF NOT EXISTS secret_shares_recipient_id_idx ON secret_shares (recipient_id);
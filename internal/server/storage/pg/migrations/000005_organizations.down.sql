@@ -0,0 +1,11 @@
+DELETE FROM secrets WHERE collection_id IS NOT NULL;
+DROP INDEX IF EXISTS secrets_name_collection_id_active_idx;
+ALTER TABLE secrets DROP COLUMN IF EXISTS collection_id;
+DROP VIEW IF EXISTS collection_access;
+DROP TABLE IF EXISTS collection_keys;
+DROP TABLE IF EXISTS collection_teams;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS organizations(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR (128) UNIQUE NOT NULL
+);
+CREATE TABLE IF NOT EXISTS organization_members(
+    organization_id INTEGER REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+    role VARCHAR (16) NOT NULL,
+    PRIMARY KEY (organization_id, user_id)
+);
+CREATE TABLE IF NOT EXISTS teams(
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER REFERENCES organizations (id) ON DELETE CASCADE,
+    name VARCHAR (128) NOT NULL,
+    UNIQUE (organization_id, name)
+);
+CREATE TABLE IF NOT EXISTS team_members(
+    team_id INTEGER REFERENCES teams (id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, user_id)
+);
+CREATE TABLE IF NOT EXISTS collections(
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER REFERENCES organizations (id) ON DELETE CASCADE,
+    name VARCHAR (128) NOT NULL,
+    UNIQUE (organization_id, name)
+);
+CREATE TABLE IF NOT EXISTS collection_teams(
+    collection_id INTEGER REFERENCES collections (id) ON DELETE CASCADE,
+    team_id INTEGER REFERENCES teams (id) ON DELETE CASCADE,
+    PRIMARY KEY (collection_id, team_id)
+);
+CREATE TABLE IF NOT EXISTS collection_keys(
+    collection_id INTEGER REFERENCES collections (id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    PRIMARY KEY (collection_id, user_id)
+);
+CREATE OR REPLACE VIEW collection_access AS
+    SELECT c.id AS collection_id, m.user_id, m.role FROM collections c
+    JOIN organization_members m ON m.organization_id = c.organization_id
+    WHERE m.role IN ('owner', 'admin')
+    UNION
+    SELECT ct.collection_id, tm.user_id, m.role FROM collection_teams ct
+    JOIN teams t ON t.id = ct.team_id
+    JOIN team_members tm ON tm.team_id = t.id
+    JOIN organization_members m ON m.organization_id = t.organization_id AND m.user_id = tm.user_id;
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS collection_id INTEGER REFERENCES collections (id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS secrets_name_collection_id_active_idx
+    ON secrets (name, collection_id) WHERE deleted_at IS NULL AND collection_id IS NOT NULL;
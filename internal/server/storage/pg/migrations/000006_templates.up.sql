@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS templates(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR (128) NOT NULL,
+    content BYTEA NOT NULL,
+    data_key BYTEA NOT NULL,
+    owner_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (owner_id, name)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS blobs(
+    id UUID DEFAULT uuid_generate_v4() NOT NULL,
+    secret_id INTEGER REFERENCES secrets (id) ON DELETE CASCADE,
+    content BYTEA NOT NULL,
+    PRIMARY KEY (secret_id, id)
+);
@@ -0,0 +1 @@
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS target VARCHAR (255);
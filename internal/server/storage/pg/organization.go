@@ -0,0 +1,504 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+type organizationStorage struct {
+	db *sql.DB
+}
+
+var _ storage.OrganizationStorage = (*organizationStorage)(nil)
+
+// NewOrganizationStorage возвращает объект, реализующий интерфейс storage.OrganizationStorage
+func NewOrganizationStorage(databaseURL string) (storage.OrganizationStorage, error) {
+	if err := migrate(databaseURL); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("pgx", databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &organizationStorage{db: db}, nil
+}
+
+// CreateOrganization создает организацию, владельцем которой становится пользователь ownerID
+func (s *organizationStorage) CreateOrganization(
+	ctx context.Context,
+	name string,
+	ownerID int,
+) (*models.Organization, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rollback(tx)
+
+	organization := &models.Organization{
+		Name: name,
+		Role: models.RoleOwner,
+	}
+	row := tx.QueryRowContext(
+		ctx,
+		`INSERT INTO organizations (name) VALUES($1) ON CONFLICT DO NOTHING RETURNING id`,
+		name,
+	)
+	if err = row.Scan(&organization.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrOrganizationConflict
+		}
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO organization_members (organization_id, user_id, role) VALUES($1, $2, $3)`,
+		organization.ID, ownerID, string(models.RoleOwner),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return organization, tx.Commit()
+}
+
+// ListOrganizations возвращает список организаций, участником которых является пользователь userID
+func (s *organizationStorage) ListOrganizations(ctx context.Context, userID int) ([]*models.Organization, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT o.id, o.name, m.role FROM organizations o
+                   JOIN organization_members m ON m.organization_id = o.id
+                   WHERE m.user_id = ($1)
+                   ORDER BY o.name`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := make([]*models.Organization, 0)
+	for rows.Next() {
+		organization := &models.Organization{}
+		if err = rows.Scan(&organization.ID, &organization.Name, &organization.Role); err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization)
+	}
+	return organizations, rows.Err()
+}
+
+// GetRole возвращает роль пользователя userID в организации
+func (s *organizationStorage) GetRole(ctx context.Context, organization string, userID int) (models.Role, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT m.role FROM organization_members m
+                   JOIN organizations o ON o.id = m.organization_id
+                   WHERE o.name = ($1) AND m.user_id = ($2)`,
+		organization, userID,
+	)
+	var role models.Role
+	err := row.Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", storage.ErrOrganizationNotFound
+	}
+	return role, err
+}
+
+// PutMember добавляет пользователя в организацию или изменяет его роль
+func (s *organizationStorage) PutMember(ctx context.Context, organization, email string, role models.Role) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rollback(tx)
+
+	result, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO organization_members (organization_id, user_id, role)
+                   SELECT o.id, u.id, $3 FROM organizations o, users u
+                   WHERE o.name = ($1) AND u.email = ($2)
+                   ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role`,
+		organization, email, string(role),
+	)
+	if err != nil {
+		return err
+	}
+	if err = checkAffected(result, storage.ErrMemberNotFound); err != nil {
+		return err
+	}
+	if err = deleteInaccessibleCollectionKeys(ctx, tx, organization); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveMember исключает пользователя из организации и удаляет выданные ему ключи коллекций
+func (s *organizationStorage) RemoveMember(ctx context.Context, organization, email string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rollback(tx)
+
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM team_members tm USING teams t, organizations o, users u
+                   WHERE tm.team_id = t.id AND t.organization_id = o.id AND tm.user_id = u.id
+                     AND o.name = ($1) AND u.email = ($2)`,
+		organization, email,
+	)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM organization_members m USING organizations o, users u
+                   WHERE m.organization_id = o.id AND m.user_id = u.id
+                     AND o.name = ($1) AND u.email = ($2)`,
+		organization, email,
+	)
+	if err != nil {
+		return err
+	}
+	if err = checkAffected(result, storage.ErrMemberNotFound); err != nil {
+		return err
+	}
+	if err = deleteInaccessibleCollectionKeys(ctx, tx, organization); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListMembers возвращает список участников организации
+func (s *organizationStorage) ListMembers(ctx context.Context, organization string) ([]*models.Member, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT u.email, m.role, u.public_key FROM organization_members m
+                   JOIN organizations o ON o.id = m.organization_id
+                   JOIN users u ON u.id = m.user_id
+                   WHERE o.name = ($1)
+                   ORDER BY u.email`,
+		organization,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*models.Member, 0)
+	for rows.Next() {
+		member := &models.Member{}
+		if err = rows.Scan(&member.Email, &member.Role, &member.PublicKey); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// CreateTeam создает команду в организации
+func (s *organizationStorage) CreateTeam(ctx context.Context, organization, team string) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO teams (organization_id, name)
+                   SELECT id, $2 FROM organizations WHERE name = ($1)
+                   ON CONFLICT DO NOTHING`,
+		organization, team,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrTeamConflict)
+}
+
+// ListTeams возвращает список команд организации
+func (s *organizationStorage) ListTeams(ctx context.Context, organization string) ([]*models.Team, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT t.name, u.email FROM teams t
+                   JOIN organizations o ON o.id = t.organization_id
+                   LEFT JOIN team_members tm ON tm.team_id = t.id
+                   LEFT JOIN users u ON u.id = tm.user_id
+                   WHERE o.name = ($1)
+                   ORDER BY t.name, u.email`,
+		organization,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*models.Team, 0)
+	for rows.Next() {
+		var name string
+		var email sql.NullString
+		if err = rows.Scan(&name, &email); err != nil {
+			return nil, err
+		}
+		if len(teams) == 0 || teams[len(teams)-1].Name != name {
+			teams = append(teams, &models.Team{Name: name, Members: make([]string, 0)})
+		}
+		if email.Valid {
+			team := teams[len(teams)-1]
+			team.Members = append(team.Members, email.String)
+		}
+	}
+	return teams, rows.Err()
+}
+
+// AddTeamMember добавляет участника организации в команду
+func (s *organizationStorage) AddTeamMember(ctx context.Context, organization, team, email string) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO team_members (team_id, user_id)
+                   SELECT t.id, m.user_id FROM teams t
+                   JOIN organizations o ON o.id = t.organization_id
+                   JOIN organization_members m ON m.organization_id = o.id
+                   JOIN users u ON u.id = m.user_id
+                   WHERE o.name = ($1) AND t.name = ($2) AND u.email = ($3)
+                   ON CONFLICT (team_id, user_id) DO UPDATE SET user_id = EXCLUDED.user_id`,
+		organization, team, email,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrMemberNotFound)
+}
+
+// RemoveTeamMember исключает участника из команды и удаляет ключи коллекций, ставших ему недоступными
+func (s *organizationStorage) RemoveTeamMember(ctx context.Context, organization, team, email string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rollback(tx)
+
+	result, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM team_members tm USING teams t, organizations o, users u
+                   WHERE tm.team_id = t.id AND t.organization_id = o.id AND tm.user_id = u.id
+                     AND o.name = ($1) AND t.name = ($2) AND u.email = ($3)`,
+		organization, team, email,
+	)
+	if err != nil {
+		return err
+	}
+	if err = checkAffected(result, storage.ErrMemberNotFound); err != nil {
+		return err
+	}
+	if err = deleteInaccessibleCollectionKeys(ctx, tx, organization); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateCollection создает коллекцию и сохраняет ключ коллекции для пользователя creatorID
+func (s *organizationStorage) CreateCollection(
+	ctx context.Context,
+	organization, collection string,
+	creatorID int,
+	wrappedKey []byte,
+) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rollback(tx)
+
+	var collectionID int
+	row := tx.QueryRowContext(
+		ctx,
+		`INSERT INTO collections (organization_id, name)
+                   SELECT id, $2 FROM organizations WHERE name = ($1)
+                   ON CONFLICT DO NOTHING RETURNING id`,
+		organization, collection,
+	)
+	if err = row.Scan(&collectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrCollectionConflict
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO collection_keys (collection_id, user_id, wrapped_key) VALUES($1, $2, $3)`,
+		collectionID, creatorID, wrappedKey,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListCollections возвращает список коллекций организации, доступных пользователю userID
+func (s *organizationStorage) ListCollections(
+	ctx context.Context,
+	organization string,
+	userID int,
+) ([]*models.Collection, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT c.id, c.name FROM collections c
+                   JOIN organizations o ON o.id = c.organization_id
+                   JOIN collection_access ca ON ca.collection_id = c.id
+                   WHERE o.name = ($1) AND ca.user_id = ($2)
+                   ORDER BY c.name`,
+		organization, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]*models.Collection, 0)
+	for rows.Next() {
+		collection := &models.Collection{}
+		if err = rows.Scan(&collection.ID, &collection.Name); err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+	return collections, rows.Err()
+}
+
+// GrantCollection предоставляет команде доступ к коллекции
+func (s *organizationStorage) GrantCollection(ctx context.Context, organization, collection, team string) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO collection_teams (collection_id, team_id)
+                   SELECT c.id, t.id FROM collections c
+                   JOIN organizations o ON o.id = c.organization_id
+                   JOIN teams t ON t.organization_id = o.id
+                   WHERE o.name = ($1) AND c.name = ($2) AND t.name = ($3)
+                   ON CONFLICT (collection_id, team_id) DO UPDATE SET team_id = EXCLUDED.team_id`,
+		organization, collection, team,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrCollectionNotFound)
+}
+
+// RevokeCollection отзывает у команды доступ к коллекции и удаляет ключи коллекции,
+// ставшие недоступными участникам команды
+func (s *organizationStorage) RevokeCollection(ctx context.Context, organization, collection, team string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rollback(tx)
+
+	result, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM collection_teams ct USING collections c, teams t, organizations o
+                   WHERE ct.collection_id = c.id AND ct.team_id = t.id
+                     AND c.organization_id = o.id AND t.organization_id = o.id
+                     AND o.name = ($1) AND c.name = ($2) AND t.name = ($3)`,
+		organization, collection, team,
+	)
+	if err != nil {
+		return err
+	}
+	if err = checkAffected(result, storage.ErrCollectionNotFound); err != nil {
+		return err
+	}
+	if err = deleteInaccessibleCollectionKeys(ctx, tx, organization); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetCollectionAccess возвращает права пользователя userID на коллекцию
+func (s *organizationStorage) GetCollectionAccess(
+	ctx context.Context,
+	organization, collection string,
+	userID int,
+) (*models.CollectionAccess, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT c.id, ca.role, ck.wrapped_key FROM collections c
+                   JOIN organizations o ON o.id = c.organization_id
+                   JOIN collection_access ca ON ca.collection_id = c.id
+                   LEFT JOIN collection_keys ck ON ck.collection_id = c.id AND ck.user_id = ca.user_id
+                   WHERE o.name = ($1) AND c.name = ($2) AND ca.user_id = ($3)`,
+		organization, collection, userID,
+	)
+	access := &models.CollectionAccess{}
+	err := row.Scan(&access.CollectionID, &access.Role, &access.WrappedKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrCollectionNotFound
+	}
+	return access, err
+}
+
+// ListPendingCollectionKeys возвращает участников с доступом к коллекции,
+// для которых еще не сохранен ключ коллекции
+func (s *organizationStorage) ListPendingCollectionKeys(
+	ctx context.Context,
+	collectionID int,
+) ([]*models.Member, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT u.email, ca.role, u.public_key FROM collection_access ca
+                   JOIN users u ON u.id = ca.user_id
+                   LEFT JOIN collection_keys ck ON ck.collection_id = ca.collection_id AND ck.user_id = ca.user_id
+                   WHERE ca.collection_id = ($1) AND ck.user_id IS NULL AND u.public_key IS NOT NULL
+                   ORDER BY u.email`,
+		collectionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*models.Member, 0)
+	for rows.Next() {
+		member := &models.Member{}
+		if err = rows.Scan(&member.Email, &member.Role, &member.PublicKey); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// PutCollectionKey сохраняет ключ коллекции для участника с доступом к коллекции
+func (s *organizationStorage) PutCollectionKey(
+	ctx context.Context,
+	collectionID int,
+	email string,
+	wrappedKey []byte,
+) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO collection_keys (collection_id, user_id, wrapped_key)
+                   SELECT ca.collection_id, ca.user_id, $3 FROM collection_access ca
+                   JOIN users u ON u.id = ca.user_id
+                   WHERE ca.collection_id = ($1) AND u.email = ($2)
+                   ON CONFLICT (collection_id, user_id) DO NOTHING`,
+		collectionID, email, wrappedKey,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrMemberNotFound)
+}
+
+func deleteInaccessibleCollectionKeys(ctx context.Context, tx *sql.Tx, organization string) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM collection_keys ck USING collections c, organizations o
+                   WHERE ck.collection_id = c.id AND c.organization_id = o.id AND o.name = ($1)
+                     AND NOT EXISTS (
+                         SELECT 1 FROM collection_access ca
+                         WHERE ca.collection_id = ck.collection_id AND ca.user_id = ck.user_id
+                     )`,
+		organization,
+	)
+	return err
+}
@@ -0,0 +1,191 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+func newOrganizationMock() (storage.OrganizationStorage, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Failed to create sql mock db")
+	}
+	return &organizationStorage{db: db}, mock
+}
+
+func TestOrganizationStorage_CreateOrganization(t *testing.T) {
+	s, mock := newOrganizationMock()
+	name, ownerID := "acme", 1
+
+	t.Run("OrganizationExists", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO organizations").
+			WithArgs(name).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := s.CreateOrganization(context.Background(), name, ownerID)
+		assert.ErrorIs(t, err, storage.ErrOrganizationConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO organizations").
+			WithArgs(name).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectExec("INSERT INTO organization_members").
+			WithArgs(7, ownerID, "owner").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		organization, err := s.CreateOrganization(context.Background(), name, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, organization.ID)
+		assert.Equal(t, models.RoleOwner, organization.Role)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrganizationStorage_GetRole(t *testing.T) {
+	s, mock := newOrganizationMock()
+	name, userID := "acme", 1
+
+	t.Run("NotMember", func(t *testing.T) {
+		mock.ExpectQuery("SELECT m.role FROM organization_members").
+			WithArgs(name, userID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetRole(context.Background(), name, userID)
+		assert.ErrorIs(t, err, storage.ErrOrganizationNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT m.role FROM organization_members").
+			WithArgs(name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("admin"))
+
+		role, err := s.GetRole(context.Background(), name, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleAdmin, role)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrganizationStorage_RemoveMember(t *testing.T) {
+	s, mock := newOrganizationMock()
+	name, email := "acme", "member@mail.ru"
+
+	t.Run("MemberNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM team_members").
+			WithArgs(name, email).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM organization_members").
+			WithArgs(name, email).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := s.RemoveMember(context.Background(), name, email)
+		assert.ErrorIs(t, err, storage.ErrMemberNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM team_members").
+			WithArgs(name, email).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("DELETE FROM organization_members").
+			WithArgs(name, email).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM collection_keys").
+			WithArgs(name).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		err := s.RemoveMember(context.Background(), name, email)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrganizationStorage_ListTeams(t *testing.T) {
+	s, mock := newOrganizationMock()
+	name := "acme"
+
+	mock.ExpectQuery("SELECT t.name, u.email FROM teams").
+		WithArgs(name).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).
+			AddRow("backend", "a@mail.ru").
+			AddRow("backend", "b@mail.ru").
+			AddRow("empty", nil))
+
+	teams, err := s.ListTeams(context.Background(), name)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Team{
+		{Name: "backend", Members: []string{"a@mail.ru", "b@mail.ru"}},
+		{Name: "empty", Members: []string{}},
+	}, teams)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationStorage_GetCollectionAccess(t *testing.T) {
+	s, mock := newOrganizationMock()
+	name, collection, userID := "acme", "prod", 1
+
+	t.Run("NoAccess", func(t *testing.T) {
+		mock.ExpectQuery("SELECT c.id, ca.role, ck.wrapped_key FROM collections").
+			WithArgs(name, collection, userID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetCollectionAccess(context.Background(), name, collection, userID)
+		assert.ErrorIs(t, err, storage.ErrCollectionNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT c.id, ca.role, ck.wrapped_key FROM collections").
+			WithArgs(name, collection, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "role", "wrapped_key"}).
+				AddRow(3, "read-only", []byte("wrapped")))
+
+		access, err := s.GetCollectionAccess(context.Background(), name, collection, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, access.CollectionID)
+		assert.Equal(t, models.RoleReadOnly, access.Role)
+		assert.Equal(t, []byte("wrapped"), access.WrappedKey)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrganizationStorage_PutCollectionKey(t *testing.T) {
+	s, mock := newOrganizationMock()
+	collectionID, email, wrappedKey := 3, "member@mail.ru", []byte("wrapped")
+
+	t.Run("NoAccessOrKeyExists", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO collection_keys").
+			WithArgs(collectionID, email, wrappedKey).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.PutCollectionKey(context.Background(), collectionID, email, wrappedKey)
+		assert.ErrorIs(t, err, storage.ErrMemberNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO collection_keys").
+			WithArgs(collectionID, email, wrappedKey).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.PutCollectionKey(context.Background(), collectionID, email, wrappedKey)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -6,11 +6,15 @@ import (
 
 	m "github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgconn"
 	// Register some db stuff
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
+// uniqueViolationCode код ошибки PostgreSQL при нарушении ограничения уникальности
+const uniqueViolationCode = "23505"
+
 //go:embed migrations/*.sql
 var fs embed.FS
 
@@ -29,3 +33,9 @@ func migrate(databaseURL string) error {
 	}
 	return nil
 }
+
+// isUniqueViolation сообщает, что запрос нарушил ограничение уникальности
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -35,3 +35,25 @@ func TestNewUserStorage(t *testing.T) {
 	_, err = NewUserStorage("")
 	assert.Error(t, err)
 }
+
+func TestNewAuditStorage(t *testing.T) {
+	databaseURL := os.Getenv("DB_URL")
+
+	storage, err := NewAuditStorage(databaseURL)
+	assert.NotNil(t, storage)
+	assert.NoError(t, err)
+
+	_, err = NewAuditStorage("")
+	assert.Error(t, err)
+}
+
+func TestNewOrganizationStorage(t *testing.T) {
+	databaseURL := os.Getenv("DB_URL")
+
+	storage, err := NewOrganizationStorage(databaseURL)
+	assert.NotNil(t, storage)
+	assert.NoError(t, err)
+
+	_, err = NewOrganizationStorage("")
+	assert.Error(t, err)
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
@@ -33,14 +37,14 @@ func NewSecretStorage(databaseURL string) (storage.SecretStorage, error) {
 func (s *secretStorage) GetSecret(ctx context.Context, name string, userID int) (*models.Secret, error) {
 	row := s.db.QueryRowContext(
 		ctx,
-		`SELECT content, version FROM secrets WHERE name = ($1) AND owner_id = ($2)`,
+		`SELECT content, version, data_key FROM secrets WHERE name = ($1) AND owner_id = ($2) AND deleted_at IS NULL`,
 		name, userID,
 	)
 	secret := &models.Secret{
 		Name:    name,
 		OwnerID: userID,
 	}
-	err := row.Scan(&secret.Content, &secret.Version)
+	err := row.Scan(&secret.Content, &secret.Version, &secret.DataKey)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, storage.ErrSecretNotFound
 	}
@@ -51,10 +55,10 @@ func (s *secretStorage) GetSecret(ctx context.Context, name string, userID int)
 func (s *secretStorage) CreateSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
 	row := s.db.QueryRowContext(
 		ctx,
-		`INSERT INTO secrets (name, content, owner_id)
-                   VALUES($1, $2, $3)
+		`INSERT INTO secrets (name, content, data_key, owner_id)
+                   VALUES($1, $2, $3, $4)
                    ON CONFLICT DO NOTHING RETURNING version`,
-		secret.Name, secret.Content, secret.OwnerID,
+		secret.Name, secret.Content, secret.DataKey, secret.OwnerID,
 	)
 	err := row.Scan(&secret.Version)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -63,15 +67,20 @@ func (s *secretStorage) CreateSecret(ctx context.Context, secret *models.Secret)
 	return secret, err
 }
 
-// UpdateSecret функция обновления секрета в базе данных
+// UpdateSecret функция обновления секрета в базе данных. Если задана версия secret.Version,
+// секрет обновляется, только если она совпадает с текущей
 func (s *secretStorage) UpdateSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
+	if secret.Version != uuid.Nil {
+		return s.updateSecretVersion(ctx, secret)
+	}
+
 	SQLQuery := `
         UPDATE secrets
-        SET version = uuid_generate_v4(), content = ($1)
-        WHERE owner_id = ($2) AND name = ($3)
+        SET version = uuid_generate_v4(), content = ($1), data_key = ($2)
+        WHERE owner_id = ($3) AND name = ($4) AND deleted_at IS NULL
         RETURNING version`
 
-	row := s.db.QueryRowContext(ctx, SQLQuery, secret.Content, secret.OwnerID, secret.Name)
+	row := s.db.QueryRowContext(ctx, SQLQuery, secret.Content, secret.DataKey, secret.OwnerID, secret.Name)
 	err := row.Scan(&secret.Version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -83,20 +92,161 @@ func (s *secretStorage) UpdateSecret(ctx context.Context, secret *models.Secret)
 	return secret, nil
 }
 
+// updateSecretVersion обновляет секрет, если его текущая версия совпадает с secret.Version
+func (s *secretStorage) updateSecretVersion(ctx context.Context, secret *models.Secret) (*models.Secret, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rollback(tx)
+
+	var current uuid.UUID
+	row := tx.QueryRowContext(
+		ctx,
+		`SELECT version FROM secrets WHERE owner_id = ($1) AND name = ($2) AND deleted_at IS NULL FOR UPDATE`,
+		secret.OwnerID, secret.Name,
+	)
+	if err = row.Scan(&current); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSecretNotFound
+		}
+		return nil, err
+	}
+	if current != secret.Version {
+		return nil, storage.ErrVersionConflict
+	}
+
+	row = tx.QueryRowContext(
+		ctx,
+		`UPDATE secrets SET version = uuid_generate_v4(), content = ($1), data_key = ($2)
+                   WHERE owner_id = ($3) AND name = ($4) AND deleted_at IS NULL
+                   RETURNING version`,
+		secret.Content, secret.DataKey, secret.OwnerID, secret.Name,
+	)
+	if err = row.Scan(&secret.Version); err != nil {
+		return nil, err
+	}
+	return secret, tx.Commit()
+}
+
+// DeleteSecret перемещает секрет в корзину
 func (s *secretStorage) DeleteSecret(ctx context.Context, secret *models.Secret) error {
-	_, err := s.db.ExecContext(
+	result, err := s.db.ExecContext(
 		ctx,
-		`DELETE FROM secrets WHERE name = ($1) AND owner_id = ($2)`,
+		`UPDATE secrets SET deleted_at = now() WHERE name = ($1) AND owner_id = ($2) AND deleted_at IS NULL`,
 		secret.Name,
 		secret.OwnerID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrSecretNotFound)
+}
+
+// RenameSecret переименовывает секрет name пользователя userID в newName.
+// Занятое название определяется по нарушению уникального индекса названий секретов
+func (s *secretStorage) RenameSecret(ctx context.Context, name, newName string, userID int) (*models.Secret, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rollback(tx)
+
+	row := tx.QueryRowContext(
+		ctx,
+		`UPDATE secrets SET name = ($1)
+                   WHERE name = ($2) AND owner_id = ($3) AND deleted_at IS NULL
+                   RETURNING content, version`,
+		newName, name, userID,
+	)
+	secret := &models.Secret{
+		Name:    newName,
+		OwnerID: userID,
+	}
+	if err = row.Scan(&secret.Content, &secret.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSecretNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, storage.ErrSecretConflict
+		}
+		return nil, err
+	}
+
+	return secret, tx.Commit()
+}
+
+// CopySecret создает копию секрета name пользователя userID с названием newName.
+// Занятое название определяется по нарушению уникального индекса названий секретов
+func (s *secretStorage) CopySecret(ctx context.Context, name, newName string, userID int) (*models.Secret, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rollback(tx)
+
+	row := tx.QueryRowContext(
+		ctx,
+		`INSERT INTO secrets (name, content, data_key, owner_id)
+                   SELECT $1, content, data_key, owner_id FROM secrets
+                   WHERE name = ($2) AND owner_id = ($3) AND deleted_at IS NULL
+                   RETURNING id, content, version`,
+		newName, name, userID,
+	)
+	secret := &models.Secret{
+		Name:    newName,
+		OwnerID: userID,
+	}
+	var secretID int
+	if err = row.Scan(&secretID, &secret.Content, &secret.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSecretNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, storage.ErrSecretConflict
+		}
+		return nil, err
+	}
+
+	// Копия ссылается на вложения по тем же идентификаторам, поэтому они копируются вместе с секретом
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO blobs (id, secret_id, content)
+                   SELECT blobs.id, $1, blobs.content FROM blobs JOIN secrets ON secrets.id = blobs.secret_id
+                   WHERE secrets.name = ($2) AND secrets.owner_id = ($3) AND secrets.deleted_at IS NULL`,
+		secretID, name, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, tx.Commit()
+}
+
+func checkAffected(result sql.Result, errNotFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errNotFound
+	}
+	return nil
+}
+
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		log.Warn().Err(err).Msg("Failed to rollback transaction")
+	}
 }
 
 // ListSecrets возвращает список всех секретов пользователя с указанным идентификатором
 func (s *secretStorage) ListSecrets(ctx context.Context, userID int) ([]*models.Secret, error) {
 	rows, err := s.db.QueryContext(
-		ctx, `SELECT name, version, content FROM secrets WHERE owner_id = ($1)`, userID)
+		ctx,
+		`SELECT name, version, content, data_key FROM secrets WHERE owner_id = ($1) AND deleted_at IS NULL`,
+		userID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -106,10 +256,99 @@ func (s *secretStorage) ListSecrets(ctx context.Context, userID int) ([]*models.
 		secret := &models.Secret{
 			OwnerID: userID,
 		}
-		if err = rows.Scan(&secret.Name, &secret.Version, &secret.Content); err != nil {
+		if err = rows.Scan(&secret.Name, &secret.Version, &secret.Content, &secret.DataKey); err != nil {
 			return nil, err
 		}
 		secrets = append(secrets, secret)
 	}
 	return secrets, nil
 }
+
+// ListDeletedSecrets возвращает список секретов пользователя, находящихся в корзине
+func (s *secretStorage) ListDeletedSecrets(ctx context.Context, userID int) ([]*models.Secret, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT name, version, deleted_at FROM secrets
+                   WHERE owner_id = ($1) AND deleted_at IS NOT NULL
+                   ORDER BY deleted_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*models.Secret, 0)
+	for rows.Next() {
+		secret := &models.Secret{
+			OwnerID: userID,
+		}
+		if err = rows.Scan(&secret.Name, &secret.Version, &secret.DeletedAt); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
+
+// RestoreSecret восстанавливает из корзины последний удаленный секрет с указанным именем.
+// Если название занято действующим секретом, возвращает storage.ErrSecretConflict
+func (s *secretStorage) RestoreSecret(ctx context.Context, name string, userID int) (*models.Secret, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rollback(tx)
+
+	row := tx.QueryRowContext(
+		ctx,
+		`UPDATE secrets SET deleted_at = NULL
+                   WHERE id = (
+                       SELECT id FROM secrets
+                       WHERE name = ($1) AND owner_id = ($2) AND deleted_at IS NOT NULL
+                       ORDER BY deleted_at DESC LIMIT 1
+                   )
+                   RETURNING content, version`,
+		name, userID,
+	)
+	secret := &models.Secret{
+		Name:    name,
+		OwnerID: userID,
+	}
+	if err = row.Scan(&secret.Content, &secret.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSecretNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, storage.ErrSecretConflict
+		}
+		return nil, err
+	}
+
+	return secret, tx.Commit()
+}
+
+// PurgeSecret окончательно удаляет из корзины секреты с указанным именем
+func (s *secretStorage) PurgeSecret(ctx context.Context, name string, userID int) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM secrets WHERE name = ($1) AND owner_id = ($2) AND deleted_at IS NOT NULL`,
+		name, userID,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrSecretNotFound)
+}
+
+// PurgeExpiredSecrets окончательно удаляет секреты, перемещенные в корзину ранее deletedBefore
+func (s *secretStorage) PurgeExpiredSecrets(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM secrets WHERE deleted_at < ($1)`,
+		deletedBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -2,11 +2,14 @@ package pg
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 
@@ -28,7 +31,7 @@ func TestPostgresStorage_GetSecret(t *testing.T) {
 	secretOwnerID := 0
 
 	t.Run("SecretNotExists", func(t *testing.T) {
-		mock.ExpectQuery("SELECT content, version FROM secrets WHERE").
+		mock.ExpectQuery("SELECT content, version, data_key FROM secrets WHERE").
 			WithArgs(secretName, secretOwnerID).
 			WillReturnError(storage.ErrSecretNotFound)
 
@@ -39,7 +42,7 @@ func TestPostgresStorage_GetSecret(t *testing.T) {
 
 	t.Run("ErrorOnSelect", func(t *testing.T) {
 		selectError := errors.New("some error")
-		mock.ExpectQuery("SELECT content, version FROM secrets WHERE").
+		mock.ExpectQuery("SELECT content, version, data_key FROM secrets WHERE").
 			WithArgs(secretName, secretOwnerID).
 			WillReturnError(selectError)
 
@@ -51,18 +54,20 @@ func TestPostgresStorage_GetSecret(t *testing.T) {
 	t.Run("SecretExists", func(t *testing.T) {
 		versionExpected := uuid.New()
 		contentExpected := []byte("TestContent")
+		dataKeyExpected := []byte("TestDataKey")
 
-		mock.ExpectQuery("SELECT content, version FROM secrets WHERE").
+		mock.ExpectQuery("SELECT content, version, data_key FROM secrets WHERE").
 			WithArgs(secretName, secretOwnerID).
 			WillReturnRows(
 				sqlmock.
-					NewRows([]string{"content", "version"}).
-					AddRow(contentExpected, versionExpected))
+					NewRows([]string{"content", "version", "data_key"}).
+					AddRow(contentExpected, versionExpected, dataKeyExpected))
 
 		secret, err := s.GetSecret(context.Background(), secretName, secretOwnerID)
 		assert.NoError(t, err)
 		assert.Equal(t, versionExpected, secret.Version)
 		assert.Equal(t, contentExpected, secret.Content)
+		assert.Equal(t, dataKeyExpected, secret.DataKey)
 	})
 }
 
@@ -78,7 +83,7 @@ func TestSecretStorage_CreateSecret(t *testing.T) {
 
 	t.Run("NameConflict", func(t *testing.T) {
 		mock.ExpectQuery("INSERT INTO secrets").
-			WithArgs(secret.Name, secret.Content, secret.OwnerID).
+			WithArgs(secret.Name, secret.Content, secret.DataKey, secret.OwnerID).
 			WillReturnError(storage.ErrSecretConflict)
 
 		_, err := s.CreateSecret(context.Background(), secret)
@@ -89,7 +94,7 @@ func TestSecretStorage_CreateSecret(t *testing.T) {
 	t.Run("ErrorOnInsert", func(t *testing.T) {
 		insertError := errors.New("some error")
 		mock.ExpectQuery("INSERT INTO secrets").
-			WithArgs(secret.Name, secret.Content, secret.OwnerID).
+			WithArgs(secret.Name, secret.Content, secret.DataKey, secret.OwnerID).
 			WillReturnError(insertError)
 
 		_, err := s.CreateSecret(context.Background(), secret)
@@ -101,7 +106,7 @@ func TestSecretStorage_CreateSecret(t *testing.T) {
 		versionExpected := uuid.New()
 
 		mock.ExpectQuery("INSERT INTO secrets").
-			WithArgs(secret.Name, secret.Content, secret.OwnerID).
+			WithArgs(secret.Name, secret.Content, secret.DataKey, secret.OwnerID).
 			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(versionExpected))
 
 		secretActual, err := s.CreateSecret(context.Background(), secret)
@@ -121,7 +126,7 @@ func TestPostgresStorage_UpdateSecret(t *testing.T) {
 
 	t.Run("SecretNotFound", func(t *testing.T) {
 		mock.ExpectQuery("UPDATE secrets SET version").
-			WithArgs(secret.Content, secret.OwnerID, secret.Name).
+			WithArgs(secret.Content, secret.DataKey, secret.OwnerID, secret.Name).
 			WillReturnError(storage.ErrSecretNotFound)
 
 		_, err := s.UpdateSecret(context.Background(), secret)
@@ -133,7 +138,7 @@ func TestPostgresStorage_UpdateSecret(t *testing.T) {
 		updateError := errors.New("some error")
 
 		mock.ExpectQuery("UPDATE secrets SET version").
-			WithArgs(secret.Content, secret.OwnerID, secret.Name).
+			WithArgs(secret.Content, secret.DataKey, secret.OwnerID, secret.Name).
 			WillReturnError(updateError)
 
 		_, err := s.UpdateSecret(context.Background(), secret)
@@ -145,7 +150,7 @@ func TestPostgresStorage_UpdateSecret(t *testing.T) {
 		newVersion := uuid.New()
 
 		mock.ExpectQuery("UPDATE secrets SET version").
-			WithArgs(secret.Content, secret.OwnerID, secret.Name).
+			WithArgs(secret.Content, secret.DataKey, secret.OwnerID, secret.Name).
 			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(newVersion))
 
 		secretActual, err := s.UpdateSecret(context.Background(), secret)
@@ -154,13 +159,70 @@ func TestPostgresStorage_UpdateSecret(t *testing.T) {
 	})
 }
 
+func TestPostgresStorage_UpdateSecretVersion(t *testing.T) {
+	s, mock := newSecretMock()
+	version := uuid.New()
+	newSecret := func() *models.Secret {
+		return &models.Secret{
+			Name:    "TestName",
+			Content: []byte("TestContent"),
+			Version: version,
+			OwnerID: 0,
+		}
+	}
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		secret := newSecret()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT version FROM secrets").
+			WithArgs(secret.OwnerID, secret.Name).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := s.UpdateSecret(context.Background(), secret)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		secret := newSecret()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT version FROM secrets").
+			WithArgs(secret.OwnerID, secret.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(uuid.New()))
+		mock.ExpectRollback()
+
+		_, err := s.UpdateSecret(context.Background(), secret)
+		assert.ErrorIs(t, err, storage.ErrVersionConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessfulUpdate", func(t *testing.T) {
+		secret := newSecret()
+		newVersion := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT version FROM secrets").
+			WithArgs(secret.OwnerID, secret.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+		mock.ExpectQuery("UPDATE secrets SET version").
+			WithArgs(secret.Content, secret.DataKey, secret.OwnerID, secret.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(newVersion))
+		mock.ExpectCommit()
+
+		secretActual, err := s.UpdateSecret(context.Background(), secret)
+		assert.NoError(t, err)
+		assert.Equal(t, newVersion, secretActual.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestPostgresStorage_ListSecrets(t *testing.T) {
 	s, mock := newSecretMock()
 	userID := 0
 
 	t.Run("SelectError", func(t *testing.T) {
 		errExpected := errors.New("some error")
-		mock.ExpectQuery("SELECT name, version, content FROM secrets").
+		mock.ExpectQuery("SELECT name, version, content, data_key FROM secrets").
 			WithArgs(userID).WillReturnError(errExpected)
 
 		_, err := s.ListSecrets(context.Background(), userID)
@@ -180,17 +242,18 @@ func TestPostgresStorage_ListSecrets(t *testing.T) {
 				Name:    "Name2",
 				Version: uuid.New(),
 				Content: []byte("Content2"),
+				DataKey: []byte("DataKey2"),
 				OwnerID: userID,
 			},
 		}
 
-		mock.ExpectQuery("SELECT name, version, content FROM secrets").
+		mock.ExpectQuery("SELECT name, version, content, data_key FROM secrets").
 			WithArgs(userID).
 			WillReturnRows(
 				sqlmock.
-					NewRows([]string{"name", "version", "content"}).
-					AddRow(secrets[0].Name, secrets[0].Version, secrets[0].Content).
-					AddRow(secrets[1].Name, secrets[1].Version, secrets[1].Content))
+					NewRows([]string{"name", "version", "content", "data_key"}).
+					AddRow(secrets[0].Name, secrets[0].Version, secrets[0].Content, nil).
+					AddRow(secrets[1].Name, secrets[1].Version, secrets[1].Content, secrets[1].DataKey))
 
 		secretsActual, err := s.ListSecrets(context.Background(), userID)
 		assert.NoError(t, err)
@@ -199,6 +262,7 @@ func TestPostgresStorage_ListSecrets(t *testing.T) {
 			assert.Equal(t, secrets[i].Name, secretsActual[i].Name)
 			assert.Equal(t, secrets[i].Version, secretsActual[i].Version)
 			assert.Equal(t, secrets[i].Content, secretsActual[i].Content)
+			assert.Equal(t, secrets[i].DataKey, secretsActual[i].DataKey)
 			assert.Equal(t, secrets[i].OwnerID, secretsActual[i].OwnerID)
 		}
 	})
@@ -217,7 +281,7 @@ func TestPostgresStorage_DeleteSecret(t *testing.T) {
 	t.Run("ErrorOnDelete", func(t *testing.T) {
 		deleteError := errors.New("some error")
 
-		mock.ExpectExec("DELETE FROM secrets WHERE").
+		mock.ExpectExec("UPDATE secrets SET deleted_at = now()").
 			WithArgs(secret.Name, secret.OwnerID).
 			WillReturnError(deleteError)
 
@@ -226,8 +290,17 @@ func TestPostgresStorage_DeleteSecret(t *testing.T) {
 		assert.ErrorIs(t, err, deleteError)
 	})
 
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectExec("UPDATE secrets SET deleted_at = now()").
+			WithArgs(secret.Name, secret.OwnerID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.DeleteSecret(context.Background(), secret)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
 	t.Run("SuccessfulDelete", func(t *testing.T) {
-		mock.ExpectExec("DELETE FROM secrets WHERE").
+		mock.ExpectExec("UPDATE secrets SET deleted_at = now()").
 			WithArgs(secret.Name, secret.OwnerID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -235,3 +308,255 @@ func TestPostgresStorage_DeleteSecret(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestPostgresStorage_RenameSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, newName, userID := "TestName", "NewTestName", 0
+
+	t.Run("NameConflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE secrets SET name").
+			WithArgs(newName, name, userID).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationCode})
+		mock.ExpectRollback()
+
+		_, err := s.RenameSecret(context.Background(), name, newName, userID)
+		assert.ErrorIs(t, err, storage.ErrSecretConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE secrets SET name").
+			WithArgs(newName, name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version"}))
+		mock.ExpectRollback()
+
+		_, err := s.RenameSecret(context.Background(), name, newName, userID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ErrorOnUpdate", func(t *testing.T) {
+		updateError := errors.New("some error")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE secrets SET name").
+			WithArgs(newName, name, userID).
+			WillReturnError(updateError)
+		mock.ExpectRollback()
+
+		_, err := s.RenameSecret(context.Background(), name, newName, userID)
+		assert.ErrorIs(t, err, updateError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessfulRename", func(t *testing.T) {
+		version := uuid.New()
+		content := []byte("TestContent")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE secrets SET name").
+			WithArgs(newName, name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version"}).AddRow(content, version))
+		mock.ExpectCommit()
+
+		secret, err := s.RenameSecret(context.Background(), name, newName, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, newName, secret.Name)
+		assert.Equal(t, content, secret.Content)
+		assert.Equal(t, version, secret.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresStorage_CopySecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, newName, userID := "TestName", "NewTestName", 0
+
+	t.Run("NameConflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO secrets").
+			WithArgs(newName, name, userID).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationCode})
+		mock.ExpectRollback()
+
+		_, err := s.CopySecret(context.Background(), name, newName, userID)
+		assert.ErrorIs(t, err, storage.ErrSecretConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO secrets").
+			WithArgs(newName, name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "content", "version"}))
+		mock.ExpectRollback()
+
+		_, err := s.CopySecret(context.Background(), name, newName, userID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessfulCopy", func(t *testing.T) {
+		version := uuid.New()
+		content := []byte("TestContent")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO secrets").
+			WithArgs(newName, name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "content", "version"}).AddRow(7, content, version))
+		mock.ExpectExec("INSERT INTO blobs").
+			WithArgs(7, name, userID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		secret, err := s.CopySecret(context.Background(), name, newName, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, newName, secret.Name)
+		assert.Equal(t, content, secret.Content)
+		assert.Equal(t, version, secret.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresStorage_ListDeletedSecrets(t *testing.T) {
+	s, mock := newSecretMock()
+	userID := 0
+
+	t.Run("SelectError", func(t *testing.T) {
+		errExpected := errors.New("some error")
+		mock.ExpectQuery("SELECT name, version, deleted_at FROM secrets").
+			WithArgs(userID).WillReturnError(errExpected)
+
+		_, err := s.ListDeletedSecrets(context.Background(), userID)
+		assert.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("SuccessfulList", func(t *testing.T) {
+		secret := &models.Secret{
+			Name:      "Name",
+			Version:   uuid.New(),
+			OwnerID:   userID,
+			DeletedAt: time.Now().UTC(),
+		}
+
+		mock.ExpectQuery("SELECT name, version, deleted_at FROM secrets").
+			WithArgs(userID).
+			WillReturnRows(
+				sqlmock.
+					NewRows([]string{"name", "version", "deleted_at"}).
+					AddRow(secret.Name, secret.Version, secret.DeletedAt))
+
+		secretsActual, err := s.ListDeletedSecrets(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.Secret{secret}, secretsActual)
+	})
+}
+
+func TestPostgresStorage_RestoreSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, userID := "TestName", 0
+
+	t.Run("NameConflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE secrets SET deleted_at = NULL").
+			WithArgs(name, userID).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationCode})
+		mock.ExpectRollback()
+
+		_, err := s.RestoreSecret(context.Background(), name, userID)
+		assert.ErrorIs(t, err, storage.ErrSecretConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE secrets SET deleted_at = NULL").
+			WithArgs(name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version"}))
+		mock.ExpectRollback()
+
+		_, err := s.RestoreSecret(context.Background(), name, userID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SuccessfulRestore", func(t *testing.T) {
+		version := uuid.New()
+		content := []byte("TestContent")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE secrets SET deleted_at = NULL").
+			WithArgs(name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version"}).AddRow(content, version))
+		mock.ExpectCommit()
+
+		secret, err := s.RestoreSecret(context.Background(), name, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, name, secret.Name)
+		assert.Equal(t, content, secret.Content)
+		assert.Equal(t, version, secret.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresStorage_PurgeSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, userID := "TestName", 0
+
+	t.Run("ErrorOnDelete", func(t *testing.T) {
+		deleteError := errors.New("some error")
+
+		mock.ExpectExec("DELETE FROM secrets WHERE").
+			WithArgs(name, userID).
+			WillReturnError(deleteError)
+
+		err := s.PurgeSecret(context.Background(), name, userID)
+		assert.ErrorIs(t, err, deleteError)
+	})
+
+	t.Run("SecretNotFound", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM secrets WHERE").
+			WithArgs(name, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.PurgeSecret(context.Background(), name, userID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("SuccessfulPurge", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM secrets WHERE").
+			WithArgs(name, userID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := s.PurgeSecret(context.Background(), name, userID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPostgresStorage_PurgeExpiredSecrets(t *testing.T) {
+	s, mock := newSecretMock()
+	deletedBefore := time.Now()
+
+	t.Run("ErrorOnDelete", func(t *testing.T) {
+		deleteError := errors.New("some error")
+
+		mock.ExpectExec("DELETE FROM secrets WHERE deleted_at").
+			WithArgs(deletedBefore).
+			WillReturnError(deleteError)
+
+		_, err := s.PurgeExpiredSecrets(context.Background(), deletedBefore)
+		assert.ErrorIs(t, err, deleteError)
+	})
+
+	t.Run("SuccessfulPurge", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM secrets WHERE deleted_at").
+			WithArgs(deletedBefore).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		purged, err := s.PurgeExpiredSecrets(context.Background(), deletedBefore)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), purged)
+	})
+}
@@ -0,0 +1,158 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+// ShareSecret предоставляет получателю доступ к секрету или изменяет права существующего доступа
+func (s *secretStorage) ShareSecret(ctx context.Context, share *models.Share) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO secret_shares (secret_id, recipient_id, wrapped_key, permission)
+                   SELECT s.id, u.id, $1, $2 FROM secrets s, users u
+                   WHERE s.name = ($3) AND s.owner_id = ($4) AND s.deleted_at IS NULL
+                     AND u.email = ($5) AND u.id <> s.owner_id
+                   ON CONFLICT (secret_id, recipient_id)
+                   DO UPDATE SET wrapped_key = EXCLUDED.wrapped_key, permission = EXCLUDED.permission`,
+		share.WrappedKey, string(share.Permission), share.SecretName, share.OwnerID, share.RecipientEmail,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrSecretNotFound)
+}
+
+// UnshareSecret отзывает у получателя recipientEmail доступ к секрету name пользователя ownerID
+func (s *secretStorage) UnshareSecret(ctx context.Context, name string, ownerID int, recipientEmail string) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM secret_shares sh USING secrets s, users u
+                   WHERE sh.secret_id = s.id AND sh.recipient_id = u.id
+                     AND s.name = ($1) AND s.owner_id = ($2) AND s.deleted_at IS NULL AND u.email = ($3)`,
+		name, ownerID, recipientEmail,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrSecretNotFound)
+}
+
+// ListSecretShares возвращает список получателей, которым доступен секрет name пользователя ownerID
+func (s *secretStorage) ListSecretShares(ctx context.Context, name string, ownerID int) ([]*models.Share, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT u.email, sh.permission FROM secret_shares sh
+                   JOIN secrets s ON s.id = sh.secret_id
+                   JOIN users u ON u.id = sh.recipient_id
+                   WHERE s.name = ($1) AND s.owner_id = ($2) AND s.deleted_at IS NULL
+                   ORDER BY u.email`,
+		name, ownerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*models.Share, 0)
+	for rows.Next() {
+		share := &models.Share{
+			SecretName: name,
+			OwnerID:    ownerID,
+		}
+		if err = rows.Scan(&share.RecipientEmail, &share.Permission); err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+// GetSharedSecret возвращает секрет name пользователя ownerEmail, доступный получателю recipientID
+func (s *secretStorage) GetSharedSecret(
+	ctx context.Context,
+	name, ownerEmail string,
+	recipientID int,
+) (*models.SharedSecret, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT s.content, s.version, sh.wrapped_key, sh.permission FROM secret_shares sh
+                   JOIN secrets s ON s.id = sh.secret_id
+                   JOIN users u ON u.id = s.owner_id
+                   WHERE s.name = ($1) AND u.email = ($2) AND sh.recipient_id = ($3) AND s.deleted_at IS NULL`,
+		name, ownerEmail, recipientID,
+	)
+	secret := &models.SharedSecret{
+		Name:        name,
+		OwnerEmail:  ownerEmail,
+		RecipientID: recipientID,
+	}
+	err := row.Scan(&secret.Content, &secret.Version, &secret.WrappedKey, &secret.Permission)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrSecretNotFound
+	}
+	return secret, err
+}
+
+// UpdateSharedSecret обновляет содержимое чужого секрета, доступного получателю на запись
+func (s *secretStorage) UpdateSharedSecret(
+	ctx context.Context,
+	secret *models.SharedSecret,
+) (*models.SharedSecret, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`UPDATE secrets s SET version = uuid_generate_v4(), content = ($1)
+                   FROM secret_shares sh, users u
+                   WHERE sh.secret_id = s.id AND u.id = s.owner_id
+                     AND s.name = ($2) AND u.email = ($3) AND s.deleted_at IS NULL
+                     AND sh.recipient_id = ($4) AND sh.permission = ($5)
+                   RETURNING s.version`,
+		secret.Content, secret.Name, secret.OwnerEmail, secret.RecipientID, string(models.PermissionReadWrite),
+	)
+	if err := row.Scan(&secret.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrSecretNotFound
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// ListSharedSecrets возвращает список чужих секретов, доступных получателю recipientID
+func (s *secretStorage) ListSharedSecrets(ctx context.Context, recipientID int) ([]*models.SharedSecret, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT u.email, s.name, s.content, s.version, sh.wrapped_key, sh.permission FROM secret_shares sh
+                   JOIN secrets s ON s.id = sh.secret_id
+                   JOIN users u ON u.id = s.owner_id
+                   WHERE sh.recipient_id = ($1) AND s.deleted_at IS NULL
+                   ORDER BY u.email, s.name`,
+		recipientID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*models.SharedSecret, 0)
+	for rows.Next() {
+		secret := &models.SharedSecret{
+			RecipientID: recipientID,
+		}
+		err = rows.Scan(
+			&secret.OwnerEmail,
+			&secret.Name,
+			&secret.Content,
+			&secret.Version,
+			&secret.WrappedKey,
+			&secret.Permission,
+		)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
@@ -0,0 +1,176 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+func TestSecretStorage_ShareSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	share := &models.Share{
+		SecretName:     "TestName",
+		OwnerID:        1,
+		RecipientEmail: "recipient@mail.ru",
+		WrappedKey:     []byte("wrapped"),
+		Permission:     models.PermissionRead,
+	}
+
+	t.Run("SecretOrRecipientNotFound", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO secret_shares").
+			WithArgs(share.WrappedKey, string(share.Permission), share.SecretName, share.OwnerID, share.RecipientEmail).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.ShareSecret(context.Background(), share)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO secret_shares").
+			WithArgs(share.WrappedKey, string(share.Permission), share.SecretName, share.OwnerID, share.RecipientEmail).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.ShareSecret(context.Background(), share)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_UnshareSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, ownerID, recipient := "TestName", 1, "recipient@mail.ru"
+
+	t.Run("ShareNotFound", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM secret_shares").
+			WithArgs(name, ownerID, recipient).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.UnshareSecret(context.Background(), name, ownerID, recipient)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM secret_shares").
+			WithArgs(name, ownerID, recipient).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.UnshareSecret(context.Background(), name, ownerID, recipient)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_ListSecretShares(t *testing.T) {
+	s, mock := newSecretMock()
+	name, ownerID := "TestName", 1
+
+	mock.ExpectQuery("SELECT u.email, sh.permission FROM secret_shares").
+		WithArgs(name, ownerID).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "permission"}).
+			AddRow("a@mail.ru", "read").
+			AddRow("b@mail.ru", "read-write"))
+
+	shares, err := s.ListSecretShares(context.Background(), name, ownerID)
+	assert.NoError(t, err)
+	assert.Len(t, shares, 2)
+	assert.Equal(t, "a@mail.ru", shares[0].RecipientEmail)
+	assert.Equal(t, models.PermissionRead, shares[0].Permission)
+	assert.Equal(t, models.PermissionReadWrite, shares[1].Permission)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecretStorage_GetSharedSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	name, owner, recipientID := "TestName", "owner@mail.ru", 2
+
+	t.Run("SecretNotShared", func(t *testing.T) {
+		mock.ExpectQuery("SELECT s.content, s.version, sh.wrapped_key, sh.permission FROM secret_shares").
+			WithArgs(name, owner, recipientID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetSharedSecret(context.Background(), name, owner, recipientID)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		version := uuid.New()
+		mock.ExpectQuery("SELECT s.content, s.version, sh.wrapped_key, sh.permission FROM secret_shares").
+			WithArgs(name, owner, recipientID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "version", "wrapped_key", "permission"}).
+				AddRow([]byte("content"), version, []byte("wrapped"), "read"))
+
+		secret, err := s.GetSharedSecret(context.Background(), name, owner, recipientID)
+		assert.NoError(t, err)
+		assert.Equal(t, name, secret.Name)
+		assert.Equal(t, owner, secret.OwnerEmail)
+		assert.Equal(t, version, secret.Version)
+		assert.Equal(t, []byte("wrapped"), secret.WrappedKey)
+		assert.Equal(t, models.PermissionRead, secret.Permission)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_UpdateSharedSecret(t *testing.T) {
+	s, mock := newSecretMock()
+	secret := &models.SharedSecret{
+		Name:        "TestName",
+		Content:     []byte("content"),
+		OwnerEmail:  "owner@mail.ru",
+		RecipientID: 2,
+	}
+
+	t.Run("NoWritePermission", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE secrets s SET version").
+			WithArgs(secret.Content, secret.Name, secret.OwnerEmail, secret.RecipientID, "read-write").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.UpdateSharedSecret(context.Background(), secret)
+		assert.ErrorIs(t, err, storage.ErrSecretNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		version := uuid.New()
+		mock.ExpectQuery("UPDATE secrets s SET version").
+			WithArgs(secret.Content, secret.Name, secret.OwnerEmail, secret.RecipientID, "read-write").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+
+		updated, err := s.UpdateSharedSecret(context.Background(), secret)
+		assert.NoError(t, err)
+		assert.Equal(t, version, updated.Version)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_ListSharedSecrets(t *testing.T) {
+	s, mock := newSecretMock()
+	recipientID := 2
+	version := uuid.New()
+
+	mock.ExpectQuery("SELECT u.email, s.name, s.content, s.version, sh.wrapped_key, sh.permission FROM secret_shares").
+		WithArgs(recipientID).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "content", "version", "wrapped_key", "permission"}).
+			AddRow("owner@mail.ru", "TestName", []byte("content"), version, []byte("wrapped"), "read-write"))
+
+	secrets, err := s.ListSharedSecrets(context.Background(), recipientID)
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 1)
+	assert.Equal(t, "owner@mail.ru", secrets[0].OwnerEmail)
+	assert.Equal(t, "TestName", secrets[0].Name)
+	assert.Equal(t, version, secrets[0].Version)
+	assert.Equal(t, models.PermissionReadWrite, secrets[0].Permission)
+	assert.Equal(t, recipientID, secrets[0].RecipientID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
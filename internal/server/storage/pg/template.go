@@ -0,0 +1,78 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+// PutTemplate создает шаблон секретов или заменяет существующий шаблон с тем же названием
+func (s *secretStorage) PutTemplate(ctx context.Context, template *models.Template) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO templates (name, content, data_key, owner_id)
+                   VALUES($1, $2, $3, $4)
+                   ON CONFLICT (owner_id, name)
+                   DO UPDATE SET content = EXCLUDED.content, data_key = EXCLUDED.data_key`,
+		template.Name, template.Content, template.DataKey, template.OwnerID,
+	)
+	return err
+}
+
+// GetTemplate возвращает шаблон name пользователя userID
+func (s *secretStorage) GetTemplate(ctx context.Context, name string, userID int) (*models.Template, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT content, data_key FROM templates WHERE name = ($1) AND owner_id = ($2)`,
+		name, userID,
+	)
+	template := &models.Template{
+		Name:    name,
+		OwnerID: userID,
+	}
+	err := row.Scan(&template.Content, &template.DataKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrTemplateNotFound
+	}
+	return template, err
+}
+
+// ListTemplates возвращает список шаблонов пользователя userID, упорядоченный по названию
+func (s *secretStorage) ListTemplates(ctx context.Context, userID int) ([]*models.Template, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT name, content, data_key FROM templates WHERE owner_id = ($1) ORDER BY name`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*models.Template, 0)
+	for rows.Next() {
+		template := &models.Template{
+			OwnerID: userID,
+		}
+		if err = rows.Scan(&template.Name, &template.Content, &template.DataKey); err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// DeleteTemplate удаляет шаблон name пользователя userID
+func (s *secretStorage) DeleteTemplate(ctx context.Context, name string, userID int) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM templates WHERE name = ($1) AND owner_id = ($2)`,
+		name, userID,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, storage.ErrTemplateNotFound)
+}
@@ -0,0 +1,121 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
+	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/storage"
+)
+
+func TestSecretStorage_PutTemplate(t *testing.T) {
+	s, mock := newSecretMock()
+	template := &models.Template{
+		Name:    "database",
+		Content: []byte("content"),
+		DataKey: []byte("key"),
+		OwnerID: 1,
+	}
+
+	t.Run("InsertError", func(t *testing.T) {
+		errExpected := errors.New("some error")
+		mock.ExpectExec("INSERT INTO templates").
+			WithArgs(template.Name, template.Content, template.DataKey, template.OwnerID).
+			WillReturnError(errExpected)
+
+		err := s.PutTemplate(context.Background(), template)
+		assert.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO templates").
+			WithArgs(template.Name, template.Content, template.DataKey, template.OwnerID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := s.PutTemplate(context.Background(), template)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_GetTemplate(t *testing.T) {
+	s, mock := newSecretMock()
+	name, userID := "database", 1
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery("SELECT content, data_key FROM templates").
+			WithArgs(name, userID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetTemplate(context.Background(), name, userID)
+		assert.ErrorIs(t, err, storage.ErrTemplateNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT content, data_key FROM templates").
+			WithArgs(name, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"content", "data_key"}).AddRow([]byte("content"), []byte("key")))
+
+		template, err := s.GetTemplate(context.Background(), name, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Template{
+			Name:    name,
+			Content: []byte("content"),
+			DataKey: []byte("key"),
+			OwnerID: userID,
+		}, template)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSecretStorage_ListTemplates(t *testing.T) {
+	s, mock := newSecretMock()
+	userID := 1
+
+	mock.ExpectQuery("SELECT name, content, data_key FROM templates").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "content", "data_key"}).
+			AddRow("api", []byte("a"), []byte("ka")).
+			AddRow("database", []byte("d"), []byte("kd")))
+
+	templates, err := s.ListTemplates(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Template{
+		{Name: "api", Content: []byte("a"), DataKey: []byte("ka"), OwnerID: userID},
+		{Name: "database", Content: []byte("d"), DataKey: []byte("kd"), OwnerID: userID},
+	}, templates)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecretStorage_DeleteTemplate(t *testing.T) {
+	s, mock := newSecretMock()
+	name, userID := "database", 1
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM templates").
+			WithArgs(name, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.DeleteTemplate(context.Background(), name, userID)
+		assert.ErrorIs(t, err, storage.ErrTemplateNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM templates").
+			WithArgs(name, userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.DeleteTemplate(context.Background(), name, userID)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -58,3 +58,56 @@ func (s *userStorage) GetUser(ctx context.Context, user *models.User) (*models.U
 	}
 	return user, err
 }
+
+// PutKeyPair сохраняет пару ключей пользователя userID, если она еще не задана
+func (s *userStorage) PutKeyPair(ctx context.Context, userID int, keyPair *models.KeyPair) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		`UPDATE users SET public_key = ($1), encrypted_private_key = ($2)
+                   WHERE id = ($3) AND public_key IS NULL`,
+		keyPair.PublicKey,
+		keyPair.EncryptedPrivateKey,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return storage.ErrKeyPairConflict
+	}
+	return nil
+}
+
+// GetKeyPair возвращает пару ключей пользователя userID
+func (s *userStorage) GetKeyPair(ctx context.Context, userID int) (*models.KeyPair, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT public_key, encrypted_private_key FROM users WHERE id = ($1) AND public_key IS NOT NULL`,
+		userID,
+	)
+	keyPair := &models.KeyPair{}
+	err := row.Scan(&keyPair.PublicKey, &keyPair.EncryptedPrivateKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrKeyPairNotFound
+	}
+	return keyPair, err
+}
+
+// GetPublicKey возвращает открытый ключ пользователя с указанным адресом электронной почты
+func (s *userStorage) GetPublicKey(ctx context.Context, email string) ([]byte, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT public_key FROM users WHERE email = ($1) AND public_key IS NOT NULL`,
+		email,
+	)
+	var publicKey []byte
+	err := row.Scan(&publicKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrKeyPairNotFound
+	}
+	return publicKey, err
+}
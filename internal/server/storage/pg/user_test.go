@@ -83,3 +83,86 @@ func TestPostgresStorage_PutUser(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestPostgresStorage_PutKeyPair(t *testing.T) {
+	s, mock := newUserMock()
+	userID := 1
+	keyPair := &models.KeyPair{
+		PublicKey:           []byte("public"),
+		EncryptedPrivateKey: []byte("private"),
+	}
+
+	t.Run("KeyPairExists", func(t *testing.T) {
+		mock.ExpectExec("UPDATE users SET public_key").
+			WithArgs(keyPair.PublicKey, keyPair.EncryptedPrivateKey, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := s.PutKeyPair(context.Background(), userID, keyPair)
+		assert.ErrorIs(t, err, storage.ErrKeyPairConflict)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("UPDATE users SET public_key").
+			WithArgs(keyPair.PublicKey, keyPair.EncryptedPrivateKey, userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.PutKeyPair(context.Background(), userID, keyPair)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresStorage_GetKeyPair(t *testing.T) {
+	s, mock := newUserMock()
+	userID := 1
+
+	t.Run("KeyPairNotFound", func(t *testing.T) {
+		mock.ExpectQuery("SELECT public_key, encrypted_private_key FROM users").
+			WithArgs(userID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetKeyPair(context.Background(), userID)
+		assert.ErrorIs(t, err, storage.ErrKeyPairNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT public_key, encrypted_private_key FROM users").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"public_key", "encrypted_private_key"}).
+				AddRow([]byte("public"), []byte("private")))
+
+		keyPair, err := s.GetKeyPair(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("public"), keyPair.PublicKey)
+		assert.Equal(t, []byte("private"), keyPair.EncryptedPrivateKey)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresStorage_GetPublicKey(t *testing.T) {
+	s, mock := newUserMock()
+	email := "test@mail.ru"
+
+	t.Run("KeyPairNotFound", func(t *testing.T) {
+		mock.ExpectQuery("SELECT public_key FROM users").
+			WithArgs(email).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := s.GetPublicKey(context.Background(), email)
+		assert.ErrorIs(t, err, storage.ErrKeyPairNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT public_key FROM users").
+			WithArgs(email).
+			WillReturnRows(sqlmock.NewRows([]string{"public_key"}).AddRow([]byte("public")))
+
+		publicKey, err := s.GetPublicKey(context.Background(), email)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("public"), publicKey)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
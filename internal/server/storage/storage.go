@@ -3,6 +3,9 @@ package storage
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/go-developer-ya-practicum/gophkeeper/internal/server/models"
 )
@@ -11,6 +14,9 @@ import (
 var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrUserConflict = errors.New("user conflict")
+
+	ErrKeyPairNotFound = errors.New("key pair not found")
+	ErrKeyPairConflict = errors.New("key pair conflict")
 )
 
 // UserStorage определяет интерфейс для хранения учетных данных пользователей
@@ -19,12 +25,21 @@ type UserStorage interface {
 	PutUser(ctx context.Context, user *models.User) (*models.User, error)
 	// GetUser возвращает ID пользователя с указанными учетными данными
 	GetUser(ctx context.Context, user *models.User) (*models.User, error)
+	// PutKeyPair сохраняет пару ключей пользователя userID, если она еще не задана
+	PutKeyPair(ctx context.Context, userID int, keyPair *models.KeyPair) error
+	// GetKeyPair возвращает пару ключей пользователя userID
+	GetKeyPair(ctx context.Context, userID int) (*models.KeyPair, error)
+	// GetPublicKey возвращает открытый ключ пользователя с указанным адресом электронной почты
+	GetPublicKey(ctx context.Context, email string) ([]byte, error)
 }
 
 // Возможные ошибки при работе с хранилищем SecretStorage
 var (
-	ErrSecretNotFound = errors.New("secret not found")
-	ErrSecretConflict = errors.New("secret conflict")
+	ErrSecretNotFound   = errors.New("secret not found")
+	ErrSecretConflict   = errors.New("secret conflict")
+	ErrVersionConflict  = errors.New("secret version conflict")
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrBlobNotFound     = errors.New("blob not found")
 )
 
 // SecretStorage определяет интерфейс для хранения приватных данных пользователей
@@ -33,10 +48,125 @@ type SecretStorage interface {
 	GetSecret(ctx context.Context, name string, userID int) (*models.Secret, error)
 	// CreateSecret создает новый секрет
 	CreateSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error)
-	// UpdateSecret обновляет содержимое секрета
+	// UpdateSecret обновляет содержимое секрета. Если задана версия secret.Version,
+	// секрет обновляется, только если она совпадает с текущей, иначе возвращается ErrVersionConflict
 	UpdateSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error)
-	// DeleteSecret удаляет секрет
+	// DeleteSecret перемещает секрет в корзину
 	DeleteSecret(ctx context.Context, secret *models.Secret) error
+	// RenameSecret переименовывает секрет name пользователя userID в newName
+	RenameSecret(ctx context.Context, name, newName string, userID int) (*models.Secret, error)
+	// CopySecret создает копию секрета name пользователя userID с названием newName
+	CopySecret(ctx context.Context, name, newName string, userID int) (*models.Secret, error)
 	// ListSecrets возвращает список всех секретов пользователя с указанным идентификатором
 	ListSecrets(ctx context.Context, userID int) ([]*models.Secret, error)
+	// ListDeletedSecrets возвращает список секретов пользователя, находящихся в корзине
+	ListDeletedSecrets(ctx context.Context, userID int) ([]*models.Secret, error)
+	// RestoreSecret восстанавливает из корзины последний удаленный секрет с указанным именем
+	RestoreSecret(ctx context.Context, name string, userID int) (*models.Secret, error)
+	// PurgeSecret окончательно удаляет из корзины секреты с указанным именем
+	PurgeSecret(ctx context.Context, name string, userID int) error
+	// PurgeExpiredSecrets окончательно удаляет секреты, перемещенные в корзину ранее deletedBefore,
+	// и возвращает количество удаленных записей
+	PurgeExpiredSecrets(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// ShareSecret предоставляет получателю доступ к секрету или изменяет права существующего доступа
+	ShareSecret(ctx context.Context, share *models.Share) error
+	// UnshareSecret отзывает у получателя recipientEmail доступ к секрету name пользователя ownerID
+	UnshareSecret(ctx context.Context, name string, ownerID int, recipientEmail string) error
+	// ListSecretShares возвращает список получателей, которым доступен секрет name пользователя ownerID
+	ListSecretShares(ctx context.Context, name string, ownerID int) ([]*models.Share, error)
+	// GetSharedSecret возвращает секрет name пользователя ownerEmail, доступный получателю recipientID
+	GetSharedSecret(ctx context.Context, name, ownerEmail string, recipientID int) (*models.SharedSecret, error)
+	// UpdateSharedSecret обновляет содержимое чужого секрета, доступного получателю на запись
+	UpdateSharedSecret(ctx context.Context, secret *models.SharedSecret) (*models.SharedSecret, error)
+	// ListSharedSecrets возвращает список чужих секретов, доступных получателю recipientID
+	ListSharedSecrets(ctx context.Context, recipientID int) ([]*models.SharedSecret, error)
+	// GetCollectionSecret возвращает секрет с указанным именем из коллекции collectionID
+	GetCollectionSecret(ctx context.Context, name string, collectionID int) (*models.Secret, error)
+	// CreateCollectionSecret создает новый секрет в коллекции
+	CreateCollectionSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error)
+	// UpdateCollectionSecret обновляет содержимое секрета коллекции
+	UpdateCollectionSecret(ctx context.Context, secret *models.Secret) (*models.Secret, error)
+	// DeleteCollectionSecret перемещает секрет коллекции в корзину
+	DeleteCollectionSecret(ctx context.Context, secret *models.Secret) error
+	// ListCollectionSecrets возвращает список всех секретов коллекции collectionID
+	ListCollectionSecrets(ctx context.Context, collectionID int) ([]*models.Secret, error)
+	// ListDeletedCollectionSecrets возвращает список секретов коллекции collectionID, находящихся в корзине
+	ListDeletedCollectionSecrets(ctx context.Context, collectionID int) ([]*models.Secret, error)
+	// RestoreCollectionSecret восстанавливает из корзины последний удаленный секрет коллекции с указанным именем
+	RestoreCollectionSecret(ctx context.Context, name string, collectionID int) (*models.Secret, error)
+	// PurgeCollectionSecret окончательно удаляет из корзины секреты коллекции с указанным именем
+	PurgeCollectionSecret(ctx context.Context, name string, collectionID int) error
+	// PutTemplate создает шаблон секретов или заменяет существующий шаблон с тем же названием
+	PutTemplate(ctx context.Context, template *models.Template) error
+	// GetTemplate возвращает шаблон name пользователя userID
+	GetTemplate(ctx context.Context, name string, userID int) (*models.Template, error)
+	// ListTemplates возвращает список шаблонов пользователя userID
+	ListTemplates(ctx context.Context, userID int) ([]*models.Template, error)
+	// DeleteTemplate удаляет шаблон name пользователя userID
+	DeleteTemplate(ctx context.Context, name string, userID int) error
+
+	// PutBlob сохраняет вложение секрета blob.SecretName пользователя blob.OwnerID и возвращает его идентификатор
+	PutBlob(ctx context.Context, blob *models.Blob) (*models.Blob, error)
+	// GetBlob возвращает вложение id секрета secretName пользователя userID
+	GetBlob(ctx context.Context, secretName string, id uuid.UUID, userID int) (*models.Blob, error)
+	// DeleteBlob удаляет вложение id секрета secretName пользователя userID
+	DeleteBlob(ctx context.Context, secretName string, id uuid.UUID, userID int) error
+}
+
+// Возможные ошибки при работе с хранилищем OrganizationStorage
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrOrganizationConflict = errors.New("organization conflict")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrTeamConflict         = errors.New("team conflict")
+	ErrCollectionNotFound   = errors.New("collection not found")
+	ErrCollectionConflict   = errors.New("collection conflict")
+)
+
+// OrganizationStorage определяет интерфейс для хранения организаций, их участников, команд и коллекций
+type OrganizationStorage interface {
+	// CreateOrganization создает организацию, владельцем которой становится пользователь ownerID
+	CreateOrganization(ctx context.Context, name string, ownerID int) (*models.Organization, error)
+	// ListOrganizations возвращает список организаций, участником которых является пользователь userID
+	ListOrganizations(ctx context.Context, userID int) ([]*models.Organization, error)
+	// GetRole возвращает роль пользователя userID в организации
+	GetRole(ctx context.Context, organization string, userID int) (models.Role, error)
+	// PutMember добавляет пользователя в организацию или изменяет его роль
+	PutMember(ctx context.Context, organization, email string, role models.Role) error
+	// RemoveMember исключает пользователя из организации и удаляет выданные ему ключи коллекций
+	RemoveMember(ctx context.Context, organization, email string) error
+	// ListMembers возвращает список участников организации
+	ListMembers(ctx context.Context, organization string) ([]*models.Member, error)
+	// CreateTeam создает команду в организации
+	CreateTeam(ctx context.Context, organization, team string) error
+	// ListTeams возвращает список команд организации
+	ListTeams(ctx context.Context, organization string) ([]*models.Team, error)
+	// AddTeamMember добавляет участника организации в команду
+	AddTeamMember(ctx context.Context, organization, team, email string) error
+	// RemoveTeamMember исключает участника из команды и удаляет ключи коллекций, ставших ему недоступными
+	RemoveTeamMember(ctx context.Context, organization, team, email string) error
+	// CreateCollection создает коллекцию и сохраняет ключ коллекции для пользователя creatorID
+	CreateCollection(ctx context.Context, organization, collection string, creatorID int, wrappedKey []byte) error
+	// ListCollections возвращает список коллекций организации, доступных пользователю userID
+	ListCollections(ctx context.Context, organization string, userID int) ([]*models.Collection, error)
+	// GrantCollection предоставляет команде доступ к коллекции
+	GrantCollection(ctx context.Context, organization, collection, team string) error
+	// RevokeCollection отзывает у команды доступ к коллекции и удаляет ключи коллекции,
+	// ставшие недоступными участникам команды
+	RevokeCollection(ctx context.Context, organization, collection, team string) error
+	// GetCollectionAccess возвращает права пользователя userID на коллекцию
+	GetCollectionAccess(ctx context.Context, organization, collection string, userID int) (*models.CollectionAccess, error)
+	// ListPendingCollectionKeys возвращает участников с доступом к коллекции,
+	// для которых еще не сохранен ключ коллекции
+	ListPendingCollectionKeys(ctx context.Context, collectionID int) ([]*models.Member, error)
+	// PutCollectionKey сохраняет ключ коллекции для участника с доступом к коллекции
+	PutCollectionKey(ctx context.Context, collectionID int, email string, wrappedKey []byte) error
+}
+
+// AuditStorage определяет интерфейс журнала аудита, допускающего только добавление записей
+type AuditStorage interface {
+	// PutEvent добавляет запись в журнал аудита
+	PutEvent(ctx context.Context, event *models.AuditEvent) error
+	// ListEvents возвращает записи журнала пользователя userID, созданные в промежутке [from, to)
+	ListEvents(ctx context.Context, userID int, from, to time.Time) ([]*models.AuditEvent, error)
 }
@@ -15,6 +15,9 @@ const (
 	minPasswordSize = 32
 	keySize         = 32
 	nonceSize       = 12
+
+	// DerivedKeySize размер ключа, вычисляемого по паролю функцией DeriveKey
+	DerivedKeySize = keySize
 )
 
 var (
@@ -35,9 +38,37 @@ func New(password string) (*Cipher, error) {
 	if len(password) < minPasswordSize {
 		return nil, ErrInvalidPasswordSize
 	}
-	key := sha256.Sum256([]byte(password))
+	key := make([]byte, DerivedKeySize)
+	if err := DeriveKey(password, key); err != nil {
+		return nil, err
+	}
+	return NewFromKey(key)
+}
+
+// DeriveKey вычисляет по паролю ключ шифрования и записывает его в key
+func DeriveKey(password string, key []byte) error {
+	if len(password) < minPasswordSize {
+		return ErrInvalidPasswordSize
+	}
+	if len(key) != DerivedKeySize {
+		return ErrInvalidKeySize
+	}
+	sum := sha256.Sum256([]byte(password))
+	copy(key, sum[:])
+	for i := range sum {
+		sum[i] = 0
+	}
+	return nil
+}
+
+// NewFromKey создает экземпляр Cipher по ключу, вычисленному функцией DeriveKey.
+// Ключ используется без копирования, что позволяет хранить его в защищенной памяти
+func NewFromKey(key []byte) (*Cipher, error) {
+	if len(key) != DerivedKeySize {
+		return nil, ErrInvalidKeySize
+	}
 	return &Cipher{
-		key:   key[:],
+		key:   key,
 		nonce: key[len(key)-nonceSize:],
 	}, nil
 }
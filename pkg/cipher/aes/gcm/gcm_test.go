@@ -24,6 +24,24 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestNewFromKey(t *testing.T) {
+	password := "11112222333344445555666677778888"
+
+	key := make([]byte, DerivedKeySize)
+	assert.NoError(t, DeriveKey(password, key))
+	assert.ErrorIs(t, DeriveKey("short", key), ErrInvalidPasswordSize)
+	assert.ErrorIs(t, DeriveKey(password, key[:1]), ErrInvalidKeySize)
+
+	fromKey, err := NewFromKey(key)
+	assert.NoError(t, err)
+	fromPassword, err := New(password)
+	assert.NoError(t, err)
+	assert.Equal(t, fromPassword, fromKey)
+
+	_, err = NewFromKey(key[:1])
+	assert.ErrorIs(t, err, ErrInvalidKeySize)
+}
+
 // https://boringssl.googlesource.com/boringssl/+/refs/heads/2564/crypto/cipher/test/cipher_test.txt
 var tests = []struct {
 	key        string
@@ -0,0 +1,67 @@
+package gcm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	blockCipher "github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/generate"
+)
+
+var (
+	// ErrInvalidKeySize ключ неподходящей длины
+	ErrInvalidKeySize = errors.New("invalid key size")
+	// ErrCiphertextTooShort шифротекст короче nonce
+	ErrCiphertextTooShort = errors.New("ciphertext too short")
+)
+
+var _ blockCipher.BlockCipher = (*KeyCipher)(nil)
+
+// KeyCipher блочный шифр AES в режиме GCM со случайным nonce,
+// который записывается в начало шифротекста
+type KeyCipher struct {
+	aead cipher.AEAD
+}
+
+// NewWithKey создает экземпляр KeyCipher с 256-битным ключом key
+func NewWithKey(key []byte) (*KeyCipher, error) {
+	if len(key) != keySize {
+		return nil, ErrInvalidKeySize
+	}
+
+	aesCipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aesGCM, err := cipher.NewGCM(aesCipher)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyCipher{aead: aesGCM}, nil
+}
+
+// NewKey генерирует случайный ключ для KeyCipher
+func NewKey() ([]byte, error) {
+	return generate.RandomBytes(keySize)
+}
+
+// Encrypt выполняет шифрование переданной байтовой последовательности
+func (c KeyCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce, err := generate.RandomBytes(c.aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt выполняет расшифрование переданной байтовой последовательности
+func (c KeyCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < c.aead.NonceSize() {
+		return nil, ErrCiphertextTooShort
+	}
+	nonce, ciphertext := ciphertext[:c.aead.NonceSize()], ciphertext[c.aead.NonceSize():]
+	return c.aead.Open(nil, nonce, ciphertext, nil)
+}
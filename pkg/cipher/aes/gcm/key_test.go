@@ -0,0 +1,69 @@
+package gcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithKey(t *testing.T) {
+	t.Run("InvalidKeySize", func(t *testing.T) {
+		_, err := NewWithKey([]byte("short"))
+		assert.ErrorIs(t, err, ErrInvalidKeySize)
+	})
+
+	t.Run("ValidKey", func(t *testing.T) {
+		key, err := NewKey()
+		require.NoError(t, err)
+		assert.Equal(t, keySize, len(key))
+
+		cipher, err := NewWithKey(key)
+		assert.NoError(t, err)
+		assert.NotNil(t, cipher)
+	})
+}
+
+func TestKeyCipher(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	cipher, err := NewWithKey(key)
+	require.NoError(t, err)
+
+	plaintext := []byte("secret data")
+
+	t.Run("EncryptDecrypt", func(t *testing.T) {
+		ciphertext, err := cipher.Encrypt(plaintext)
+		require.NoError(t, err)
+
+		decrypted, err := cipher.Decrypt(ciphertext)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("RandomNonce", func(t *testing.T) {
+		c1, err := cipher.Encrypt(plaintext)
+		require.NoError(t, err)
+		c2, err := cipher.Encrypt(plaintext)
+		require.NoError(t, err)
+		assert.NotEqual(t, c1, c2)
+	})
+
+	t.Run("WrongKey", func(t *testing.T) {
+		ciphertext, err := cipher.Encrypt(plaintext)
+		require.NoError(t, err)
+
+		otherKey, err := NewKey()
+		require.NoError(t, err)
+		other, err := NewWithKey(otherKey)
+		require.NoError(t, err)
+
+		_, err = other.Decrypt(ciphertext)
+		assert.Error(t, err)
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		_, err := cipher.Decrypt([]byte("short"))
+		assert.ErrorIs(t, err, ErrCiphertextTooShort)
+	})
+}
@@ -0,0 +1,76 @@
+package gcm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	blockCipher "github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/generate"
+)
+
+var _ blockCipher.BlockCipher = (*MasterCipher)(nil)
+
+// MasterCipher блочный шифр AES в режиме GCM для шифрования мастер-ключом.
+// Шифрует со случайным nonce, который записывается в начало шифротекста, и расшифровывает
+// также шифротексты Cipher с nonce, вычисленным по ключу, созданные предыдущими версиями клиента
+type MasterCipher struct {
+	key    []byte
+	legacy Cipher
+}
+
+// NewMaster создает экземпляр MasterCipher по паролю
+func NewMaster(password string) (*MasterCipher, error) {
+	key := make([]byte, DerivedKeySize)
+	if err := DeriveKey(password, key); err != nil {
+		return nil, err
+	}
+	return NewMasterFromKey(key)
+}
+
+// NewMasterFromKey создает экземпляр MasterCipher по ключу, вычисленному функцией DeriveKey.
+// Ключ используется без копирования, что позволяет хранить его в защищенной памяти
+func NewMasterFromKey(key []byte) (*MasterCipher, error) {
+	legacy, err := NewFromKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &MasterCipher{key: key, legacy: *legacy}, nil
+}
+
+// aead создает шифр при каждом вызове, чтобы ключ хранился только в переданном буфере
+func (c MasterCipher) aead() (cipher.AEAD, error) {
+	aesCipher, err := aes.NewCipher(c.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(aesCipher)
+}
+
+// Encrypt выполняет шифрование переданной байтовой последовательности со случайным nonce
+func (c MasterCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	aesGCM, err := c.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := generate.RandomBytes(aesGCM.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aesGCM.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt выполняет расшифрование переданной байтовой последовательности.
+// Если шифротекст не расшифровывается с nonce из его начала, он расшифровывается как шифротекст Cipher
+func (c MasterCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	aesGCM, err := c.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) >= aesGCM.NonceSize()+aesGCM.Overhead() {
+		nonce, sealed := ciphertext[:aesGCM.NonceSize()], ciphertext[aesGCM.NonceSize():]
+		if plaintext, err := aesGCM.Open(nil, nonce, sealed, nil); err == nil {
+			return plaintext, nil
+		}
+	}
+	return c.legacy.Decrypt(ciphertext)
+}
@@ -0,0 +1,62 @@
+package gcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMaster(t *testing.T) {
+	_, err := NewMaster("short")
+	assert.ErrorIs(t, err, ErrInvalidPasswordSize)
+
+	_, err = NewMasterFromKey([]byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKeySize)
+}
+
+func TestMasterCipher(t *testing.T) {
+	password := "11112222333344445555666677778888"
+	cipher, err := NewMaster(password)
+	require.NoError(t, err)
+
+	dataKey, err := NewKey()
+	require.NoError(t, err)
+
+	t.Run("RandomNonce", func(t *testing.T) {
+		c1, err := cipher.Encrypt(dataKey)
+		require.NoError(t, err)
+		c2, err := cipher.Encrypt(dataKey)
+		require.NoError(t, err)
+		assert.NotEqual(t, c1, c2)
+
+		for _, ciphertext := range [][]byte{c1, c2} {
+			decrypted, err := cipher.Decrypt(ciphertext)
+			require.NoError(t, err)
+			assert.Equal(t, dataKey, decrypted)
+		}
+	})
+
+	t.Run("LegacyCiphertext", func(t *testing.T) {
+		legacy, err := New(password)
+		require.NoError(t, err)
+		ciphertext, err := legacy.Encrypt(dataKey)
+		require.NoError(t, err)
+
+		decrypted, err := cipher.Decrypt(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, dataKey, decrypted)
+	})
+
+	t.Run("WrongKey", func(t *testing.T) {
+		ciphertext, err := cipher.Encrypt(dataKey)
+		require.NoError(t, err)
+
+		other, err := NewMaster("88887777666655554444333322221111")
+		require.NoError(t, err)
+		_, err = other.Decrypt(ciphertext)
+		assert.Error(t, err)
+		_, err = other.Decrypt([]byte("short"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,105 @@
+// Package x25519 реализует асимметричное шифрование данных для владельца открытого ключа X25519
+package x25519
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/generate"
+)
+
+// KeySize размер открытого и закрытого ключей X25519
+const KeySize = curve25519.ScalarSize
+
+const info = "gophkeeper x25519 sealed box"
+
+var (
+	// ErrInvalidKeySize ключ неподходящей длины
+	ErrInvalidKeySize = errors.New("invalid key size")
+	// ErrCiphertextTooShort шифротекст короче эфемерного ключа
+	ErrCiphertextTooShort = errors.New("ciphertext too short")
+)
+
+// GenerateKeyPair генерирует новую пару ключей X25519
+func GenerateKeyPair() (publicKey, privateKey []byte, err error) {
+	privateKey, err = generate.RandomBytes(KeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKey, err = curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return publicKey, privateKey, nil
+}
+
+// Seal шифрует plaintext для владельца открытого ключа publicKey.
+// Каждое сообщение шифруется ключом, выработанным из эфемерной пары ключей,
+// открытый ключ которой записывается в начало шифротекста
+func Seal(publicKey, plaintext []byte) ([]byte, error) {
+	if len(publicKey) != KeySize {
+		return nil, ErrInvalidKeySize
+	}
+
+	ephemeralPublic, ephemeralPrivate, err := GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	shared, err := curve25519.X25519(ephemeralPrivate, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cipher, err := newCipher(shared, ephemeralPublic, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := cipher.Encrypt(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return append(ephemeralPublic, ciphertext...), nil
+}
+
+// Open расшифровывает данные, зашифрованные Seal, закрытым ключом privateKey
+func Open(privateKey, ciphertext []byte) ([]byte, error) {
+	if len(privateKey) != KeySize {
+		return nil, ErrInvalidKeySize
+	}
+	if len(ciphertext) < KeySize {
+		return nil, ErrCiphertextTooShort
+	}
+
+	publicKey, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	ephemeralPublic, ciphertext := ciphertext[:KeySize], ciphertext[KeySize:]
+	shared, err := curve25519.X25519(privateKey, ephemeralPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	cipher, err := newCipher(shared, ephemeralPublic, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.Decrypt(ciphertext)
+}
+
+func newCipher(shared, ephemeralPublic, publicKey []byte) (*gcm.KeyCipher, error) {
+	salt := make([]byte, 0, 2*KeySize)
+	salt = append(salt, ephemeralPublic...)
+	salt = append(salt, publicKey...)
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(info)), key); err != nil {
+		return nil, err
+	}
+	return gcm.NewWithKey(key)
+}
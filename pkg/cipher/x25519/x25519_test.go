@@ -0,0 +1,61 @@
+package x25519
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	publicKey, privateKey, err := GenerateKeyPair()
+	require.NoError(t, err)
+	assert.Equal(t, KeySize, len(publicKey))
+	assert.Equal(t, KeySize, len(privateKey))
+	assert.NotEqual(t, publicKey, privateKey)
+}
+
+func TestSealOpen(t *testing.T) {
+	publicKey, privateKey, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	plaintext := []byte("data key")
+
+	t.Run("Success", func(t *testing.T) {
+		ciphertext, err := Seal(publicKey, plaintext)
+		require.NoError(t, err)
+
+		decrypted, err := Open(privateKey, ciphertext)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("EphemeralKey", func(t *testing.T) {
+		c1, err := Seal(publicKey, plaintext)
+		require.NoError(t, err)
+		c2, err := Seal(publicKey, plaintext)
+		require.NoError(t, err)
+		assert.NotEqual(t, c1[:KeySize], c2[:KeySize])
+	})
+
+	t.Run("WrongPrivateKey", func(t *testing.T) {
+		ciphertext, err := Seal(publicKey, plaintext)
+		require.NoError(t, err)
+
+		_, otherPrivateKey, err := GenerateKeyPair()
+		require.NoError(t, err)
+
+		_, err = Open(otherPrivateKey, ciphertext)
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidPublicKey", func(t *testing.T) {
+		_, err := Seal([]byte("short"), plaintext)
+		assert.ErrorIs(t, err, ErrInvalidKeySize)
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		_, err := Open(privateKey, []byte("short"))
+		assert.ErrorIs(t, err, ErrCiphertextTooShort)
+	})
+}
@@ -0,0 +1,9 @@
+// Package memlock позволяет запретить выгрузку чувствительных данных из оперативной памяти в swap
+package memlock
+
+// Wipe затирает содержимое среза нулями
+func Wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !(linux || darwin || freebsd || openbsd || netbsd || dragonfly || windows)
+
+package memlock
+
+// Lock не поддерживается на данной платформе
+func Lock(_ []byte) error {
+	return nil
+}
+
+// Unlock не поддерживается на данной платформе
+func Unlock(_ []byte) error {
+	return nil
+}
@@ -0,0 +1,23 @@
+package memlock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockUnlock(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	if err := Lock(key); err != nil {
+		t.Skipf("memory locking is not permitted: %v", err)
+	}
+	assert.NoError(t, Unlock(key))
+	assert.NoError(t, Lock(nil))
+}
+
+func TestWipe(t *testing.T) {
+	key := []byte("secret")
+	Wipe(key)
+	assert.Equal(t, make([]byte, 6), key)
+}
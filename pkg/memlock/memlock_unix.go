@@ -0,0 +1,21 @@
+//go:build linux || darwin || freebsd || openbsd || netbsd || dragonfly
+
+package memlock
+
+import "golang.org/x/sys/unix"
+
+// Lock запрещает выгрузку страниц памяти, занимаемых срезом, в swap
+func Lock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return unix.Mlock(b)
+}
+
+// Unlock снимает запрет на выгрузку страниц памяти, занимаемых срезом
+func Unlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return unix.Munlock(b)
+}
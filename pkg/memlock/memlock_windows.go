@@ -0,0 +1,25 @@
+//go:build windows
+
+package memlock
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Lock запрещает выгрузку страниц памяти, занимаемых срезом, в файл подкачки
+func Lock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return windows.VirtualLock(uintptr(unsafe.Pointer(&b[0])), uintptr(len(b)))
+}
+
+// Unlock снимает запрет на выгрузку страниц памяти, занимаемых срезом
+func Unlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return windows.VirtualUnlock(uintptr(unsafe.Pointer(&b[0])), uintptr(len(b)))
+}
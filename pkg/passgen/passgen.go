@@ -0,0 +1,183 @@
+// Package passgen генерирует пароли и парольные фразы с помощью криптографически стойкого генератора
+package passgen
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	lowerChars  = "abcdefghijklmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars  = "0123456789"
+	symbolChars = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+
+	// ambiguousChars символы, которые легко спутать при чтении
+	ambiguousChars = "Il1O0o|"
+
+	maxLength = 1024
+	maxWords  = 64
+)
+
+var (
+	// ErrInvalidLength недопустимая длина пароля
+	ErrInvalidLength = errors.New("invalid password length")
+	// ErrNoCharacterClasses не выбран ни один класс символов
+	ErrNoCharacterClasses = errors.New("no character classes selected")
+	// ErrInvalidWordCount недопустимое количество слов парольной фразы
+	ErrInvalidWordCount = errors.New("invalid passphrase word count")
+)
+
+//go:embed wordlist.txt
+var wordlistData string
+
+var wordlist = strings.Fields(wordlistData)
+
+// Options параметры генерации пароля
+type Options struct {
+	Length           int
+	Lower            bool
+	Upper            bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool
+}
+
+// DefaultOptions параметры генерации пароля по умолчанию
+var DefaultOptions = Options{
+	Length:  20,
+	Lower:   true,
+	Upper:   true,
+	Digits:  true,
+	Symbols: true,
+}
+
+// classes возвращает выбранные классы символов
+func (o Options) classes() []string {
+	var classes []string
+	for _, class := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{o.Lower, lowerChars},
+		{o.Upper, upperChars},
+		{o.Digits, digitChars},
+		{o.Symbols, symbolChars},
+	} {
+		if !class.enabled {
+			continue
+		}
+		chars := class.chars
+		if o.ExcludeAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(ambiguousChars, r) {
+					return -1
+				}
+				return r
+			}, chars)
+		}
+		classes = append(classes, chars)
+	}
+	return classes
+}
+
+// Entropy оценивает энтропию пароля в битах
+func (o Options) Entropy() float64 {
+	return float64(o.Length) * math.Log2(float64(len(strings.Join(o.classes(), ""))))
+}
+
+// Generate генерирует пароль, содержащий хотя бы один символ каждого выбранного класса
+func Generate(o Options) (string, error) {
+	classes := o.classes()
+	if len(classes) == 0 {
+		return "", ErrNoCharacterClasses
+	}
+	if o.Length < len(classes) || o.Length > maxLength {
+		return "", ErrInvalidLength
+	}
+
+	alphabet := strings.Join(classes, "")
+	password := make([]byte, 0, o.Length)
+	for _, class := range classes {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	for len(password) < o.Length {
+		c, err := randomChar(alphabet)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+// PassphraseOptions параметры генерации парольной фразы
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool
+}
+
+// DefaultPassphraseOptions параметры генерации парольной фразы по умолчанию
+var DefaultPassphraseOptions = PassphraseOptions{
+	Words:     6,
+	Separator: "-",
+}
+
+// Entropy возвращает энтропию парольной фразы в битах
+func (o PassphraseOptions) Entropy() float64 {
+	return float64(o.Words) * math.Log2(float64(len(wordlist)))
+}
+
+// Passphrase генерирует парольную фразу из слов встроенного словаря
+func Passphrase(o PassphraseOptions) (string, error) {
+	if o.Words <= 0 || o.Words > maxWords {
+		return "", ErrInvalidWordCount
+	}
+
+	words := make([]string, 0, o.Words)
+	for i := 0; i < o.Words; i++ {
+		index, err := randomInt(len(wordlist))
+		if err != nil {
+			return "", err
+		}
+		word := wordlist[index]
+		if o.Capitalize {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, o.Separator), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	i, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,69 @@
+package passgen
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr error
+	}{
+		{name: "Default", options: DefaultOptions},
+		{name: "Digits", options: Options{Length: 6, Digits: true}},
+		{name: "Exclude ambiguous", options: Options{Length: 64, Lower: true, Upper: true, Digits: true, ExcludeAmbiguous: true}},
+		{name: "No classes", options: Options{Length: 10}, wantErr: ErrNoCharacterClasses},
+		{name: "Too short", options: Options{Length: 3, Lower: true, Upper: true, Digits: true, Symbols: true}, wantErr: ErrInvalidLength},
+		{name: "Too long", options: Options{Length: maxLength + 1, Lower: true}, wantErr: ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, err := Generate(tt.options)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, password, tt.options.Length)
+
+			alphabet := strings.Join(tt.options.classes(), "")
+			for _, class := range tt.options.classes() {
+				assert.True(t, strings.ContainsAny(password, class), "no characters of class %q", class)
+			}
+			for _, r := range password {
+				assert.True(t, strings.ContainsRune(alphabet, r), "unexpected character %q", r)
+			}
+			if tt.options.ExcludeAmbiguous {
+				assert.False(t, strings.ContainsAny(password, ambiguousChars))
+			}
+		})
+	}
+}
+
+func TestOptions_Entropy(t *testing.T) {
+	assert.InDelta(t, 6*math.Log2(10), Options{Length: 6, Digits: true}.Entropy(), 1e-9)
+	assert.InDelta(t, 20*math.Log2(90), DefaultOptions.Entropy(), 1e-9)
+}
+
+func TestPassphrase(t *testing.T) {
+	assert.Len(t, wordlist, 4096)
+
+	passphrase, err := Passphrase(PassphraseOptions{Words: 5, Separator: " ", Capitalize: true})
+	require.NoError(t, err)
+	words := strings.Split(passphrase, " ")
+	require.Len(t, words, 5)
+	for _, word := range words {
+		assert.Equal(t, strings.ToUpper(word[:1]), word[:1])
+	}
+
+	_, err = Passphrase(PassphraseOptions{Words: 0})
+	assert.ErrorIs(t, err, ErrInvalidWordCount)
+
+	assert.Equal(t, 72.0, DefaultPassphraseOptions.Entropy())
+}
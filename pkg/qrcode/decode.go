@@ -0,0 +1,432 @@
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// errInvalidFormat не удалось прочитать информацию о формате символа
+var errInvalidFormat = errors.New("invalid format information")
+
+// matrix квадратная матрица модулей символа: true - темный модуль
+type matrix struct {
+	size    int
+	modules []bool
+}
+
+func newMatrix(size int) *matrix {
+	return &matrix{size: size, modules: make([]bool, size*size)}
+}
+
+func (m *matrix) get(x, y int) bool {
+	return m.modules[y*m.size+x]
+}
+
+func (m *matrix) set(x, y int, v bool) {
+	m.modules[y*m.size+x] = v
+}
+
+// setRegion отмечает прямоугольную область шириной width и высотой height
+func (m *matrix) setRegion(left, top, width, height int) {
+	for y := top; y < top+height; y++ {
+		for x := left; x < left+width; x++ {
+			m.set(x, y, true)
+		}
+	}
+}
+
+// format уровень коррекции ошибок (индекс L, M, Q, H) и номер маски символа
+type format struct {
+	level int
+	mask  int
+}
+
+// formatLevels преобразует биты уровня коррекции из информации о формате в индекс L, M, Q, H
+var formatLevels = [4]int{1, 0, 3, 2}
+
+// formatCodes кодовые слова информации о формате для всех 32 комбинаций уровня и маски
+var formatCodes = func() [32]uint32 {
+	var codes [32]uint32
+	for data := uint32(0); data < 32; data++ {
+		code := data << 10
+		for i := 14; i >= 10; i-- {
+			if code&(1<<i) != 0 {
+				code ^= 0x537 << (i - 10)
+			}
+		}
+		codes[data] = (data<<10 | code) ^ 0x5412
+	}
+	return codes
+}()
+
+// readFormat читает обе копии информации о формате и выбирает ближайшее допустимое кодовое слово
+func (m *matrix) readFormat() (format, error) {
+	var first, second uint32
+	read := func(bits uint32, x, y int) uint32 {
+		bits <<= 1
+		if m.get(x, y) {
+			bits |= 1
+		}
+		return bits
+	}
+	for x := 0; x < 6; x++ {
+		first = read(first, x, 8)
+	}
+	first = read(first, 7, 8)
+	first = read(first, 8, 8)
+	first = read(first, 8, 7)
+	for y := 5; y >= 0; y-- {
+		first = read(first, 8, y)
+	}
+	for y := m.size - 1; y >= m.size-7; y-- {
+		second = read(second, 8, y)
+	}
+	for x := m.size - 8; x < m.size; x++ {
+		second = read(second, x, 8)
+	}
+
+	best, bestDistance := 0, 16
+	for data, code := range formatCodes {
+		for _, candidate := range []uint32{first, second} {
+			if d := bits.OnesCount32(candidate ^ code); d < bestDistance {
+				best, bestDistance = data, d
+			}
+		}
+	}
+	if bestDistance > 3 {
+		return format{}, errInvalidFormat
+	}
+	return format{level: formatLevels[best>>3], mask: best & 7}, nil
+}
+
+// masked сообщает, инвертирует ли маска mask модуль в строке row и столбце column
+func masked(mask, row, column int) bool {
+	switch mask {
+	case 0:
+		return (row+column)%2 == 0
+	case 1:
+		return row%2 == 0
+	case 2:
+		return column%3 == 0
+	case 3:
+		return (row+column)%3 == 0
+	case 4:
+		return (row/2+column/3)%2 == 0
+	case 5:
+		return row*column%2+row*column%3 == 0
+	case 6:
+		return (row*column%2+row*column%3)%2 == 0
+	default:
+		return ((row+column)%2+row*column%3)%2 == 0
+	}
+}
+
+// functionPatterns возвращает матрицу служебных модулей символа версии version
+func functionPatterns(version int) *matrix {
+	size := 17 + 4*version
+	m := newMatrix(size)
+	// Поисковые узоры с разделителями и информацией о формате
+	m.setRegion(0, 0, 9, 9)
+	m.setRegion(size-8, 0, 8, 9)
+	m.setRegion(0, size-8, 9, 8)
+
+	positions := alignmentPositions[version]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if i == 0 && (j == 0 || j == last) || i == last && j == 0 {
+				continue
+			}
+			m.setRegion(x-2, y-2, 5, 5)
+		}
+	}
+
+	// Синхронизирующие полосы
+	m.setRegion(6, 9, 1, size-17)
+	m.setRegion(9, 6, size-17, 1)
+
+	// Информация о версии
+	if version >= 7 {
+		m.setRegion(size-11, 0, 3, 6)
+		m.setRegion(0, size-11, 6, 3)
+	}
+	return m
+}
+
+// readCodewords снимает маску и считывает кодовые слова в порядке размещения в символе
+func (m *matrix) readCodewords(version int, f format, total int) []byte {
+	function := functionPatterns(version)
+	codewords := make([]byte, 0, total)
+	var current byte
+	bitsRead := 0
+	up := true
+	for right := m.size - 1; right > 0; right -= 2 {
+		// Вертикальная синхронизирующая полоса пропускается целиком
+		if right == 6 {
+			right--
+		}
+		for count := 0; count < m.size; count++ {
+			y := count
+			if up {
+				y = m.size - 1 - count
+			}
+			for column := 0; column < 2; column++ {
+				x := right - column
+				if function.get(x, y) {
+					continue
+				}
+				current <<= 1
+				if m.get(x, y) != masked(f.mask, y, x) {
+					current |= 1
+				}
+				bitsRead++
+				if bitsRead == 8 {
+					codewords = append(codewords, current)
+					if len(codewords) == total {
+						return codewords
+					}
+					current, bitsRead = 0, 0
+				}
+			}
+		}
+		up = !up
+	}
+	return codewords
+}
+
+// decodeMatrix декодирует содержимое символа по матрице модулей
+func decodeMatrix(m *matrix) (string, error) {
+	version := (m.size - 17) / 4
+	if version < 1 || version > 40 || m.size != 17+4*version {
+		return "", fmt.Errorf("invalid symbol size %d", m.size)
+	}
+	f, err := m.readFormat()
+	if err != nil {
+		return "", err
+	}
+
+	ec := ecBlocks[version-1][f.level]
+	ecLen := ec[0]
+	var blocks [][]byte
+	var dataLens []int
+	for group := 0; group < 2; group++ {
+		for i := 0; i < ec[1+2*group]; i++ {
+			dataLens = append(dataLens, ec[2+2*group])
+			blocks = append(blocks, make([]byte, 0, ec[2+2*group]+ecLen))
+		}
+	}
+	total := 0
+	for _, n := range dataLens {
+		total += n + ecLen
+	}
+
+	codewords := m.readCodewords(version, f, total)
+	if len(codewords) != total {
+		return "", errors.New("not enough codewords")
+	}
+
+	// Кодовые слова блоков чередуются: сначала данные, затем коррекция ошибок
+	offset := 0
+	for i := 0; i < dataLens[len(dataLens)-1]; i++ {
+		for b := range blocks {
+			if i < dataLens[b] {
+				blocks[b] = append(blocks[b], codewords[offset])
+				offset++
+			}
+		}
+	}
+	for i := 0; i < ecLen; i++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], codewords[offset])
+			offset++
+		}
+	}
+
+	var data []byte
+	for b, block := range blocks {
+		if err = correctErrors(block, ecLen); err != nil {
+			return "", err
+		}
+		data = append(data, block[:dataLens[b]]...)
+	}
+	return decodeSegments(data, version)
+}
+
+// bitReader последовательно читает биты потока данных
+type bitReader struct {
+	data     []byte
+	position int
+}
+
+func (r *bitReader) available() int {
+	return len(r.data)*8 - r.position
+}
+
+func (r *bitReader) read(n int) (int, error) {
+	if n > r.available() {
+		return 0, errors.New("unexpected end of data")
+	}
+	value := 0
+	for i := 0; i < n; i++ {
+		value <<= 1
+		if r.data[r.position/8]&(0x80>>(r.position%8)) != 0 {
+			value |= 1
+		}
+		r.position++
+	}
+	return value, nil
+}
+
+// Режимы кодирования сегментов данных
+const (
+	modeTerminator       = 0x0
+	modeNumeric          = 0x1
+	modeAlphanumeric     = 0x2
+	modeStructuredAppend = 0x3
+	modeByte             = 0x4
+	modeFNC1First        = 0x5
+	modeECI              = 0x7
+	modeKanji            = 0x8
+	modeFNC1Second       = 0x9
+)
+
+// countBits возвращает длину поля количества символов сегмента
+func countBits(mode, version int) int {
+	index := 0
+	switch {
+	case version >= 27:
+		index = 2
+	case version >= 10:
+		index = 1
+	}
+	switch mode {
+	case modeNumeric:
+		return [3]int{10, 12, 14}[index]
+	case modeAlphanumeric:
+		return [3]int{9, 11, 13}[index]
+	case modeByte:
+		return [3]int{8, 16, 16}[index]
+	default:
+		return [3]int{8, 10, 12}[index]
+	}
+}
+
+// decodeSegments декодирует сегменты потока данных. Сегменты в байтовом режиме
+// возвращаются как есть, что соответствует UTF-8 и ASCII
+func decodeSegments(data []byte, version int) (string, error) {
+	r := &bitReader{data: data}
+	var b strings.Builder
+	for r.available() >= 4 {
+		mode, err := r.read(4)
+		if err != nil {
+			return "", err
+		}
+
+		switch mode {
+		case modeTerminator:
+			return b.String(), nil
+		case modeFNC1First, modeFNC1Second:
+			continue
+		case modeStructuredAppend:
+			if _, err = r.read(16); err != nil {
+				return "", err
+			}
+			continue
+		case modeECI:
+			// Назначение кодировки пропускается, содержимое читается как UTF-8
+			first, err := r.read(8)
+			if err != nil {
+				return "", err
+			}
+			extra := 0
+			switch {
+			case first&0x80 == 0:
+			case first&0xc0 == 0x80:
+				extra = 8
+			default:
+				extra = 16
+			}
+			if _, err = r.read(extra); err != nil {
+				return "", err
+			}
+			continue
+		case modeNumeric, modeAlphanumeric, modeByte:
+		default:
+			return "", fmt.Errorf("unsupported data mode %d", mode)
+		}
+
+		count, err := r.read(countBits(mode, version))
+		if err != nil {
+			return "", err
+		}
+		switch mode {
+		case modeNumeric:
+			err = readNumeric(r, count, &b)
+		case modeAlphanumeric:
+			err = readAlphanumeric(r, count, &b)
+		case modeByte:
+			err = readBytes(r, count, &b)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+func readNumeric(r *bitReader, count int, b *strings.Builder) error {
+	for count > 0 {
+		digits, width := 3, 10
+		switch count {
+		case 1:
+			digits, width = 1, 4
+		case 2:
+			digits, width = 2, 7
+		}
+		value, err := r.read(width)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "%0*d", digits, value)
+		count -= digits
+	}
+	return nil
+}
+
+func readAlphanumeric(r *bitReader, count int, b *strings.Builder) error {
+	for ; count >= 2; count -= 2 {
+		value, err := r.read(11)
+		if err != nil {
+			return err
+		}
+		if value/45 >= len(alphanumericChars) {
+			return errors.New("invalid alphanumeric data")
+		}
+		b.WriteByte(alphanumericChars[value/45])
+		b.WriteByte(alphanumericChars[value%45])
+	}
+	if count == 1 {
+		value, err := r.read(6)
+		if err != nil {
+			return err
+		}
+		if value >= len(alphanumericChars) {
+			return errors.New("invalid alphanumeric data")
+		}
+		b.WriteByte(alphanumericChars[value])
+	}
+	return nil
+}
+
+func readBytes(r *bitReader, count int, b *strings.Builder) error {
+	for i := 0; i < count; i++ {
+		value, err := r.read(8)
+		if err != nil {
+			return err
+		}
+		b.WriteByte(byte(value))
+	}
+	return nil
+}
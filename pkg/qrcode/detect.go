@@ -0,0 +1,268 @@
+package qrcode
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// bitmap бинаризованное изображение: true - темный пиксель
+type bitmap struct {
+	width  int
+	height int
+	pixels []bool
+}
+
+// binarize переводит изображение в оттенки серого и бинаризует его по порогу
+// посередине между самой темной и самой светлой точками
+func binarize(img image.Image) *bitmap {
+	bounds := img.Bounds()
+	b := &bitmap{
+		width:  bounds.Dx(),
+		height: bounds.Dy(),
+		pixels: make([]bool, bounds.Dx()*bounds.Dy()),
+	}
+
+	luminance := make([]uint32, len(b.pixels))
+	var minimum, maximum uint32 = math.MaxUint32, 0
+	for y := 0; y < b.height; y++ {
+		for x := 0; x < b.width; x++ {
+			r, g, bl, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			// Прозрачные пиксели считаются светлыми
+			l := (299*r+587*g+114*bl)/1000 + (0xffff - a)
+			luminance[y*b.width+x] = l
+			if l < minimum {
+				minimum = l
+			}
+			if l > maximum {
+				maximum = l
+			}
+		}
+	}
+
+	threshold := (minimum + maximum) / 2
+	for i, l := range luminance {
+		b.pixels[i] = l < threshold
+	}
+	return b
+}
+
+// dark сообщает, является ли пиксель темным. Точки за пределами изображения считаются светлыми
+func (b *bitmap) dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= b.width || y >= b.height {
+		return false
+	}
+	return b.pixels[y*b.width+x]
+}
+
+// point точка изображения
+type point struct {
+	x float64
+	y float64
+}
+
+func distance(a, b point) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+// finderPattern найденный поисковый узор: центр, размер модуля и количество строк, на которых он обнаружен
+type finderPattern struct {
+	center     point
+	moduleSize float64
+	count      int
+}
+
+// isFinderRatio проверяет, что длины пяти отрезков соотносятся как 1:1:3:1:1
+func isFinderRatio(runs [5]int) bool {
+	total := 0
+	for _, r := range runs {
+		if r == 0 {
+			return false
+		}
+		total += r
+	}
+	if total < 7 {
+		return false
+	}
+	module := float64(total) / 7
+	variance := module / 2
+	return math.Abs(module-float64(runs[0])) < variance &&
+		math.Abs(module-float64(runs[1])) < variance &&
+		math.Abs(3*module-float64(runs[2])) < 3*variance &&
+		math.Abs(module-float64(runs[3])) < variance &&
+		math.Abs(module-float64(runs[4])) < variance
+}
+
+// crossRuns измеряет отрезки узора вдоль линии, проходящей через темную точку (x, y) в направлении (dx, dy).
+// Возвращает длины отрезков и координату центра узора вдоль линии
+func (b *bitmap) crossRuns(x, y, dx, dy int) ([5]int, float64, bool) {
+	var runs [5]int
+	if !b.dark(x, y) {
+		return runs, 0, false
+	}
+
+	// Центральный темный отрезок
+	start, end := 0, 0
+	for b.dark(x-(start+1)*dx, y-(start+1)*dy) {
+		start++
+	}
+	for b.dark(x+(end+1)*dx, y+(end+1)*dy) {
+		end++
+	}
+	runs[2] = start + end + 1
+
+	step := func(from, direction int, dark bool) int {
+		n := 0
+		for {
+			px, py := x+(from+n+1)*direction*dx, y+(from+n+1)*direction*dy
+			if px < 0 || py < 0 || px >= b.width || py >= b.height || b.dark(px, py) != dark {
+				return n
+			}
+			n++
+		}
+	}
+	runs[1] = step(start, -1, false)
+	runs[0] = step(start+runs[1], -1, true)
+	runs[3] = step(end, 1, false)
+	runs[4] = step(end+runs[3], 1, true)
+
+	position := x
+	if dx == 0 {
+		position = y
+	}
+	center := float64(position-start) + float64(runs[2])/2
+	return runs, center, isFinderRatio(runs)
+}
+
+// findFinderPatterns ищет поисковые узоры построчным сканированием с проверкой по вертикали
+func (b *bitmap) findFinderPatterns() []finderPattern {
+	var patterns []finderPattern
+	for y := 0; y < b.height; y++ {
+		// Длины чередующихся отрезков строки, начиная с первого темного
+		var runs []int
+		var starts []int
+		for x := 0; x < b.width; {
+			dark := b.dark(x, y)
+			start := x
+			for x < b.width && b.dark(x, y) == dark {
+				x++
+			}
+			if len(runs) == 0 && !dark {
+				continue
+			}
+			runs = append(runs, x-start)
+			starts = append(starts, start)
+		}
+
+		for i := 0; i+4 < len(runs); i += 2 {
+			if !isFinderRatio([5]int{runs[i], runs[i+1], runs[i+2], runs[i+3], runs[i+4]}) {
+				continue
+			}
+			cx := starts[i+2] + runs[i+2]/2
+			vertical, cy, ok := b.crossRuns(cx, y, 0, 1)
+			if !ok {
+				continue
+			}
+			horizontal, refinedX, ok := b.crossRuns(cx, int(cy), 1, 0)
+			if !ok {
+				continue
+			}
+			moduleSize := float64(sum(vertical)+sum(horizontal)) / 14
+			patterns = addFinderPattern(patterns, point{x: refinedX, y: cy}, moduleSize)
+		}
+	}
+	return patterns
+}
+
+// addFinderPattern объединяет найденный центр с близким ранее найденным узором или добавляет новый узор
+func addFinderPattern(patterns []finderPattern, center point, moduleSize float64) []finderPattern {
+	for i, p := range patterns {
+		if distance(p.center, center) <= math.Max(p.moduleSize, moduleSize) &&
+			math.Abs(p.moduleSize-moduleSize) <= math.Max(p.moduleSize, moduleSize)/2 {
+			n := float64(p.count)
+			patterns[i] = finderPattern{
+				center: point{
+					x: (p.center.x*n + center.x) / (n + 1),
+					y: (p.center.y*n + center.y) / (n + 1),
+				},
+				moduleSize: (p.moduleSize*n + moduleSize) / (n + 1),
+				count:      p.count + 1,
+			}
+			return patterns
+		}
+	}
+	return append(patterns, finderPattern{center: center, moduleSize: moduleSize, count: 1})
+}
+
+// orderFinderPatterns выбирает три наиболее надежных узора и упорядочивает их:
+// верхний левый, верхний правый и нижний левый относительно символа
+func orderFinderPatterns(patterns []finderPattern) (topLeft, topRight, bottomLeft finderPattern, ok bool) {
+	if len(patterns) < 3 {
+		return topLeft, topRight, bottomLeft, false
+	}
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return patterns[i].count > patterns[j].count
+	})
+	a, b, c := patterns[0], patterns[1], patterns[2]
+
+	// Верхний левый узор лежит напротив самой длинной стороны треугольника
+	ab, bc, ac := distance(a.center, b.center), distance(b.center, c.center), distance(a.center, c.center)
+	switch {
+	case bc >= ab && bc >= ac:
+		topLeft, topRight, bottomLeft = a, b, c
+	case ac >= ab && ac >= bc:
+		topLeft, topRight, bottomLeft = b, a, c
+	default:
+		topLeft, topRight, bottomLeft = c, a, b
+	}
+
+	// В системе координат изображения ось y направлена вниз
+	cross := (topRight.center.x-topLeft.center.x)*(bottomLeft.center.y-topLeft.center.y) -
+		(topRight.center.y-topLeft.center.y)*(bottomLeft.center.x-topLeft.center.x)
+	if cross < 0 {
+		topRight, bottomLeft = bottomLeft, topRight
+	}
+	return topLeft, topRight, bottomLeft, true
+}
+
+// estimateDimension оценивает количество модулей на стороне символа по расстоянию между узорами
+func estimateDimension(topLeft, topRight, bottomLeft finderPattern) int {
+	moduleSize := (topLeft.moduleSize + topRight.moduleSize + bottomLeft.moduleSize) / 3
+	modules := (distance(topLeft.center, topRight.center) + distance(topLeft.center, bottomLeft.center)) / (2 * moduleSize)
+	dimension := int(math.Round(modules)) + 7
+	switch dimension % 4 {
+	case 0:
+		dimension++
+	case 2:
+		dimension--
+	case 3:
+		dimension -= 2
+	}
+	return dimension
+}
+
+// sample считывает матрицу модулей символа размера dimension по центрам поисковых узоров
+func (b *bitmap) sample(topLeft, topRight, bottomLeft point, dimension int) *matrix {
+	m := newMatrix(dimension)
+	scale := float64(dimension - 7)
+	ux, uy := (topRight.x-topLeft.x)/scale, (topRight.y-topLeft.y)/scale
+	vx, vy := (bottomLeft.x-topLeft.x)/scale, (bottomLeft.y-topLeft.y)/scale
+	for y := 0; y < dimension; y++ {
+		for x := 0; x < dimension; x++ {
+			// Центр верхнего левого узора совпадает с центром модуля (3, 3)
+			fx, fy := float64(x-3), float64(y-3)
+			px := topLeft.x + fx*ux + fy*vx
+			py := topLeft.y + fx*uy + fy*vy
+			m.set(x, y, b.dark(int(math.Floor(px)), int(math.Floor(py))))
+		}
+	}
+	return m
+}
+
+func sum(runs [5]int) int {
+	total := 0
+	for _, r := range runs {
+		total += r
+	}
+	return total
+}
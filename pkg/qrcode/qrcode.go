@@ -0,0 +1,59 @@
+// Package qrcode распознает QR-коды на изображениях, например, QR-коды настройки
+// двухфакторной аутентификации. Поддерживаются четкие изображения без перспективных искажений,
+// в том числе повернутые на угол, кратный 90 градусам
+package qrcode
+
+import (
+	"errors"
+	"image"
+	"os"
+
+	// Поддержка форматов изображений QR-кодов
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+var (
+	// ErrNotFound на изображении не найден QR-код
+	ErrNotFound = errors.New("qr code not found")
+	// ErrDecode не удалось декодировать найденный QR-код
+	ErrDecode = errors.New("failed to decode qr code")
+)
+
+// Decode распознает QR-код на изображении и возвращает его содержимое
+func Decode(img image.Image) (string, error) {
+	b := binarize(img)
+	topLeft, topRight, bottomLeft, ok := orderFinderPatterns(b.findFinderPatterns())
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	// Размер символа оценивается по расстоянию между узорами, при ошибке проверяются соседние версии
+	dimension := estimateDimension(topLeft, topRight, bottomLeft)
+	for _, size := range []int{dimension, dimension - 4, dimension + 4} {
+		if size < 21 || size > 177 {
+			continue
+		}
+		m := b.sample(topLeft.center, topRight.center, bottomLeft.center, size)
+		if content, err := decodeMatrix(m); err == nil {
+			return content, nil
+		}
+	}
+	return "", ErrDecode
+}
+
+// DecodeFile распознает QR-код на изображении в формате PNG, JPEG или GIF
+func DecodeFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return "", err
+	}
+	return Decode(img)
+}
@@ -0,0 +1,158 @@
+package qrcode
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	encoder "github.com/skip2/go-qrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, content string, level encoder.RecoveryLevel, size int) image.Image {
+	t.Helper()
+	code, err := encoder.New(content, level)
+	require.NoError(t, err)
+	return code.Image(size)
+}
+
+// rotate поворачивает изображение на 90 градусов по часовой стрелке
+func rotate(img image.Image) image.Image {
+	bounds := img.Bounds()
+	rotated := image.NewGray(image.Rect(0, 0, bounds.Dy(), bounds.Dx()))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			rotated.Set(bounds.Dy()-1-y, x, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return rotated
+}
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		level   encoder.RecoveryLevel
+		size    int
+	}{
+		{
+			name:    "TOTP URI",
+			content: "otpauth://totp/Example:alice@google.com?secret=JBSWY3DPEHPK3PXP&issuer=Example",
+			level:   encoder.Medium,
+			size:    256,
+		},
+		{
+			name:    "Numeric",
+			content: "0123456789012345",
+			level:   encoder.Low,
+			size:    100,
+		},
+		{
+			name:    "Alphanumeric",
+			content: "HELLO WORLD $%*+-./:",
+			level:   encoder.Highest,
+			size:    200,
+		},
+		{
+			name:    "Large version",
+			content: strings.Repeat("otpauth://totp/GitHub:gopher?secret=JBSWY3DPEHPK3PXP&issuer=GitHub ", 8),
+			level:   encoder.High,
+			size:    800,
+		},
+		{
+			name:    "UTF-8",
+			content: "otpauth://totp/Сервис:гофер?secret=JBSWY3DPEHPK3PXP",
+			level:   encoder.Medium,
+			size:    300,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			img := encode(t, tc.content, tc.level, tc.size)
+			for i := 0; i < 4; i++ {
+				content, err := Decode(img)
+				require.NoError(t, err, "rotation %d", i*90)
+				assert.Equal(t, tc.content, content)
+				img = rotate(img)
+			}
+		})
+	}
+}
+
+func TestDecodeCorrectsErrors(t *testing.T) {
+	content := "otpauth://totp/Example:alice@google.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"
+	code, err := encoder.New(content, encoder.High)
+	require.NoError(t, err)
+	code.DisableBorder = false
+	bitmap := code.Bitmap()
+
+	// Инвертирует несколько модулей области данных
+	for _, module := range [][2]int{{14, 14}, {15, 20}, {20, 15}, {22, 24}, {17, 30}} {
+		x, y := module[0], module[1]
+		bitmap[y][x] = !bitmap[y][x]
+	}
+	img := image.NewGray(image.Rect(0, 0, len(bitmap)*4, len(bitmap)*4))
+	for y := range bitmap {
+		for x := range bitmap[y] {
+			c := color.Gray{Y: 255}
+			if bitmap[y][x] {
+				c = color.Gray{Y: 0}
+			}
+			for dy := 0; dy < 4; dy++ {
+				for dx := 0; dx < 4; dx++ {
+					img.SetGray(x*4+dx, y*4+dy, c)
+				}
+			}
+		}
+	}
+
+	decoded, err := Decode(img)
+	require.NoError(t, err)
+	assert.Equal(t, content, decoded)
+}
+
+func TestDecodeNotFound(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 100, 100))
+	_, err := Decode(img)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDecodeFile(t *testing.T) {
+	content := "otpauth://totp/GitHub:gopher?secret=JBSWY3DPEHPK3PXP&issuer=GitHub"
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, encode(t, content, encoder.Medium, 256)))
+	path := filepath.Join(t.TempDir(), "qr.png")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+	decoded, err := DecodeFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, decoded)
+}
+
+func TestCorrectErrors(t *testing.T) {
+	// Блок версии 1-M: 16 кодовых слов данных и 10 кодовых слов коррекции
+	block := []byte{
+		0x10, 0x20, 0x0c, 0x56, 0x61, 0x80, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11,
+		0xa5, 0x24, 0xd4, 0xc1, 0xed, 0x36, 0xc7, 0x87, 0x2c, 0x55,
+	}
+	original := append([]byte(nil), block...)
+
+	require.NoError(t, correctErrors(block, 10))
+	assert.Equal(t, original, block)
+
+	for _, i := range []int{0, 3, 7, 12, 20} {
+		block[i] ^= byte(i + 1)
+	}
+	require.NoError(t, correctErrors(block, 10))
+	assert.Equal(t, original, block)
+
+	for _, i := range []int{0, 2, 4, 6, 8, 10} {
+		block[i] ^= 0xff
+	}
+	assert.Error(t, correctErrors(block, 10))
+}
@@ -0,0 +1,164 @@
+package qrcode
+
+import "errors"
+
+// errTooManyErrors количество ошибок в блоке превышает корректирующую способность кода
+var errTooManyErrors = errors.New("too many errors")
+
+// Арифметика поля GF(256) с порождающим многочленом x^8 + x^4 + x^3 + x^2 + 1
+var (
+	gfExp [512]byte
+	gfLog [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+255-gfLog[b]]
+}
+
+// gfPow возвращает α^n
+func gfPow(n int) byte {
+	n %= 255
+	if n < 0 {
+		n += 255
+	}
+	return gfExp[n]
+}
+
+// polyEval вычисляет многочлен с коэффициентами по возрастанию степеней в точке x
+func polyEval(p []byte, x byte) byte {
+	var y byte
+	for i := len(p) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ p[i]
+	}
+	return y
+}
+
+// correctErrors исправляет ошибки в блоке block, последние ecLen байт которого содержат
+// кодовые слова коррекции. Первый байт блока соответствует старшей степени многочлена
+func correctErrors(block []byte, ecLen int) error {
+	n := len(block)
+	syndromes, ok := computeSyndromes(block, ecLen)
+	if ok {
+		return nil
+	}
+
+	// Многочлен локаторов ошибок алгоритмом Берлекэмпа-Мэсси
+	locator := []byte{1}
+	previous := []byte{1}
+	errorsCount, shift := 0, 1
+	var lastDiscrepancy byte = 1
+	for k := 0; k < ecLen; k++ {
+		discrepancy := syndromes[k]
+		for i := 1; i <= errorsCount && i < len(locator); i++ {
+			discrepancy ^= gfMul(locator[i], syndromes[k-i])
+		}
+		if discrepancy == 0 {
+			shift++
+			continue
+		}
+
+		scale := gfDiv(discrepancy, lastDiscrepancy)
+		next := make([]byte, max(len(locator), len(previous)+shift))
+		copy(next, locator)
+		for i, c := range previous {
+			next[i+shift] ^= gfMul(scale, c)
+		}
+		if 2*errorsCount <= k {
+			previous = locator
+			errorsCount = k + 1 - errorsCount
+			lastDiscrepancy = discrepancy
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = next
+	}
+	if 2*errorsCount > ecLen {
+		return errTooManyErrors
+	}
+
+	// Позиции ошибок - корни многочлена локаторов (поиск Ченя)
+	var positions []int
+	for p := 0; p < n; p++ {
+		if polyEval(locator, gfPow(-p)) == 0 {
+			positions = append(positions, p)
+		}
+	}
+	if len(positions) != errorsCount {
+		return errTooManyErrors
+	}
+
+	// Величины ошибок по формуле Форни
+	evaluator := make([]byte, ecLen)
+	for i := 0; i < ecLen; i++ {
+		for j := 0; j <= i && j < len(locator); j++ {
+			evaluator[i] ^= gfMul(syndromes[i-j], locator[j])
+		}
+	}
+	derivative := make([]byte, len(locator))
+	for i := 1; i < len(locator); i += 2 {
+		derivative[i-1] = locator[i]
+	}
+	for _, p := range positions {
+		xInverse := gfPow(-p)
+		denominator := polyEval(derivative, xInverse)
+		if denominator == 0 {
+			return errTooManyErrors
+		}
+		magnitude := gfMul(gfPow(p), gfDiv(polyEval(evaluator, xInverse), denominator))
+		block[n-1-p] ^= magnitude
+	}
+
+	if _, ok = computeSyndromes(block, ecLen); !ok {
+		return errTooManyErrors
+	}
+	return nil
+}
+
+// computeSyndromes вычисляет синдромы блока и сообщает, равны ли все они нулю
+func computeSyndromes(block []byte, ecLen int) ([]byte, bool) {
+	syndromes := make([]byte, ecLen)
+	ok := true
+	for i := range syndromes {
+		var s byte
+		x := gfPow(i)
+		for _, c := range block {
+			s = gfMul(s, x) ^ c
+		}
+		syndromes[i] = s
+		ok = ok && s == 0
+	}
+	return syndromes, ok
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
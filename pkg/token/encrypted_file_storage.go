@@ -0,0 +1,136 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher"
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+)
+
+// EncryptedFileStorage файловое хранилище токена, зашифрованного мастер-ключом.
+// Токен шифруется случайным ключом данных, который хранится в файле зашифрованным мастер-ключом.
+// Шифр мастер-ключа должен использовать случайный nonce, как gcm.MasterCipher
+type EncryptedFileStorage struct {
+	Path   string
+	Cipher cipher.BlockCipher
+}
+
+var _ Storage = (*EncryptedFileStorage)(nil)
+
+type encryptedToken struct {
+	DataKey []byte `json:"data_key"`
+	Token   []byte `json:"token"`
+}
+
+// NewEncryptedFileStorage создает файловое хранилище токена, зашифрованного шифром blockCipher
+func NewEncryptedFileStorage(path string, blockCipher cipher.BlockCipher) *EncryptedFileStorage {
+	return &EncryptedFileStorage{
+		Path:   path,
+		Cipher: blockCipher,
+	}
+}
+
+// ProfilePath возвращает путь к файлу токена профиля в каталоге настроек пользователя
+func ProfilePath(profile string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gophkeeper", "tokens", profile+".token"), nil
+}
+
+// Save шифрует токен и атомарно записывает его в файл, доступный только пользователю
+func (s *EncryptedFileStorage) Save(accessToken string) error {
+	dataKey, err := gcm.NewKey()
+	if err != nil {
+		return err
+	}
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return err
+	}
+
+	var encrypted encryptedToken
+	if encrypted.DataKey, err = s.Cipher.Encrypt(dataKey); err != nil {
+		return err
+	}
+	if encrypted.Token, err = dataCipher.Encrypt([]byte(accessToken)); err != nil {
+		return err
+	}
+	data, err := json.Marshal(encrypted)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.Path)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if err = file.Chmod(0600); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.Path)
+}
+
+// Load читает токен из файла и расшифровывает его
+func (s *EncryptedFileStorage) Load() (string, error) {
+	encrypted, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := s.Cipher.Decrypt(encrypted.DataKey)
+	if err != nil {
+		return "", err
+	}
+	dataCipher, err := gcm.NewWithKey(dataKey)
+	if err != nil {
+		return "", err
+	}
+	accessToken, err := dataCipher.Decrypt(encrypted.Token)
+	if err != nil {
+		return "", err
+	}
+	return string(accessToken), nil
+}
+
+// EncryptedDataKey возвращает ключ данных токена, зашифрованный мастер-ключом.
+// Позволяет проверить мастер-ключ без расшифрования токена
+func (s *EncryptedFileStorage) EncryptedDataKey() ([]byte, error) {
+	encrypted, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return encrypted.DataKey, nil
+}
+
+func (s *EncryptedFileStorage) read() (encryptedToken, error) {
+	var encrypted encryptedToken
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return encrypted, ErrNotFound
+	}
+	if err != nil {
+		return encrypted, err
+	}
+	err = json.Unmarshal(data, &encrypted)
+	return encrypted, err
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 
@@ -23,7 +24,7 @@ func NewFileStorage(path string) *FileStorage {
 
 // Save записывает токен в файл
 func (s *FileStorage) Save(accessToken string) error {
-	file, err := os.Create(s.Path)
+	file, err := os.OpenFile(s.Path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
@@ -41,8 +42,11 @@ func (s *FileStorage) Save(accessToken string) error {
 // Load читает токен из файла
 func (s *FileStorage) Load() (string, error) {
 	file, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotFound
+	}
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 	defer func() {
 		if err = file.Close(); err != nil {
@@ -0,0 +1,96 @@
+package token
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-developer-ya-practicum/gophkeeper/pkg/cipher/aes/gcm"
+)
+
+func TestFileStorage(t *testing.T) {
+	storage := NewFileStorage(filepath.Join(t.TempDir(), "token.txt"))
+
+	_, err := storage.Load()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, storage.Save("AccessToken"))
+	accessToken, err := storage.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "AccessToken", accessToken)
+
+	_, err = NewFileStorage(t.TempDir()).Load()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestEncryptedFileStorage(t *testing.T) {
+	blockCipher, err := gcm.NewMaster("11112222333344445555666677778888")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "tokens", "default.token")
+	storage := NewEncryptedFileStorage(path, blockCipher)
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := storage.Load()
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = storage.EncryptedDataKey()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("SaveLoad", func(t *testing.T) {
+		require.NoError(t, storage.Save("AccessToken"))
+		require.NoError(t, storage.Save("NewAccessToken"))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "AccessToken")
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+
+		accessToken, err := storage.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "NewAccessToken", accessToken)
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+	t.Run("RandomNonce", func(t *testing.T) {
+		readDataKey := func() []byte {
+			dataKey, err := storage.EncryptedDataKey()
+			require.NoError(t, err)
+			_, err = blockCipher.Decrypt(dataKey)
+			require.NoError(t, err)
+			return dataKey
+		}
+
+		require.NoError(t, storage.Save("AccessToken"))
+		first := readDataKey()
+		require.NoError(t, storage.Save("AccessToken"))
+		assert.NotEqual(t, first, readDataKey())
+	})
+	t.Run("LegacyCipher", func(t *testing.T) {
+		legacyCipher, err := gcm.New("11112222333344445555666677778888")
+		require.NoError(t, err)
+		legacyPath := filepath.Join(t.TempDir(), "legacy.token")
+		require.NoError(t, NewEncryptedFileStorage(legacyPath, legacyCipher).Save("AccessToken"))
+
+		accessToken, err := NewEncryptedFileStorage(legacyPath, blockCipher).Load()
+		require.NoError(t, err)
+		assert.Equal(t, "AccessToken", accessToken)
+	})
+	t.Run("WrongKey", func(t *testing.T) {
+		otherCipher, err := gcm.NewMaster("88887777666655554444333322221111")
+		require.NoError(t, err)
+
+		_, err = NewEncryptedFileStorage(path, otherCipher).Load()
+		assert.Error(t, err)
+	})
+}
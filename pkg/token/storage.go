@@ -1,8 +1,13 @@
 package token
 
+import "errors"
+
+// ErrNotFound токен не сохранен
+var ErrNotFound = errors.New("token not found")
+
 // Storage интерфейс сохранения из загрузки токена
 type Storage interface {
-	// Load загружает токен
+	// Load загружает токен; если токен не сохранен, возвращает ErrNotFound
 	Load() (accessToken string, err error)
 	// Save сохраняет токен
 	Save(accessToken string) error
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WritePrivateFile атомарно записывает данные в файл, доступный только владельцу
+func WritePrivateFile(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if err = file.Chmod(0600); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
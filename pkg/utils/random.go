@@ -15,7 +15,8 @@ var errInvalidLength = errors.New("invalid length")
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 
-// RandomString генерирует случайную строку заданной длины из символов letters
+// RandomString генерирует случайную строку заданной длины из символов letters.
+// Использует math/rand и не подходит для генерации паролей, для них предназначен пакет passgen
 func RandomString(length int) (string, error) {
 	if length <= 0 {
 		return "", errInvalidLength
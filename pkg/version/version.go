@@ -23,6 +23,17 @@ var (
 	BuildCommit string
 )
 
+// MetadataKey ключ метаданных gRPC, в котором сервер передает клиенту свою версию
+const MetadataKey = "server-version"
+
+// Version возвращает версию исполняемого файла или N/A, если версия не задана при сборке
+func Version() string {
+	if BuildVersion == "" {
+		return "N/A"
+	}
+	return BuildVersion
+}
+
 type buildInfo struct {
 	Version string
 	Date    string
@@ -32,14 +43,11 @@ type buildInfo struct {
 // WriteBuildInfo записывает информацию о сборке
 func WriteBuildInfo(w io.Writer) {
 	info := buildInfo{
-		Version: "N/A",
+		Version: Version(),
 		Date:    "N/A",
 		Commit:  "N/A",
 	}
 
-	if BuildVersion != "" {
-		info.Version = BuildVersion
-	}
 	if BuildDate != "" {
 		info.Date = BuildDate
 	}